@@ -15,13 +15,17 @@ import (
 	"github.com/pkg/errors"
 
 	_ "github.com/pivotal/kpack/internal/logrus/fatal"
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/blob"
 	"github.com/pivotal/kpack/pkg/buildchange"
 	"github.com/pivotal/kpack/pkg/cnb"
+	"github.com/pivotal/kpack/pkg/cosign"
 	"github.com/pivotal/kpack/pkg/dockercreds"
 	"github.com/pivotal/kpack/pkg/flaghelpers"
 	"github.com/pivotal/kpack/pkg/git"
 	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/secret"
+	"github.com/pivotal/kpack/pkg/volume"
 )
 
 var (
@@ -31,14 +35,25 @@ var (
 	gitURL                  = flag.String("git-url", os.Getenv("GIT_URL"), "The url of the Git repository to initialize.")
 	gitRevision             = flag.String("git-revision", os.Getenv("GIT_REVISION"), "The Git revision to make the repository HEAD.")
 	gitInitializeSubmodules = flag.Bool("git-initialize-submodules", getenvBool("GIT_INITIALIZE_SUBMODULES"), "Initialize submodules during git clone")
+	gitLFS                  = flag.Bool("git-lfs", getenvBool("GIT_LFS"), "Download Git LFS objects during git clone")
+	gitSparseCheckout       = flag.Bool("git-sparse-checkout", getenvBool("GIT_SPARSE_CHECKOUT"), "Only checkout the source sub path and sparse checkout paths")
+	gitSparseCheckoutPaths  = flag.String("git-sparse-checkout-paths", os.Getenv("GIT_SPARSE_CHECKOUT_PATHS"), "Comma separated directories to checkout in addition to the source sub path")
+	gitVerifySignature      = flag.Bool("git-verify-signature", getenvBool("GIT_VERIFY_SIGNATURE"), "Require the commit to be signed by a key from the source verification secret")
 	blobURL                 = flag.String("blob-url", os.Getenv("BLOB_URL"), "The url of the source code blob.")
 	blobAuth                = flag.Bool("blob-auth", getenvBool("BLOB_AUTH"), "If authentication should be used for blobs")
 	stripComponents         = flag.Int("strip-components", getenvInt("BLOB_STRIP_COMPONENTS", 0), "The number of directory components to strip from the blobs content when extracting.")
 	registryImage           = flag.String("registry-image", os.Getenv("REGISTRY_IMAGE"), "The registry location of the source code image.")
+	registryDigest          = flag.String("registry-digest", os.Getenv("REGISTRY_DIGEST"), "The digest the source code image was resolved to.")
+	registryVerifySignature = flag.Bool("registry-verify-signature", getenvBool("REGISTRY_VERIFY_SIGNATURE"), "Require the source code image to be signed by the key from the source verification secret")
+	volumeSource            = flag.String("volume-source", os.Getenv("VOLUME_SOURCE"), "The volume the source code is copied from.")
+	volumeSourcePath        = flag.String("volume-source-path", os.Getenv("VOLUME_SOURCE_PATH"), "The path of the source code within the volume.")
+	volumeSourceArchive     = flag.Bool("volume-source-archive", getenvBool("VOLUME_SOURCE_ARCHIVE"), "If the source code path in the volume is an archive to extract")
 	sourceSubPath           = flag.String("source-sub-path", os.Getenv("SOURCE_SUB_PATH"), "the subpath inside the source directory that will be the buildpack workspace")
 	buildChanges            = flag.String("build-changes", os.Getenv("BUILD_CHANGES"), "JSON string of build changes and their reason")
 	descriptorPath          = flag.String("project-descriptor-path", os.Getenv("PROJECT_DESCRIPTOR_PATH"), "path to project descriptor file")
 
+	extensionsGeneratedDir = flag.String("check-extensions-generated", "", "Only check that the image extensions in the generated directory do not extend the run image")
+
 	builderImage = flag.String("builder-image", os.Getenv("BUILDER_IMAGE"), "The builder image used to build the application")
 	builderName  = flag.String("builder-name", os.Getenv("BUILDER_NAME"), "The builder name provided during creation")
 	builderKind  = flag.String("builder-kind", os.Getenv("BUILDER_KIND"), "The builder kind")
@@ -70,6 +85,9 @@ const (
 	platformDir                  = "/platform"
 	buildSecretsDir              = "/var/build-secrets"
 	registrySourcePullSecretsDir = "/registrySourcePullSecrets"
+	sourceVerificationDir        = "/sourceVerification"
+	volumeSourceDir              = "/volumeSource"
+	terminationMessagePath       = "/dev/termination-log"
 	projectMetadataDir           = "/projectMetadata" // place to write project-metadata.toml which gets exported to image label by the lifecycle
 	networkWaitLauncherDir       = "/networkWait"
 	networkWaitLauncherBinary    = "network-wait-launcher.exe"
@@ -80,6 +98,13 @@ func main() {
 
 	logger := log.New(os.Stdout, "", 0)
 
+	if *extensionsGeneratedDir != "" {
+		if err := cnb.RejectRunImageExtensions(*extensionsGeneratedDir); err != nil {
+			logger.Fatal(err)
+		}
+		return
+	}
+
 	if err := buildchange.Log(logger, *buildChanges); err != nil {
 		logger.Println(err)
 	}
@@ -146,7 +171,14 @@ func main() {
 	}
 
 	err = fetchSource(logger, keychain)
-	if err != nil {
+	if errors.Is(err, git.ErrUnverifiedCommit) || errors.Is(err, cosign.ErrUnverifiedImage) {
+		// the termination message provides the reason of the failed build condition
+		message := buildapi.SourceVerificationFailedReason + ": " + err.Error()
+		if writeErr := os.WriteFile(terminationMessagePath, []byte(message), 0644); writeErr != nil {
+			logger.Printf("error writing termination message: %s", writeErr)
+		}
+		logger.Fatal(err)
+	} else if err != nil {
 		logger.Fatal(err)
 	}
 
@@ -186,10 +218,32 @@ func fetchSource(logger *log.Logger, keychain authn.Keychain) error {
 			initializeSubmodules = *gitInitializeSubmodules
 		}
 
+		var sparseCheckoutPaths []string
+		if *gitSparseCheckout {
+			sparseCheckoutPaths = []string{*sourceSubPath}
+			for _, p := range strings.Split(*gitSparseCheckoutPaths, ",") {
+				if p != "" {
+					sparseCheckoutPaths = append(sparseCheckoutPaths, p)
+				}
+			}
+		}
+
+		var verifier *git.CommitVerifier
+		if *gitVerifySignature {
+			logger.Println("Loading trusted keys for commit signature verification")
+			verifier, err = git.NewCommitVerifier(sourceVerificationDir)
+			if err != nil {
+				return err
+			}
+		}
+
 		fetcher := git.Fetcher{
 			Logger:               logger,
 			Keychain:             gitKeychain,
 			InitializeSubmodules: initializeSubmodules,
+			LFS:                  *gitLFS,
+			SparseCheckoutPaths:  sparseCheckoutPaths,
+			Verifier:             verifier,
 		}
 		return fetcher.Fetch(appDir, *gitURL, *gitRevision, projectMetadataDir)
 	case *blobURL != "":
@@ -227,9 +281,24 @@ func fetchSource(logger *log.Logger, keychain authn.Keychain) error {
 			Client:   &registry.Client{},
 			Keychain: authn.NewMultiKeychain(registrySourcePullSecrets, keychain),
 		}
-		return fetcher.Fetch(appDir, *registryImage, projectMetadataDir)
+
+		if *registryVerifySignature {
+			logger.Println("Loading trusted key for image signature verification")
+			verifier, err := cosign.NewSourceVerifier(filepath.Join(sourceVerificationDir, secret.CosignSecretPublicKey))
+			if err != nil {
+				return err
+			}
+			fetcher.Verifier = verifier
+		}
+		return fetcher.Fetch(appDir, *registryImage, *registryDigest, projectMetadataDir)
+	case *volumeSource != "":
+		fetcher := volume.Fetcher{
+			Logger:    logger,
+			VolumeDir: volumeSourceDir,
+		}
+		return fetcher.Fetch(appDir, *volumeSource, *volumeSourcePath, *volumeSourceArchive, projectMetadataDir)
 	default:
-		return errors.New("no git url, blob url, or registry image provided")
+		return errors.New("no git url, blob url, registry image, or volume source provided")
 	}
 }
 
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -13,6 +14,7 @@ import (
 	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -31,9 +33,11 @@ import (
 
 	"github.com/pivotal/kpack/cmd"
 	_ "github.com/pivotal/kpack/internal/logrus/fatal"
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/blob"
 	"github.com/pivotal/kpack/pkg/buildchange"
 	"github.com/pivotal/kpack/pkg/buildpod"
+	"github.com/pivotal/kpack/pkg/buildqueue"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	"github.com/pivotal/kpack/pkg/client/informers/externalversions"
 	"github.com/pivotal/kpack/pkg/cnb"
@@ -43,20 +47,25 @@ import (
 	"github.com/pivotal/kpack/pkg/duckbuilder"
 	"github.com/pivotal/kpack/pkg/flaghelpers"
 	"github.com/pivotal/kpack/pkg/git"
+	"github.com/pivotal/kpack/pkg/gitwebhook"
 	"github.com/pivotal/kpack/pkg/reconciler"
 	"github.com/pivotal/kpack/pkg/reconciler/build"
 	"github.com/pivotal/kpack/pkg/reconciler/builder"
 	"github.com/pivotal/kpack/pkg/reconciler/buildpack"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterbuilder"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterbuildpack"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterextension"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterlifecycle"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstack"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstore"
+	"github.com/pivotal/kpack/pkg/reconciler/extension"
 	"github.com/pivotal/kpack/pkg/reconciler/image"
 	"github.com/pivotal/kpack/pkg/reconciler/sourceresolver"
 	"github.com/pivotal/kpack/pkg/registry"
 	"github.com/pivotal/kpack/pkg/secret"
 	"github.com/pivotal/kpack/pkg/slsa"
+	"github.com/pivotal/kpack/pkg/sourceupload"
+	"github.com/pivotal/kpack/pkg/volume"
 )
 
 const (
@@ -87,6 +96,15 @@ func main() {
 	flag.StringVar(&cfg.MaximumPlatformApiVersion, "maximum-platform-api-version", os.Getenv("MAXIMUM_PLATFORM_API_VERSION"), "The maximum allowed platform api version a build can utilize")
 	flag.BoolVar(&cfg.SshTrustUnknownHosts, "insecure-ssh-trust-unknown-hosts", flaghelpers.GetEnvBool("INSECURE_SSH_TRUST_UNKNOWN_HOSTS", true), "if set to true, automatically trust unknown hosts when using git ssh source")
 	flag.IntVar(&cfg.ScalingFactor, "scaling-factor", flaghelpers.GetEnvInt("SCALING_FACTOR", 1), "The scaling factor to scale client-side rate limits by")
+	flag.DurationVar(&cfg.SourcePollingFrequency, "source-polling-frequency", flaghelpers.GetEnvDuration("SOURCE_POLLING_FREQUENCY", 1*time.Minute), "How often pollable sources are re-resolved. Can be increased when git host webhooks are configured")
+	flag.IntVar(&cfg.SourceWebhookPort, "source-webhook-port", flaghelpers.GetEnvInt("SOURCE_WEBHOOK_PORT", 0), "if set, serves git host push webhooks on this port to trigger immediate source resolution")
+	flag.IntVar(&cfg.SourceUploadPort, "source-upload-port", flaghelpers.GetEnvInt("SOURCE_UPLOAD_PORT", 0), "if set, serves the local source upload api on this port")
+	flag.StringVar(&cfg.SourceUploadRepository, "source-upload-repository", os.Getenv("SOURCE_UPLOAD_REPOSITORY"), "The repository uploaded sources are pushed to")
+	flag.StringVar(&cfg.SourceUploadTLSCert, "source-upload-tls-cert", os.Getenv("SOURCE_UPLOAD_TLS_CERT"), "The certificate file the source upload api is served with")
+	flag.StringVar(&cfg.SourceUploadTLSKey, "source-upload-tls-key", os.Getenv("SOURCE_UPLOAD_TLS_KEY"), "The private key file the source upload api is served with")
+	flag.IntVar(&cfg.MaxConcurrentBuilds, "max-concurrent-builds", flaghelpers.GetEnvInt("MAX_CONCURRENT_BUILDS", 0), "if set, the maximum number of build pods across the cluster, additional builds are queued")
+	flag.IntVar(&cfg.MaxConcurrentBuildsPerNamespace, "max-concurrent-builds-per-namespace", flaghelpers.GetEnvInt("MAX_CONCURRENT_BUILDS_PER_NAMESPACE", 0), "if set, the maximum number of build pods in each namespace, additional builds are queued")
+	flag.IntVar(&cfg.MaxConcurrentBuildsPerBuilder, "max-concurrent-builds-per-builder", flaghelpers.GetEnvInt("MAX_CONCURRENT_BUILDS_PER_BUILDER", 0), "if set, the maximum number of build pods for each builder, additional builds are queued")
 
 	flag.BoolVar(&featureFlags.InjectedSidecarSupport, "injected-sidecar-support", flaghelpers.GetEnvBool("INJECTED_SIDECAR_SUPPORT", false), "if set to true, all builds will execute in standard containers instead of init containers to support injected sidecars")
 	flag.BoolVar(&featureFlags.GenerateSlsaAttestation, "experimental-generate-slsa-attestation", flaghelpers.GetEnvBool("EXPERIMENTAL_GENERATE_SLSA_ATTESTATION", false), "if set to true, SLSA attestations will be generated for each build")
@@ -94,6 +112,14 @@ func main() {
 
 	flag.Parse()
 
+	if cfg.SourceUploadPort != 0 && cfg.SourceUploadRepository == "" {
+		log.Fatal("source upload repository is required when the source upload port is set")
+	}
+
+	if cfg.SourceUploadPort != 0 && (cfg.SourceUploadTLSCert == "" || cfg.SourceUploadTLSKey == "") {
+		log.Fatal("source upload tls certificate and key are required when the source upload port is set")
+	}
+
 	clusterConfig, err := clientcmd.BuildConfigFromFlags(*masterURL, *kubeconfig)
 	if err != nil {
 		log.Fatalf("Error building kubeconfig: %v", err)
@@ -118,7 +144,7 @@ func main() {
 		Logger:                  logger,
 		Client:                  client,
 		ResyncPeriod:            10 * time.Hour,
-		SourcePollingFrequency:  1 * time.Minute,
+		SourcePollingFrequency:  cfg.SourcePollingFrequency,
 		BuilderPollingFrequency: 1 * time.Minute,
 	}
 
@@ -130,6 +156,8 @@ func main() {
 	buildpackInformer := informerFactory.Kpack().V1alpha2().Buildpacks()
 	clusterBuilderInformer := informerFactory.Kpack().V1alpha2().ClusterBuilders()
 	clusterBuildpackInformer := informerFactory.Kpack().V1alpha2().ClusterBuildpacks()
+	extensionInformer := informerFactory.Kpack().V1alpha2().Extensions()
+	clusterExtensionInformer := informerFactory.Kpack().V1alpha2().ClusterExtensions()
 	clusterLifecycleInformer := informerFactory.Kpack().V1alpha2().ClusterLifecycles()
 	clusterStoreInformer := informerFactory.Kpack().V1alpha2().ClusterStores()
 	clusterStackInformer := informerFactory.Kpack().V1alpha2().ClusterStacks()
@@ -142,6 +170,15 @@ func main() {
 	k8sInformerFactory := informers.NewSharedInformerFactory(k8sClient, options.ResyncPeriod)
 	pvcInformer := k8sInformerFactory.Core().V1().PersistentVolumeClaims()
 	podInformer := k8sInformerFactory.Core().V1().Pods()
+	namespaceInformer := k8sInformerFactory.Core().V1().Namespaces()
+
+	// only the secrets and config maps labeled as build env sources are cached to detect changes to them
+	envSourceInformerFactory := informers.NewSharedInformerFactoryWithOptions(k8sClient, options.ResyncPeriod, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+		opts.LabelSelector = buildapi.EnvSourceLabel + "=true"
+	}))
+	secretInformer := envSourceInformerFactory.Core().V1().Secrets()
+	configMapInformer := envSourceInformerFactory.Core().V1().ConfigMaps()
+
 	keychainFactory, err := k8sdockercreds.NewSecretKeychainFactory(k8sClient)
 	if err != nil {
 		log.Fatalf("could not create k8s keychain factory: %s", err)
@@ -174,8 +211,12 @@ func main() {
 	}
 
 	gitResolver := git.NewResolver(k8sClient, cfg.SshTrustUnknownHosts, featureFlags)
-	blobResolver := &blob.Resolver{}
-	registryResolver := &registry.Resolver{}
+	blobResolver := blob.NewResolver(k8sClient)
+	registryResolver := &registry.Resolver{
+		KeychainFactory: keychainFactory,
+		Client:          &registry.Client{},
+	}
+	volumeResolver := &volume.Resolver{K8sClient: k8sClient}
 
 	remoteStoreReader := &cnb.RemoteBuildpackReader{
 		RegistryClient: &registry.Client{},
@@ -215,20 +256,50 @@ func main() {
 		SystemServiceAccountName: cfg.SystemServiceAccount,
 	}
 
-	buildController := build.NewController(ctx, options, k8sClient, buildInformer, podInformer, metadataRetriever, buildpodGenerator, podProgressLogger, keychainFactory, &slsaAttester, secretFetcher, featureFlags)
-	imageController := image.NewController(ctx, options, k8sClient, imageInformer, buildInformer, duckBuilderInformer, sourceResolverInformer, pvcInformer, cfg.EnablePriorityClasses)
-	sourceResolverController := sourceresolver.NewController(ctx, options, sourceResolverInformer, gitResolver, blobResolver, registryResolver, featureFlags)
-	builderController := builder.NewController(ctx, options, builderInformer, builderCreator, keychainFactory, clusterStoreInformer, buildpackInformer, clusterBuildpackInformer, clusterStackInformer, clusterLifecycleInformer, secretFetcher)
+	buildController := build.NewController(ctx, options, k8sClient, buildInformer, podInformer, metadataRetriever, buildpodGenerator, podProgressLogger, keychainFactory, &slsaAttester, secretFetcher, featureFlags, buildqueue.Limits{
+		Global:       cfg.MaxConcurrentBuilds,
+		PerNamespace: cfg.MaxConcurrentBuildsPerNamespace,
+		PerBuilder:   cfg.MaxConcurrentBuildsPerBuilder,
+	})
+	imageController := image.NewController(ctx, options, k8sClient, imageInformer, buildInformer, duckBuilderInformer, sourceResolverInformer, pvcInformer, namespaceInformer, secretInformer, configMapInformer, keychainFactory, &registry.IndexWriter{}, cfg.EnablePriorityClasses)
+	sourceResolverController := sourceresolver.NewController(ctx, options, sourceResolverInformer, gitResolver, blobResolver, registryResolver, volumeResolver, featureFlags)
+	builderController := builder.NewController(ctx, options, builderInformer, builderCreator, keychainFactory, clusterStoreInformer, buildpackInformer, clusterBuildpackInformer, extensionInformer, clusterExtensionInformer, clusterStackInformer, remoteStackReader, clusterLifecycleInformer, secretFetcher, imageInformer, buildInformer)
 	buildpackController := buildpack.NewController(ctx, options, keychainFactory, buildpackInformer, remoteStoreReader)
-	clusterBuilderController := clusterbuilder.NewController(ctx, options, clusterBuilderInformer, builderCreator, keychainFactory, clusterStoreInformer, clusterBuildpackInformer, clusterStackInformer, clusterLifecycleInformer, secretFetcher)
+	clusterBuilderController := clusterbuilder.NewController(ctx, options, clusterBuilderInformer, builderCreator, keychainFactory, clusterStoreInformer, clusterBuildpackInformer, clusterExtensionInformer, clusterStackInformer, remoteStackReader, clusterLifecycleInformer, secretFetcher, imageInformer, buildInformer)
 	clusterBuildpackController := clusterbuildpack.NewController(ctx, options, keychainFactory, clusterBuildpackInformer, remoteStoreReader)
+	extensionController := extension.NewController(ctx, options, keychainFactory, extensionInformer, remoteStoreReader)
+	clusterExtensionController := clusterextension.NewController(ctx, options, keychainFactory, clusterExtensionInformer, remoteStoreReader)
 	clusterStoreController := clusterstore.NewController(ctx, options, keychainFactory, clusterStoreInformer, remoteStoreReader)
 	clusterStackController := clusterstack.NewController(ctx, options, keychainFactory, clusterStackInformer, remoteStackReader)
 	clusterLifecycleController := clusterlifecycle.NewController(ctx, options, keychainFactory, clusterLifecycleInformer, remoteLifecycleReader)
 
+	sourceWebhookServer := &http.Server{
+		Addr: fmt.Sprintf(":%d", cfg.SourceWebhookPort),
+		Handler: &gitwebhook.Handler{
+			Secret:               []byte(os.Getenv("SOURCE_WEBHOOK_SECRET")),
+			SourceResolverLister: sourceResolverInformer.Lister(),
+			Enqueue:              sourceResolverController.Enqueue,
+			Logger:               logger,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	sourceUploadServer := &http.Server{
+		Addr: fmt.Sprintf(":%d", cfg.SourceUploadPort),
+		Handler: &sourceupload.Handler{
+			Repository:      cfg.SourceUploadRepository,
+			KeychainFactory: keychainFactory,
+			Authorizer:      &sourceupload.TokenReviewAuthorizer{Client: k8sClient},
+			Client:          client,
+			Logger:          logger,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
 	stopChan := make(chan struct{})
 	informerFactory.Start(stopChan)
 	k8sInformerFactory.Start(stopChan)
+	envSourceInformerFactory.Start(stopChan)
 
 	waitForSync(stopChan,
 		buildInformer.Informer(),
@@ -236,10 +307,15 @@ func main() {
 		sourceResolverInformer.Informer(),
 		pvcInformer.Informer(),
 		podInformer.Informer(),
+		namespaceInformer.Informer(),
+		secretInformer.Informer(),
+		configMapInformer.Informer(),
 		builderInformer.Informer(),
 		buildpackInformer.Informer(),
 		clusterBuilderInformer.Informer(),
 		clusterBuildpackInformer.Informer(),
+		extensionInformer.Informer(),
+		clusterExtensionInformer.Informer(),
 		clusterStoreInformer.Informer(),
 		clusterStackInformer.Informer(),
 	)
@@ -255,6 +331,8 @@ func main() {
 		run(buildpackController, routinesPerController),
 		run(clusterBuilderController, routinesPerController),
 		run(clusterBuildpackController, routinesPerController),
+		run(extensionController, routinesPerController),
+		run(clusterExtensionController, routinesPerController),
 		run(clusterStoreController, routinesPerController),
 		run(sourceResolverController, 2*routinesPerController),
 		func(ctx context.Context) error {
@@ -267,6 +345,26 @@ func main() {
 			<-ctx.Done()
 			return profilingServer.Shutdown(ctx)
 		},
+		func(ctx context.Context) error {
+			if cfg.SourceWebhookPort == 0 {
+				return nil
+			}
+			return sourceWebhookServer.ListenAndServe()
+		},
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return sourceWebhookServer.Shutdown(ctx)
+		},
+		func(ctx context.Context) error {
+			if cfg.SourceUploadPort == 0 {
+				return nil
+			}
+			return sourceUploadServer.ListenAndServeTLS(cfg.SourceUploadTLSCert, cfg.SourceUploadTLSKey)
+		},
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return sourceUploadServer.Shutdown(ctx)
+		},
 	)
 	if err != nil && err != http.ErrServerClosed {
 		logger.Fatalw("Error running controller", zap.Error(err))
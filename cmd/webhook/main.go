@@ -6,10 +6,16 @@ import (
 	"os"
 	"strconv"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	informersv1 "k8s.io/client-go/informers/storage/v1"
+	"k8s.io/client-go/kubernetes"
 	listersv1 "k8s.io/client-go/listers/storage/v1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/client/injection/kube/informers/factory"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -26,6 +32,10 @@ import (
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha1"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	"github.com/pivotal/kpack/pkg/client/informers/externalversions"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
 )
 
 const defaultWebhookPort = 8443
@@ -40,10 +50,13 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.ClusterStoreKind):     &v1alpha2.ClusterStore{},
 	v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.ClusterStackKind):     &v1alpha2.ClusterStack{},
 	v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.ClusterLifecycleKind): &v1alpha2.ClusterLifecycle{},
+	v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.ExtensionKind):        &v1alpha2.Extension{},
+	v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.ClusterExtensionKind): &v1alpha2.ClusterExtension{},
 }
 
 func init() {
 	injection.Default.RegisterInformer(withStorageClassInformer)
+	injection.Default.RegisterInformer(withImageInformer)
 }
 
 func main() {
@@ -86,6 +99,8 @@ func defaultingAdmissionController(ctx context.Context, _ configmap.Watcher) *co
 
 func validatingAdmissionController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	storageClassLister := getStorageClassInformer(ctx).Lister()
+	imageLister := getImageInformer(ctx).Lister()
+	k8sClient := kubeclient.Get(ctx)
 
 	return validation.NewAdmissionController(ctx,
 		// Name of the resource webhook.
@@ -95,7 +110,9 @@ func validatingAdmissionController(ctx context.Context, _ configmap.Watcher) *co
 		// The resources to validate.
 		types,
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-		withCheckDefaultStorageClass(storageClassLister),
+		func(ctx context.Context) context.Context {
+			return withSecretAccessReview(k8sClient)(withImageLookup(imageLister)(withCheckDefaultStorageClass(storageClassLister)(ctx)))
+		},
 		// Whether to disallow unknown fields.
 		true,
 	)
@@ -173,6 +190,52 @@ func withCheckDefaultStorageClass(storageClassLister listersv1.StorageClassListe
 	}
 }
 
+func withImageLookup(imageLister buildlisters.ImageLister) func(context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, v1alpha2.UpstreamImageLookup, v1alpha2.ImageLookup(func(namespace, name string) (*v1alpha2.Image, error) {
+			image, err := imageLister.Images(namespace).Get(name)
+			if k8serrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return image, err
+		}))
+	}
+}
+
+func withSecretAccessReview(k8sClient kubernetes.Interface) func(context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, v1alpha2.SecretAccessReview, v1alpha2.SecretAccess(func(ctx context.Context, user *authenticationv1.UserInfo, namespace, name string) (bool, error) {
+			if user == nil {
+				return false, nil
+			}
+
+			extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+			for k, v := range user.Extra {
+				extra[k] = authorizationv1.ExtraValue(v)
+			}
+
+			accessReview, err := k8sClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: namespace,
+						Verb:      "get",
+						Resource:  "secrets",
+						Name:      name,
+					},
+					User:   user.Username,
+					Groups: user.Groups,
+					Extra:  extra,
+					UID:    user.UID,
+				},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return false, err
+			}
+			return accessReview.Status.Allowed, nil
+		}))
+	}
+}
+
 // storageClassInformerKey is used for associating the Informer inside the context.Context.
 type storageClassInformerKey struct{}
 
@@ -189,3 +252,20 @@ func getStorageClassInformer(ctx context.Context) informersv1.StorageClassInform
 	}
 	return untyped.(informersv1.StorageClassInformer)
 }
+
+// imageInformerKey is used for associating the Informer inside the context.Context.
+type imageInformerKey struct{}
+
+func withImageInformer(ctx context.Context) (context.Context, controller.Informer) {
+	client := versioned.NewForConfigOrDie(injection.GetConfig(ctx))
+	inf := externalversions.NewSharedInformerFactory(client, controller.GetResyncPeriod(ctx)).Kpack().V1alpha2().Images()
+	return context.WithValue(ctx, imageInformerKey{}, inf), inf.Informer()
+}
+
+func getImageInformer(ctx context.Context) buildinformers.ImageInformer {
+	untyped := ctx.Value(imageInformerKey{})
+	if untyped == nil {
+		logging.FromContext(ctx).Panic("Unable to fetch image informer from context.")
+	}
+	return untyped.(buildinformers.ImageInformer)
+}
@@ -132,6 +132,23 @@ func (b *Build) IsFailure() bool {
 	return b.Status.GetCondition(corev1alpha1.ConditionSucceeded).IsFalse()
 }
 
+// CancelRequested is true if the build has been annotated to be canceled
+func (b *Build) CancelRequested() bool {
+	if b == nil {
+		return false
+	}
+	_, ok := b.Annotations[BuildCancelAnnotation]
+	return ok
+}
+
+// IsCanceled is true if the build was stopped by a cancel request instead of failing
+func (b *Build) IsCanceled() bool {
+	if !b.IsFailure() {
+		return false
+	}
+	return b.Status.GetCondition(corev1alpha1.ConditionSucceeded).Reason == BuildCanceledReason
+}
+
 func (b *Build) PodName() string {
 	return kmeta.ChildName(b.Name, "-build-pod")
 }
@@ -0,0 +1,158 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	EnvSourceSecretKind    = "Secret"
+	EnvSourceConfigMapKind = "ConfigMap"
+
+	// EnvSourceLabel must be set to "true" on Secrets and ConfigMaps referenced by the build env for the controller to
+	// detect changes to them
+	EnvSourceLabel = "kpack.io/build-env"
+
+	SecretAccessReview ImageContextKey = "secretAccessReview"
+)
+
+// SecretAccess reports whether the user can get the Secret with the name in the namespace. The webhook sets a
+// SecretAccess on the context with the SecretAccessReview key to only allow build env Secret refs from users that
+// can read the Secrets.
+type SecretAccess func(ctx context.Context, user *authv1.UserInfo, namespace, name string) (bool, error)
+
+// +k8s:openapi-gen=true
+type BuildEnvSource struct {
+	// Kind is Secret or ConfigMap
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Hash is the sha256 digest of the referenced keys of the Secret or ConfigMap, it is empty if it does not exist or
+	// is not labeled as a build env source
+	Hash string `json:"hash,omitempty"`
+}
+
+// EnvSources returns the Secrets and ConfigMaps referenced by the build env, sorted by kind and name
+func (im *Image) EnvSources() []BuildEnvSource {
+	referenced := map[BuildEnvSource]bool{}
+	for _, envVar := range im.Env() {
+		if envVar.ValueFrom == nil {
+			continue
+		}
+		if ref := envVar.ValueFrom.SecretKeyRef; ref != nil {
+			referenced[BuildEnvSource{Kind: EnvSourceSecretKind, Name: ref.Name}] = true
+		}
+		if ref := envVar.ValueFrom.ConfigMapKeyRef; ref != nil {
+			referenced[BuildEnvSource{Kind: EnvSourceConfigMapKind, Name: ref.Name}] = true
+		}
+	}
+	for _, envFrom := range im.EnvFrom() {
+		if ref := envFrom.SecretRef; ref != nil {
+			referenced[BuildEnvSource{Kind: EnvSourceSecretKind, Name: ref.Name}] = true
+		}
+		if ref := envFrom.ConfigMapRef; ref != nil {
+			referenced[BuildEnvSource{Kind: EnvSourceConfigMapKind, Name: ref.Name}] = true
+		}
+	}
+
+	if len(referenced) == 0 {
+		return nil
+	}
+
+	sources := make([]BuildEnvSource, 0, len(referenced))
+	for source := range referenced {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Kind != sources[j].Kind {
+			return sources[i].Kind < sources[j].Kind
+		}
+		return sources[i].Name < sources[j].Name
+	})
+	return sources
+}
+
+// EnvSourceKeys returns the keys of the Secret or ConfigMap that are referenced by the build env, all is true if it is
+// referenced by envFrom and every key is used
+func (im *Image) EnvSourceKeys(source BuildEnvSource) (keys []string, all bool) {
+	for _, envFrom := range im.EnvFrom() {
+		if source.Kind == EnvSourceSecretKind && envFrom.SecretRef != nil && envFrom.SecretRef.Name == source.Name {
+			return nil, true
+		}
+		if source.Kind == EnvSourceConfigMapKind && envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == source.Name {
+			return nil, true
+		}
+	}
+
+	for _, envVar := range im.Env() {
+		if envVar.ValueFrom == nil {
+			continue
+		}
+		if ref := envVar.ValueFrom.SecretKeyRef; source.Kind == EnvSourceSecretKind && ref != nil && ref.Name == source.Name {
+			keys = append(keys, ref.Key)
+		}
+		if ref := envVar.ValueFrom.ConfigMapKeyRef; source.Kind == EnvSourceConfigMapKind && ref != nil && ref.Name == source.Name {
+			keys = append(keys, ref.Key)
+		}
+	}
+	return keys, false
+}
+
+// validateBuildEnvSecretRefs only allows Secret refs in the build env if the user can read the Secrets, the kpack
+// controller is trusted to create builds from the Secret refs of images
+func validateBuildEnvSecretRefs(ctx context.Context, namespace string, env []corev1.EnvVar, envFrom []corev1.EnvFromSource) *apis.FieldError {
+	user := apis.GetUserInfo(ctx)
+	if resourceCreatedByKpackController(user) {
+		return nil
+	}
+
+	access, _ := ctx.Value(SecretAccessReview).(SecretAccess)
+	validate := func(name, field string) *apis.FieldError {
+		if access == nil {
+			return apis.ErrGeneric(fmt.Sprintf("%s is not supported for build environment variables", field), field)
+		}
+
+		allowed, err := access(ctx, user, namespace, name)
+		if err != nil {
+			return apis.ErrGeneric(fmt.Sprintf("unable to check access to secret %s: %s", name, err), field)
+		}
+		if !allowed {
+			return apis.ErrGeneric(fmt.Sprintf("user is not allowed to get secret %s", name), field)
+		}
+		return nil
+	}
+
+	var errs *apis.FieldError
+	for i, envVar := range env {
+		if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+			errs = errs.Also(validate(envVar.ValueFrom.SecretKeyRef.Name, "secretKeyRef").ViaField("valueFrom").ViaFieldIndex("env", i))
+		}
+	}
+	for i, source := range envFrom {
+		if source.SecretRef != nil && source.ConfigMapRef == nil && source.SecretRef.Name != "" {
+			errs = errs.Also(validate(source.SecretRef.Name, "secretRef").ViaFieldIndex("envFrom", i))
+		}
+	}
+	return errs
+}
+
+func validateBuildEnvFrom(envFrom []corev1.EnvFromSource) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, source := range envFrom {
+		switch {
+		case source.SecretRef == nil && source.ConfigMapRef == nil:
+			errs = errs.Also(apis.ErrMissingOneOf("secretRef", "configMapRef").ViaIndex(i))
+		case source.SecretRef != nil && source.ConfigMapRef != nil:
+			errs = errs.Also(apis.ErrMultipleOneOf("secretRef", "configMapRef").ViaIndex(i))
+		case source.SecretRef != nil && source.SecretRef.Name == "":
+			errs = errs.Also(apis.ErrMissingField("name").ViaField("secretRef").ViaIndex(i))
+		case source.ConfigMapRef != nil && source.ConfigMapRef.Name == "":
+			errs = errs.Also(apis.ErrMissingField("name").ViaField("configMapRef").ViaIndex(i))
+		}
+	}
+	return errs
+}
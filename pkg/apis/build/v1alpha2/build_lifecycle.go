@@ -1,11 +1,55 @@
 package v1alpha2
 
 import (
+	"fmt"
+
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// SourceVerificationFailedReason is the reason of the Succeeded condition when the source
+// was not signed by a key trusted by the source verification secret
+const SourceVerificationFailedReason = "SourceVerificationFailed"
+
+// DetectFailedReason and CompileFailedReason are the reasons of the Succeeded condition when the detect
+// or build step of the lifecycle failed
+const (
+	DetectFailedReason  = "DetectFailed"
+	CompileFailedReason = "CompileFailed"
+)
+
+// BuildCancelAnnotation requests that a running or queued build is stopped
+const BuildCancelAnnotation = "build.kpack.io/cancel"
+
+// BuildCanceledReason is the reason of the Succeeded condition when the build was stopped by a cancel request
+const BuildCanceledReason = "Canceled"
+
+// ConditionQueued is true while the build is waiting for the build queue to admit its pod
+const ConditionQueued corev1alpha1.ConditionType = "Queued"
+
+const BuildQueuedReason = "Queued"
+
+func (bs *BuildStatus) Queued(position int) {
+	message := fmt.Sprintf("Build is queued at position %d", position)
+	bs.Conditions = corev1alpha1.Conditions{
+		{
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionUnknown,
+			Reason:             BuildQueuedReason,
+			Message:            message,
+		},
+		{
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			Type:               ConditionQueued,
+			Status:             corev1.ConditionTrue,
+			Reason:             BuildQueuedReason,
+			Message:            message,
+		},
+	}
+}
+
 func (bs *BuildStatus) Error(err error) {
 	bs.Conditions = corev1alpha1.Conditions{
 		{
@@ -16,3 +60,15 @@ func (bs *BuildStatus) Error(err error) {
 		},
 	}
 }
+
+func (bs *BuildStatus) Canceled() {
+	bs.Conditions = corev1alpha1.Conditions{
+		{
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionFalse,
+			Reason:             BuildCanceledReason,
+			Message:            "Build was canceled",
+		},
+	}
+}
@@ -27,6 +27,8 @@ const (
 	ExportContainerName     = "export"
 	RebaseContainerName     = "rebase"
 	CompletionContainerName = "completion"
+	// CheckExtensionsContainerName rejects image extensions that generated a run.Dockerfile
+	CheckExtensionsContainerName = "check-extensions"
 
 	secretVolumeNameTemplate     = "secret-volume-%v"
 	pullSecretVolumeNameTemplate = "pull-secret-volume-%v"
@@ -58,12 +60,16 @@ const (
 	notaryVolumeName                    = "notary-dir"
 	platformVolumeName                  = "platform-dir"
 	registrySourcePullSecretsVolumeName = "registry-source-pull-secrets-dir"
+	sourceVerificationVolumeName        = "source-verification-dir"
+	volumeSourceVolumeName              = "volume-source-dir"
 	reportVolumeName                    = "report-dir"
 	workspaceVolumeName                 = "workspace-dir"
 
 	buildChangesEnvVar           = "BUILD_CHANGES"
 	CacheTagEnvVar               = "CACHE_TAG"
 	platformApiVersionEnvVarName = "CNB_PLATFORM_API"
+	lifecycleLogLevelEnvVar      = "CNB_LOG_LEVEL"
+	experimentalModeEnvVar       = "CNB_EXPERIMENTAL_MODE"
 	serviceBindingRootEnvVar     = "SERVICE_BINDING_ROOT"
 	TerminationMessagePathEnvVar = "TERMINATION_MESSAGE_PATH"
 
@@ -76,6 +82,7 @@ var (
 	DetectCommand     = "/cnb/lifecycle/detector"
 	RestoreCommand    = "/cnb/lifecycle/restorer"
 	BuildCommand      = "/cnb/lifecycle/builder"
+	ExtendCommand     = "/cnb/lifecycle/extender"
 	ExportCommand     = "/cnb/lifecycle/exporter"
 	CompletionCommand = "/cnb/process/completion"
 	RebaseCommand     = "/cnb/process/rebase"
@@ -110,6 +117,7 @@ type BuildPodBuilderConfig struct {
 	Gid           int64
 	PlatformAPIs  []string
 	ResolvedImage string
+	Extensions    bool
 }
 
 var (
@@ -142,6 +150,16 @@ var (
 		MountPath: "/registrySourcePullSecrets",
 		ReadOnly:  true,
 	}
+	sourceVerificationMount = corev1.VolumeMount{
+		Name:      sourceVerificationVolumeName,
+		MountPath: "/sourceVerification",
+		ReadOnly:  true,
+	}
+	volumeSourceMount = corev1.VolumeMount{
+		Name:      volumeSourceVolumeName,
+		MountPath: "/volumeSource",
+		ReadOnly:  true,
+	}
 	notaryV1Mount = corev1.VolumeMount{
 		Name:      notaryVolumeName,
 		MountPath: "/var/notary/v1",
@@ -195,6 +213,11 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 		envVar.Name = PlatformEnvVarPrefix + envVar.Name
 		buildEnv = append(buildEnv, envVar)
 	}
+	var buildEnvFrom []corev1.EnvFromSource
+	for _, envFrom := range b.Spec.EnvFrom {
+		envFrom.Prefix = PlatformEnvVarPrefix + envFrom.Prefix
+		buildEnvFrom = append(buildEnvFrom, envFrom)
+	}
 
 	blobAuthUseSecrets := b.Spec.Source.Blob != nil && b.Spec.Source.Blob.Auth == string(corev1alpha1.BlobAuthSecret)
 
@@ -239,6 +262,12 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 		genericCacheArgs = nil
 	}
 
+	// image extensions are experimental in the lifecycle and have to be explicitly allowed
+	experimentalModeEnv := corev1.EnvVar{}
+	if buildContext.BuildPodBuilderConfig.Extensions {
+		experimentalModeEnv = corev1.EnvVar{Name: experimentalModeEnvVar, Value: "warn"}
+	}
+
 	analyzeContainer := corev1.Container{
 		Name:      AnalyzeContainerName,
 		Image:     buildContext.BuildPodBuilderConfig.ResolvedImage,
@@ -273,11 +302,11 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 			workspaceVolume,
 			homeMount,
 		}),
-		Env: []corev1.EnvVar{
+		Env: envs([]corev1.EnvVar{
 			homeEnv,
 			platformApiVersionEnvVar,
 			serviceBindingRootEnv,
-		},
+		}, experimentalModeEnv),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 	}
 
@@ -297,9 +326,9 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 			workspaceVolume,
 		}, bindingVolumeMounts),
 		ImagePullPolicy: corev1.PullIfNotPresent,
-		Env: []corev1.EnvVar{
+		Env: envs([]corev1.EnvVar{
 			platformApiVersionEnvVar,
-		},
+		}, experimentalModeEnv),
 		SecurityContext: containerSecurityContext(),
 	}
 
@@ -413,6 +442,7 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 								Value: strconv.FormatBool(buildContext.SSHTrustUnknownHost),
 							},
 						),
+						EnvFrom:         buildEnvFrom,
 						ImagePullPolicy: corev1.PullIfNotPresent,
 						WorkingDir:      "/workspace",
 						VolumeMounts: volumeMounts(
@@ -420,6 +450,8 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 							imagePullVolumeMounts,
 							[]corev1.VolumeMount{
 								registrySourcePullSecretsMount,
+								sourceVerificationMount,
+								volumeSourceMount,
 								platformMount,
 								sourceMount,
 								homeMount,
@@ -430,6 +462,20 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 				)
 				step(analyzeContainer)
 				step(detectContainer)
+				if buildContext.BuildPodBuilderConfig.Extensions {
+					step(
+						corev1.Container{
+							Name:            CheckExtensionsContainerName,
+							Image:           images.BuildInitImage,
+							Command:         []string{PrepareCommand},
+							Args:            []string{"-check-extensions-generated=/layers/generated"},
+							Resources:       b.Spec.Resources,
+							SecurityContext: containerSecurityContext(),
+							VolumeMounts:    []corev1.VolumeMount{layersMount},
+							ImagePullPolicy: corev1.PullIfNotPresent,
+						},
+					)
+				}
 				step(
 					corev1.Container{
 						Name:            RestoreContainerName,
@@ -442,43 +488,83 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 							"-layers=/layers",
 						},
 							genericCacheArgs,
-							[]string{"-analyzed=/layers/analyzed.toml"}),
+							[]string{"-analyzed=/layers/analyzed.toml"},
+							func() []string {
+								if !buildContext.BuildPodBuilderConfig.Extensions {
+									return nil
+								}
+								return []string{"-build-image=" + buildContext.BuildPodBuilderConfig.ResolvedImage}
+							}()),
 						VolumeMounts: volumeMounts([]corev1.VolumeMount{
 							layersMount,
 							homeMount,
 						}, cacheVolumes),
-						Env: []corev1.EnvVar{
+						Env: envs([]corev1.EnvVar{
 							homeEnv,
 							platformApiVersionEnvVar,
-						},
+						}, experimentalModeEnv),
 						ImagePullPolicy: corev1.PullIfNotPresent,
 					},
 				)
-				step(
-					corev1.Container{
-						Name:            BuildContainerName,
-						Image:           buildContext.BuildPodBuilderConfig.ResolvedImage,
-						Command:         []string{BuildCommand},
-						Resources:       b.Spec.Resources,
-						SecurityContext: containerSecurityContext(),
-						Args: []string{
-							"-layers=/layers",
-							"-app=/workspace",
-							"-group=/layers/group.toml",
-							"-plan=/layers/plan.toml",
+				if buildContext.BuildPodBuilderConfig.Extensions {
+					step(
+						corev1.Container{
+							Name:            BuildContainerName,
+							Image:           buildContext.BuildPodBuilderConfig.ResolvedImage,
+							Command:         []string{ExtendCommand},
+							Resources:       b.Spec.Resources,
+							SecurityContext: extenderSecurityContext(),
+							Args: []string{
+								"-layers=/layers",
+								"-app=/workspace",
+								"-group=/layers/group.toml",
+								"-plan=/layers/plan.toml",
+								"-generated=/layers/generated",
+								fmt.Sprintf("-uid=%d", buildContext.BuildPodBuilderConfig.Uid),
+								fmt.Sprintf("-gid=%d", buildContext.BuildPodBuilderConfig.Gid),
+							},
+							VolumeMounts: volumeMounts([]corev1.VolumeMount{
+								layersMount,
+								platformMount,
+								workspaceVolume,
+								homeMount,
+							}, bindingVolumeMounts),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Env: []corev1.EnvVar{
+								homeEnv,
+								platformApiVersionEnvVar,
+								serviceBindingRootEnv,
+								experimentalModeEnv,
+							},
 						},
-						VolumeMounts: volumeMounts([]corev1.VolumeMount{
-							layersMount,
-							platformMount,
-							workspaceVolume,
-						}, bindingVolumeMounts),
-						ImagePullPolicy: corev1.PullIfNotPresent,
-						Env: []corev1.EnvVar{
-							platformApiVersionEnvVar,
-							serviceBindingRootEnv,
+					)
+				} else {
+					step(
+						corev1.Container{
+							Name:            BuildContainerName,
+							Image:           buildContext.BuildPodBuilderConfig.ResolvedImage,
+							Command:         []string{BuildCommand},
+							Resources:       b.Spec.Resources,
+							SecurityContext: containerSecurityContext(),
+							Args: []string{
+								"-layers=/layers",
+								"-app=/workspace",
+								"-group=/layers/group.toml",
+								"-plan=/layers/plan.toml",
+							},
+							VolumeMounts: volumeMounts([]corev1.VolumeMount{
+								layersMount,
+								platformMount,
+								workspaceVolume,
+							}, bindingVolumeMounts),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Env: []corev1.EnvVar{
+								platformApiVersionEnvVar,
+								serviceBindingRootEnv,
+							},
 						},
-					},
-				)
+					)
+				}
 				step(
 					corev1.Container{
 						Name:            ExportContainerName,
@@ -525,7 +611,8 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 									Name:  "CNB_RUN_IMAGE",
 									Value: runImage,
 								}
-							}()),
+							}(),
+							experimentalModeEnv),
 						ImagePullPolicy: corev1.PullIfNotPresent,
 					},
 				)
@@ -579,6 +666,8 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 						},
 					},
 					b.Spec.Source.Source().ImagePullSecretsVolume(registrySourcePullSecretsVolumeName),
+					b.Spec.Source.Source().VerificationSecretVolume(sourceVerificationVolumeName),
+					b.Spec.Source.Source().SourceVolume(volumeSourceVolumeName),
 					b.notarySecretVolume(),
 				},
 				bindingVolumes),
@@ -586,6 +675,8 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 		},
 	}
 
+	b.setLogLevel(pod)
+
 	if buildContext.InjectedSidecarSupport {
 		pod = b.useStandardContainers(images.BuildWaiterImage, pod)
 	}
@@ -593,10 +684,42 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 	return pod, nil
 }
 
+var lifecycleContainerNames = map[string]bool{
+	AnalyzeContainerName: true,
+	DetectContainerName:  true,
+	RestoreContainerName: true,
+	BuildContainerName:   true,
+	ExportContainerName:  true,
+	RebaseContainerName:  true,
+}
+
+// setLogLevel sets the log level of the lifecycle phases of the build pod
+func (b *Build) setLogLevel(pod *corev1.Pod) {
+	if b.Spec.LogLevel == "" {
+		return
+	}
+
+	logLevelEnv := corev1.EnvVar{Name: lifecycleLogLevelEnvVar, Value: b.Spec.LogLevel}
+	for i, container := range pod.Spec.InitContainers {
+		if lifecycleContainerNames[container.Name] {
+			pod.Spec.InitContainers[i].Env = append(container.Env, logLevelEnv)
+		}
+	}
+	for i, container := range pod.Spec.Containers {
+		if lifecycleContainerNames[container.Name] {
+			pod.Spec.Containers[i].Env = append(container.Env, logLevelEnv)
+		}
+	}
+}
+
 func boolPointer(b bool) *bool {
 	return &b
 }
 
+func int64Pointer(i int64) *int64 {
+	return &i
+}
+
 func containerSecurityContext() *corev1.SecurityContext {
 	return &corev1.SecurityContext{
 		RunAsNonRoot:             boolPointer(true),
@@ -607,6 +730,18 @@ func containerSecurityContext() *corev1.SecurityContext {
 	}
 }
 
+// extenderSecurityContext allows the extender to run as root which is required to apply the
+// image extension Dockerfiles to the build image, the buildpacks are still run as the cnb user
+func extenderSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		RunAsUser:                int64Pointer(0),
+		RunAsNonRoot:             boolPointer(false),
+		AllowPrivilegeEscalation: boolPointer(false),
+		Privileged:               boolPointer(false),
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
 func podSecurityContext(config BuildPodBuilderConfig) *corev1.PodSecurityContext {
 	return &corev1.PodSecurityContext{
 		RunAsNonRoot:   boolPointer(true),
@@ -854,6 +989,8 @@ func (b *Build) rebasePod(buildContext BuildContext, images BuildPodImages) (*co
 		Status: corev1.PodStatus{},
 	}
 
+	b.setLogLevel(pod)
+
 	if buildContext.InjectedSidecarSupport {
 		pod = b.useStandardContainers(images.BuildWaiterImage, pod)
 	}
@@ -1006,10 +1143,35 @@ func (b *Build) setupCosignVolumes(secrets []corev1.Secret) ([]corev1.Volume, []
 
 var (
 	supportedPlatformAPIVersions = []*semver.Version{semver.MustParse("0.9"), semver.MustParse("0.8"), semver.MustParse("0.7")}
+
+	// builds with image extensions run the extender which is only available from platform api 0.10
+	extensionsPlatformAPIVersions = []*semver.Version{semver.MustParse("0.10")}
 )
 
 func (bc BuildContext) highestSupportedPlatformAPI(b *Build) (*semver.Version, error) {
-	for _, supportedVersion := range supportedPlatformAPIVersions {
+	if bc.BuildPodBuilderConfig.Extensions {
+		version, err := bc.matchPlatformAPI(extensionsPlatformAPIVersions)
+		if err != nil {
+			return nil, err
+		}
+		if version == nil {
+			return nil, errors.Errorf("builder image extensions require platform API %s, builder supports: %s", extensionsPlatformAPIVersions[0].Original(), strings.Join(bc.BuildPodBuilderConfig.PlatformAPIs, ","))
+		}
+		return version, nil
+	}
+
+	version, err := bc.matchPlatformAPI(supportedPlatformAPIVersions)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, errors.Errorf("unsupported builder platform API versions: %s", strings.Join(bc.BuildPodBuilderConfig.PlatformAPIs, ","))
+	}
+	return version, nil
+}
+
+func (bc BuildContext) matchPlatformAPI(supportedVersions []*semver.Version) (*semver.Version, error) {
+	for _, supportedVersion := range supportedVersions {
 		if bc.MaximumPlatformApiVersion != nil && bc.MaximumPlatformApiVersion.LessThan(supportedVersion) {
 			continue
 		}
@@ -1025,7 +1187,7 @@ func (bc BuildContext) highestSupportedPlatformAPI(b *Build) (*semver.Version, e
 		}
 	}
 
-	return nil, errors.Errorf("unsupported builder platform API versions: %s", strings.Join(bc.BuildPodBuilderConfig.PlatformAPIs, ","))
+	return nil, nil
 }
 
 func setupBindingVolumesAndMounts(bindings []ServiceBinding) ([]corev1.Volume, []corev1.VolumeMount, error) {
@@ -700,6 +700,22 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		it("configures prepare with the build env from sources", func() {
+			build.Spec.EnvFrom = []corev1.EnvFromSource{
+				{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret"}}},
+				{Prefix: "NPM_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-config-map"}}},
+			}
+
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Equal(t, []corev1.EnvFromSource{
+				{Prefix: "PLATFORM_ENV_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret"}}},
+				{Prefix: "PLATFORM_ENV_NPM_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-config-map"}}},
+			}, pod.Spec.InitContainers[0].EnvFrom)
+			assert.Equal(t, "NPM_", build.Spec.EnvFrom[1].Prefix)
+		})
+
 		it("configures the prepare step for git source", func() {
 			pod, err := build.BuildPod(config, buildContext)
 			require.NoError(t, err)
@@ -722,6 +738,16 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 					Name:  "GIT_INITIALIZE_SUBMODULES",
 					Value: fmt.Sprintf("%v", build.Spec.Source.Git.InitializeSubmodules),
 				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_LFS",
+					Value: fmt.Sprintf("%v", build.Spec.Source.Git.LFS),
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_SPARSE_CHECKOUT",
+					Value: fmt.Sprintf("%v", build.Spec.Source.Git.SparseCheckout),
+				})
 		})
 
 		it("configures prepare with the blob source", func() {
@@ -779,6 +805,80 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 				})
 		})
 
+		it("mounts the source verification secret in prepare", func() {
+			build.Spec.Source.Git.Verification = &corev1alpha1.SourceVerification{
+				SecretRef: corev1.LocalObjectReference{Name: "trusted-keys"},
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Equal(t, "prepare", pod.Spec.InitContainers[0].Name)
+			assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts,
+				corev1.VolumeMount{
+					Name:      "source-verification-dir",
+					MountPath: "/sourceVerification",
+					ReadOnly:  true,
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_VERIFY_SIGNATURE",
+					Value: "true",
+				})
+
+			match := 0
+			for _, v := range pod.Spec.Volumes {
+				if v.Name == "source-verification-dir" {
+					require.NotNil(t, v.Secret)
+					assert.Equal(t, "trusted-keys", v.Secret.SecretName)
+					match++
+				}
+			}
+			assert.Equal(t, 1, match)
+		})
+
+		it("mounts the volume source in prepare", func() {
+			build.Spec.Source = corev1alpha1.SourceConfig{
+				Volume: &corev1alpha1.Volume{
+					PersistentVolumeClaim: &corev1alpha1.PersistentVolumeClaimSource{
+						ClaimName: "some-claim",
+						Path:      "some/path",
+					},
+					Digest: "sha256:some-digest",
+				},
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Equal(t, "prepare", pod.Spec.InitContainers[0].Name)
+			assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts,
+				corev1.VolumeMount{
+					Name:      "volume-source-dir",
+					MountPath: "/volumeSource",
+					ReadOnly:  true,
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "VOLUME_SOURCE",
+					Value: "persistentvolumeclaim/some-claim/some/path",
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "VOLUME_SOURCE_PATH",
+					Value: "some/path",
+				})
+
+			match := 0
+			for _, v := range pod.Spec.Volumes {
+				if v.Name == "volume-source-dir" {
+					require.NotNil(t, v.PersistentVolumeClaim)
+					assert.Equal(t, "some-claim", v.PersistentVolumeClaim.ClaimName)
+					assert.True(t, v.PersistentVolumeClaim.ReadOnly)
+					match++
+				}
+			}
+			assert.Equal(t, 1, match)
+		})
+
 		it("configures detect step", func() {
 			pod, err := build.BuildPod(config, buildContext)
 			require.NoError(t, err)
@@ -998,6 +1098,21 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 
+		it("configures the lifecycle log level in all lifecycle steps", func() {
+			build.Spec.LogLevel = "debug"
+
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			for _, container := range pod.Spec.InitContainers {
+				if container.Name == "prepare" {
+					assert.NotContains(t, container.Env, corev1.EnvVar{Name: "CNB_LOG_LEVEL", Value: "debug"})
+				} else {
+					assert.Contains(t, container.Env, corev1.EnvVar{Name: "CNB_LOG_LEVEL", Value: "debug"}, fmt.Sprintf("env on container '%s'", container.Name))
+				}
+			}
+		})
+
 		it("configures the init containers with resources", func() {
 			pod, err := build.BuildPod(config, buildContext)
 			require.NoError(t, err)
@@ -2180,6 +2295,95 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		it("does not extend the build image without image extensions", func() {
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(pod.Spec.InitContainers))
+			for _, container := range pod.Spec.InitContainers {
+				names = append(names, container.Name)
+				assert.NotContains(t, container.Env, corev1.EnvVar{Name: "CNB_EXPERIMENTAL_MODE", Value: "warn"}, container.Name)
+				assert.True(t, *container.SecurityContext.RunAsNonRoot, container.Name)
+			}
+			assert.Equal(t, []string{"prepare", "analyze", "detect", "restore", "build", "export"}, names)
+
+			assert.NotContains(t, pod.Spec.InitContainers[3].Args, "-build-image="+builderImage)
+			assert.Equal(t, []string{"/cnb/lifecycle/builder"}, pod.Spec.InitContainers[4].Command)
+			assert.Equal(t, []string{
+				"-layers=/layers",
+				"-app=/workspace",
+				"-group=/layers/group.toml",
+				"-plan=/layers/plan.toml",
+			}, pod.Spec.InitContainers[4].Args)
+		})
+
+		when("the builder has image extensions", func() {
+			it.Before(func() {
+				buildContext.BuildPodBuilderConfig.Extensions = true
+				buildContext.BuildPodBuilderConfig.PlatformAPIs = []string{"0.8", "0.9", "0.10"}
+			})
+
+			it("uses platform api 0.10 and enables experimental mode in the lifecycle steps", func() {
+				pod, err := build.BuildPod(config, buildContext)
+				require.NoError(t, err)
+
+				for _, container := range pod.Spec.InitContainers[1:] {
+					if container.Name == "check-extensions" {
+						continue
+					}
+					assert.Contains(t, container.Env, corev1.EnvVar{Name: "CNB_PLATFORM_API", Value: "0.10"}, container.Name)
+					assert.Contains(t, container.Env, corev1.EnvVar{Name: "CNB_EXPERIMENTAL_MODE", Value: "warn"}, container.Name)
+				}
+			})
+
+			it("restores the build image", func() {
+				pod, err := build.BuildPod(config, buildContext)
+				require.NoError(t, err)
+
+				assert.Equal(t, "restore", pod.Spec.InitContainers[4].Name)
+				assert.Contains(t, pod.Spec.InitContainers[4].Args, "-build-image="+builderImage)
+			})
+
+			it("rejects run image extensions after detection", func() {
+				pod, err := build.BuildPod(config, buildContext)
+				require.NoError(t, err)
+
+				checkContainer := pod.Spec.InitContainers[3]
+				assert.Equal(t, "detect", pod.Spec.InitContainers[2].Name)
+				assert.Equal(t, "check-extensions", checkContainer.Name)
+				assert.Equal(t, config.BuildInitImage, checkContainer.Image)
+				assert.Equal(t, []string{"/cnb/process/build-init"}, checkContainer.Command)
+				assert.Equal(t, []string{"-check-extensions-generated=/layers/generated"}, checkContainer.Args)
+				assert.True(t, *checkContainer.SecurityContext.RunAsNonRoot)
+			})
+
+			it("runs the extender as root in the build step", func() {
+				pod, err := build.BuildPod(config, buildContext)
+				require.NoError(t, err)
+
+				assert.Equal(t, "build", pod.Spec.InitContainers[5].Name)
+				assert.Equal(t, []string{"/cnb/lifecycle/extender"}, pod.Spec.InitContainers[5].Command)
+				assert.Equal(t, []string{
+					"-layers=/layers",
+					"-app=/workspace",
+					"-group=/layers/group.toml",
+					"-plan=/layers/plan.toml",
+					"-generated=/layers/generated",
+					"-uid=2000",
+					"-gid=3000",
+				}, pod.Spec.InitContainers[5].Args)
+				assert.Equal(t, int64(0), *pod.Spec.InitContainers[5].SecurityContext.RunAsUser)
+				assert.False(t, *pod.Spec.InitContainers[5].SecurityContext.RunAsNonRoot)
+			})
+
+			it("errs if the builder does not support platform api 0.10", func() {
+				buildContext.BuildPodBuilderConfig.PlatformAPIs = []string{"0.8", "0.9"}
+
+				_, err := build.BuildPod(config, buildContext)
+				require.EqualError(t, err, "builder image extensions require platform API 0.10, builder supports: 0.8,0.9")
+			})
+		})
+
 		when("complying with the restricted pod security standard", func() {
 			// enforces https://kubernetes.io/docs/concepts/security/pod-security-standards/#restricted
 			var pod *corev1.Pod
@@ -1,5 +1,7 @@
 package v1alpha2
 
+import "strings"
+
 type BuildPriority int
 
 var (
@@ -19,3 +21,37 @@ var PriorityClasses = map[BuildPriority]string{
 func (p BuildPriority) PriorityClass() string {
 	return PriorityClasses[p]
 }
+
+// priority of the change for each build reason, builds are as urgent as their most urgent reason
+var buildReasonPriorities = map[string]BuildPriority{
+	BuildReasonTrigger:   BuildPriorityHigh,
+	BuildReasonRequest:   BuildPriorityHigh,
+	BuildReasonCommit:    BuildPriorityHigh,
+	BuildReasonConfig:    BuildPriorityHigh,
+	BuildReasonBlob:      BuildPriorityHigh,
+	BuildReasonRegistry:  BuildPriorityHigh,
+	BuildReasonVolume:    BuildPriorityHigh,
+	BuildReasonBuildpack: BuildPriorityLow,
+	BuildReasonStack:     BuildPriorityLow,
+	BuildReasonLifecycle: BuildPriorityLow,
+	BuildReasonScheduled: BuildPriorityLow,
+	BuildReasonUpstream:  BuildPriorityLow,
+	BuildReasonRetry:     BuildPriorityLow,
+}
+
+// Priority is the highest priority of the build reasons. Builds without reasons were not created by an
+// Image and are high priority.
+func (b *Build) Priority() BuildPriority {
+	reasons := b.BuildReason()
+	if reasons == "" {
+		return BuildPriorityHigh
+	}
+
+	priority := BuildPriorityNone
+	for _, reason := range strings.Split(reasons, ",") {
+		if p := buildReasonPriorities[reason]; p > priority {
+			priority = p
+		}
+	}
+	return priority
+}
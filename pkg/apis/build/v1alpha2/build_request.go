@@ -0,0 +1,107 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// BuildRequestAnnotation requests a one-off build of an Image with overrides that are not applied to later builds.
+// The request is kept on the build created for it.
+const BuildRequestAnnotation = "image.kpack.io/buildRequest"
+
+var logLevels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"warn":  true,
+	"error": true,
+}
+
+// BuildRequest overrides the source revision, env and lifecycle log level of a single build
+type BuildRequest struct {
+	// ID identifies the request, a build is created once for each ID
+	ID string `json:"id,omitempty"`
+	// Revision is the git commit to build instead of the resolved revision
+	Revision string `json:"revision,omitempty"`
+	// Env is added to, or replaces variables with the same name in, the Image env
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// LogLevel is the log level of the lifecycle phases
+	LogLevel string `json:"logLevel,omitempty"`
+}
+
+func ParseBuildRequest(requestJson string) (*BuildRequest, error) {
+	var request BuildRequest
+	if err := json.Unmarshal([]byte(requestJson), &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// BuildRequest returns the build request annotated on the image, or nil if there is none
+func (im *Image) BuildRequest() (*BuildRequest, error) {
+	requestJson, ok := im.Annotations[BuildRequestAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return ParseBuildRequest(requestJson)
+}
+
+// BuildRequest returns the build request the build was created for, or nil if it was not requested
+func (b *Build) BuildRequest() (*BuildRequest, error) {
+	if b == nil {
+		return nil, nil
+	}
+	requestJson, ok := b.Annotations[BuildRequestAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return ParseBuildRequest(requestJson)
+}
+
+// Apply overrides the build with the request
+func (r *BuildRequest) Apply(build *Build) {
+	if r.Revision != "" && build.Spec.Source.Git != nil {
+		build.Spec.Source.Git.Revision = r.Revision
+	}
+	build.Spec.Env = mergeEnv(build.Spec.Env, r.Env)
+	build.Spec.LogLevel = r.LogLevel
+}
+
+func mergeEnv(env, overrides []corev1.EnvVar) []corev1.EnvVar {
+	if len(overrides) == 0 {
+		return env
+	}
+
+	overridden := map[string]bool{}
+	for _, envVar := range overrides {
+		overridden[envVar.Name] = true
+	}
+
+	merged := make([]corev1.EnvVar, 0, len(env)+len(overrides))
+	for _, envVar := range env {
+		if !overridden[envVar.Name] {
+			merged = append(merged, envVar)
+		}
+	}
+	return append(merged, overrides...)
+}
+
+func (r *BuildRequest) Validate(spec *ImageSpec) *apis.FieldError {
+	var errs *apis.FieldError
+	if r.ID == "" {
+		errs = errs.Also(apis.ErrMissingField("id"))
+	}
+	if r.Revision != "" && spec.Source.Git == nil {
+		errs = errs.Also(apis.ErrGeneric("revision can only be overridden for git sources", "revision"))
+	}
+	for i, envVar := range r.Env {
+		if envVar.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("env", i))
+		}
+	}
+	if r.LogLevel != "" && !logLevels[r.LogLevel] {
+		errs = errs.Also(apis.ErrInvalidValue(r.LogLevel, "logLevel"))
+	}
+	return errs
+}
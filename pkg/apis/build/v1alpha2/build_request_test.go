@@ -0,0 +1,83 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestBuildRequest(t *testing.T) {
+	spec.Run(t, "Build Request", testBuildRequest)
+}
+
+func testBuildRequest(t *testing.T, when spec.G, it spec.S) {
+	build := &Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				BuildRequestAnnotation: `{"id":"some-request","revision":"requested-revision","env":[{"name":"DEBUG","value":"true"}],"logLevel":"debug"}`,
+			},
+		},
+		Spec: BuildSpec{
+			Source: corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{URL: "https://some.git/url", Revision: "resolved-revision"},
+			},
+			Env: []corev1.EnvVar{
+				{Name: "DEBUG", Value: "false"},
+				{Name: "BP_JAVA_VERSION", Value: "17"},
+			},
+		},
+	}
+
+	when("BuildRequest", func() {
+		it("parses the request the build was created for", func() {
+			request, err := build.BuildRequest()
+			require.NoError(t, err)
+
+			assert.Equal(t, &BuildRequest{
+				ID:       "some-request",
+				Revision: "requested-revision",
+				Env:      []corev1.EnvVar{{Name: "DEBUG", Value: "true"}},
+				LogLevel: "debug",
+			}, request)
+		})
+
+		it("is nil if the build was not requested", func() {
+			request, err := (&Build{}).BuildRequest()
+			require.NoError(t, err)
+			assert.Nil(t, request)
+		})
+	})
+
+	when("Apply", func() {
+		it("overrides the revision, env and log level of the build", func() {
+			request, err := build.BuildRequest()
+			require.NoError(t, err)
+
+			request.Apply(build)
+
+			assert.Equal(t, "requested-revision", build.Spec.Source.Git.Revision)
+			assert.Equal(t, []corev1.EnvVar{
+				{Name: "BP_JAVA_VERSION", Value: "17"},
+				{Name: "DEBUG", Value: "true"},
+			}, build.Spec.Env)
+			assert.Equal(t, "debug", build.Spec.LogLevel)
+		})
+
+		it("keeps the build config that is not overridden", func() {
+			(&BuildRequest{ID: "some-request"}).Apply(build)
+
+			assert.Equal(t, "resolved-revision", build.Spec.Source.Git.Revision)
+			assert.Equal(t, []corev1.EnvVar{
+				{Name: "DEBUG", Value: "false"},
+				{Name: "BP_JAVA_VERSION", Value: "17"},
+			}, build.Spec.Env)
+			assert.Equal(t, "", build.Spec.LogLevel)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"knative.dev/pkg/apis"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const (
+	defaultRetryBackoff = time.Minute
+	// maxRetryDelay caps the doubling backoff of later attempts
+	maxRetryDelay    = 24 * time.Hour
+	maxRetryAttempts = 100
+)
+
+// failures caused by the application or its source that fail again when retried
+var nonRetryableReasons = map[string]bool{
+	SourceVerificationFailedReason: true,
+	DetectFailedReason:             true,
+	CompileFailedReason:            true,
+}
+
+// RetryableFailure is true if the build failed for a reason that may be transient
+func (b *Build) RetryableFailure() bool {
+	if !b.IsFailure() || b.IsCanceled() {
+		return false
+	}
+	return !nonRetryableReasons[b.Status.GetCondition(corev1alpha1.ConditionSucceeded).Reason]
+}
+
+// Attempt is the number of builds created for the changes of the build. Builds that are not a retry are the
+// first attempt.
+func (b *Build) Attempt() int64 {
+	if b == nil {
+		return 0
+	}
+	attempt, err := strconv.ParseInt(b.Annotations[BuildAttemptAnnotation], 10, 64)
+	if err != nil || attempt < 1 {
+		return 1
+	}
+	return attempt
+}
+
+// FinishedTime is when the build succeeded or failed
+func (b *Build) FinishedTime() time.Time {
+	condition := b.Status.GetCondition(corev1alpha1.ConditionSucceeded)
+	if condition == nil {
+		return time.Time{}
+	}
+	return condition.LastTransitionTime.Inner.Time
+}
+
+// RetryDelay is how long to wait after the build attempt failed before retrying it, it is at most a day
+func (p *BuildRetryPolicy) RetryDelay(attempt int64) time.Duration {
+	backoff := defaultRetryBackoff
+	if p.Backoff != nil {
+		backoff = p.Backoff.Duration
+	}
+	for i := int64(1); i < attempt && backoff < maxRetryDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return backoff
+}
+
+func (p *BuildRetryPolicy) Validate() *apis.FieldError {
+	if p == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if p.MaxAttempts < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(p.MaxAttempts, "maxAttempts", "must be at least 1"))
+	}
+	if p.MaxAttempts > maxRetryAttempts {
+		errs = errs.Also(apis.ErrInvalidValue(p.MaxAttempts, "maxAttempts", fmt.Sprintf("must be at most %d", maxRetryAttempts)))
+	}
+	if p.Backoff != nil && p.Backoff.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.Backoff.Duration.String(), "backoff", "must not be negative"))
+	}
+	return errs
+}
@@ -0,0 +1,87 @@
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestBuildRetry(t *testing.T) {
+	spec.Run(t, "Build Retry", testBuildRetry)
+}
+
+func testBuildRetry(t *testing.T, when spec.G, it spec.S) {
+	failedBuild := func(reason string) *Build {
+		return &Build{
+			Status: BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:   corev1alpha1.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+							Reason: reason,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	when("RetryableFailure", func() {
+		it("is true for builds that failed for a transient reason", func() {
+			assert.True(t, failedBuild("PodFailed").RetryableFailure())
+			assert.True(t, failedBuild("").RetryableFailure())
+		})
+
+		it("is false for builds that failed because of the application or its source or were canceled", func() {
+			assert.False(t, failedBuild(DetectFailedReason).RetryableFailure())
+			assert.False(t, failedBuild(CompileFailedReason).RetryableFailure())
+			assert.False(t, failedBuild(SourceVerificationFailedReason).RetryableFailure())
+			assert.False(t, failedBuild(BuildCanceledReason).RetryableFailure())
+		})
+
+		it("is false for builds that have not failed", func() {
+			assert.False(t, (&Build{}).RetryableFailure())
+		})
+	})
+
+	when("Attempt", func() {
+		it("reads the attempt annotation", func() {
+			build := &Build{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{BuildAttemptAnnotation: "3"}}}
+			assert.Equal(t, int64(3), build.Attempt())
+		})
+
+		it("is the first attempt for builds that are not a retry", func() {
+			assert.Equal(t, int64(1), (&Build{}).Attempt())
+			assert.Equal(t, int64(1), (&Build{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{BuildAttemptAnnotation: "invalid"}}}).Attempt())
+		})
+	})
+
+	when("RetryDelay", func() {
+		it("doubles the backoff for each attempt", func() {
+			policy := &BuildRetryPolicy{MaxAttempts: 4, Backoff: &metav1.Duration{Duration: 30 * time.Second}}
+			assert.Equal(t, 30*time.Second, policy.RetryDelay(1))
+			assert.Equal(t, time.Minute, policy.RetryDelay(2))
+			assert.Equal(t, 2*time.Minute, policy.RetryDelay(3))
+		})
+
+		it("defaults the backoff to one minute", func() {
+			assert.Equal(t, time.Minute, (&BuildRetryPolicy{MaxAttempts: 2}).RetryDelay(1))
+		})
+
+		it("caps the delay at a day", func() {
+			policy := &BuildRetryPolicy{MaxAttempts: 100}
+			assert.Equal(t, 16*time.Hour+64*time.Minute, policy.RetryDelay(11))
+			assert.Equal(t, 24*time.Hour, policy.RetryDelay(12))
+			assert.Equal(t, 24*time.Hour, policy.RetryDelay(28))
+			assert.Equal(t, 24*time.Hour, policy.RetryDelay(100))
+			assert.Equal(t, 24*time.Hour, (&BuildRetryPolicy{MaxAttempts: 1, Backoff: &metav1.Duration{Duration: 48 * time.Hour}}).RetryDelay(1))
+		})
+	})
+}
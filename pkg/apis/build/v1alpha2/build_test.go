@@ -61,6 +61,23 @@ func TestBuildReason(t *testing.T) {
 	require.True(t, build.BuildReason() == BuildReasonStack)
 }
 
+func TestBuildCanceled(t *testing.T) {
+	build := &Build{}
+	require.False(t, build.CancelRequested())
+	require.False(t, build.IsCanceled())
+
+	build.Annotations = map[string]string{BuildCancelAnnotation: ""}
+	require.True(t, build.CancelRequested())
+
+	build.Status.Canceled()
+	require.True(t, build.IsCanceled())
+	require.True(t, build.IsFailure())
+	require.True(t, build.Finished())
+
+	build.Status.Error(errors.New("some error"))
+	require.False(t, build.IsCanceled())
+}
+
 func TestBuildLifecycle(t *testing.T) {
 	build := &Build{
 		ObjectMeta: metav1.ObjectMeta{
@@ -63,7 +63,12 @@ type BuildSpec struct {
 	// +listType
 	CNBBindings corev1alpha1.CNBBindings `json:"cnbBindings,omitempty"`
 	// +listType
-	Env                   []corev1.EnvVar             `json:"env,omitempty"`
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// +listType
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// EnvSources are the hashes of the Secrets and ConfigMaps referenced by Env and EnvFrom when the build was created
+	// +listType
+	EnvSources            []BuildEnvSource            `json:"envSources,omitempty"`
 	ProjectDescriptorPath string                      `json:"projectDescriptorPath,omitempty"`
 	Resources             corev1.ResourceRequirements `json:"resources,omitempty"`
 	LastBuild             *LastBuild                  `json:"lastBuild,omitempty"`
@@ -78,6 +83,8 @@ type BuildSpec struct {
 	SchedulerName     string              `json:"schedulerName,omitempty"`
 	PriorityClassName string              `json:"priorityClassName,omitempty"`
 	CreationTime      string              `json:"creationTime,omitempty"`
+	// LogLevel is the log level of the lifecycle phases
+	LogLevel string `json:"logLevel,omitempty"`
 }
 
 func (bs *BuildSpec) RegistryCacheTag() string {
@@ -6,7 +6,6 @@ import (
 	"regexp"
 
 	authv1 "k8s.io/api/authentication/v1"
-	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
 
@@ -22,7 +21,8 @@ func (b *Build) SetDefaults(ctx context.Context) {
 }
 
 func (b *Build) Validate(ctx context.Context) *apis.FieldError {
-	return b.Spec.Validate(ctx).ViaField("spec")
+	return b.Spec.Validate(ctx).ViaField("spec").
+		Also(validateBuildEnvSecretRefs(ctx, b.Namespace, b.Spec.Env, b.Spec.EnvFrom).ViaField("spec"))
 }
 
 func (bs *BuildSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -36,7 +36,7 @@ func (bs *BuildSpec) Validate(ctx context.Context) *apis.FieldError {
 		Also(bs.validateImmutableFields(ctx)).
 		Also(validateCnbBindings(ctx, bs.CNBBindings).ViaField("cnbBindings")).
 		Also(bs.validateNodeSelector(ctx)).
-		Also(validateBuildEnvSecretKeyRefs(bs.Env).ViaField("env")).
+		Also(validateBuildEnvFrom(bs.EnvFrom).ViaField("envFrom")).
 		Also(validateNotary(ctx, bs.Notary).ViaField("notary"))
 }
 
@@ -82,16 +82,6 @@ func (bs *BuildSpec) validateNodeSelector(_ context.Context) *apis.FieldError {
 
 }
 
-func validateBuildEnvSecretKeyRefs(env []corev1.EnvVar) *apis.FieldError {
-	var errs *apis.FieldError
-	for i, envVar := range env {
-		if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
-			errs = errs.Also(apis.ErrGeneric("secretKeyRef is not supported for build environment variables", fmt.Sprintf("[%d].valueFrom.secretKeyRef", i)))
-		}
-	}
-	return errs
-}
-
 func (lb *LastBuild) Validate(context context.Context) *apis.FieldError {
 	if lb == nil || lb.Image == "" {
 		return nil
@@ -144,6 +144,23 @@ func testBuildValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(build, context.TODO(), apis.ErrGeneric("secretKeyRef is not supported for build environment variables", "spec.env[0].valueFrom.secretKeyRef"))
 		})
 
+		it("allows secret refs in builds created by the kpack controller", func() {
+			build.Spec.EnvFrom = []corev1.EnvFromSource{
+				{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret"}}},
+			}
+
+			assert.Nil(t, build.Validate(apis.WithUserInfo(context.TODO(), &authv1.UserInfo{Username: kpackControllerServiceAccountUsername})))
+		})
+
+		it("validates build env from sources", func() {
+			build.Spec.EnvFrom = []corev1.EnvFromSource{
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-config-map"}}},
+				{},
+			}
+
+			assertValidationError(build, context.TODO(), apis.ErrMissingOneOf("spec.envFrom[1].secretRef", "spec.envFrom[1].configMapRef"))
+		})
+
 		it("multiple sources", func() {
 			build.Spec.Source.Git = &corev1alpha1.Git{
 				URL:      "http://github.com/repo",
@@ -163,7 +180,7 @@ func testBuildValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			build.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(build, context.TODO(), apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(build, context.TODO(), apis.ErrMissingOneOf("git", "blob", "registry", "volume").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
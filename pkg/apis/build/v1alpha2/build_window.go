@@ -0,0 +1,160 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// BuildWindowsAnnotation may be set on a namespace to a json list of build windows that apply to
+// every Image in the namespace that does not configure its own
+const BuildWindowsAnnotation = "kpack.io/build-windows"
+
+// +k8s:openapi-gen=true
+type BuildWindow struct {
+	// Reasons are the build reasons, such as STACK or BUILDPACK, that are only built while the window is open
+	// +listType
+	Reasons []string `json:"reasons"`
+	// Schedule is a cron expression on which the window opens
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open
+	Duration metav1.Duration `json:"duration"`
+}
+
+type BuildWindows []BuildWindow
+
+// TRIGGER, REQUEST and COMMIT builds are requested by a person and are never deferred
+var windowBypassReasons = map[string]bool{
+	BuildReasonTrigger: true,
+	BuildReasonRequest: true,
+	BuildReasonCommit:  true,
+}
+
+// reasons that may be deferred by a build window
+var windowReasons = map[string]bool{
+	BuildReasonConfig:    true,
+	BuildReasonBuildpack: true,
+	BuildReasonStack:     true,
+	BuildReasonLifecycle: true,
+	BuildReasonBlob:      true,
+	BuildReasonRegistry:  true,
+	BuildReasonVolume:    true,
+	BuildReasonScheduled: true,
+	BuildReasonUpstream:  true,
+}
+
+func ParseBuildWindows(windowsJson string) (BuildWindows, error) {
+	var windows BuildWindows
+	if err := json.Unmarshal([]byte(windowsJson), &windows); err != nil {
+		return nil, err
+	}
+
+	if err := windows.Validate(context.TODO()); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+// DeferredUntil returns when the earliest window for the comma separated reasons opens, or nil if a build for
+// the reasons is allowed now. Reasons without a window are always allowed.
+func (bw BuildWindows) DeferredUntil(reasons string, now time.Time) (*time.Time, error) {
+	var deferredUntil *time.Time
+	for _, reason := range strings.Split(reasons, ",") {
+		if windowBypassReasons[reason] {
+			return nil, nil
+		}
+
+		next, err := bw.nextOpen(reason, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if next == nil || !next.After(now) {
+			return nil, nil
+		}
+
+		if deferredUntil == nil || next.Before(*deferredUntil) {
+			deferredUntil = next
+		}
+	}
+	return deferredUntil, nil
+}
+
+// nextOpen returns the earliest time at or after now that a window for the reason is open, or nil if no window
+// applies to the reason
+func (bw BuildWindows) nextOpen(reason string, now time.Time) (*time.Time, error) {
+	var nextOpen *time.Time
+	for _, w := range bw {
+		if !w.appliesTo(reason) {
+			continue
+		}
+
+		next, err := w.nextOpen(now)
+		if err != nil {
+			return nil, err
+		}
+
+		if nextOpen == nil || next.Before(*nextOpen) {
+			nextOpen = &next
+		}
+	}
+	return nextOpen, nil
+}
+
+func (w BuildWindow) appliesTo(reason string) bool {
+	for _, r := range w.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func (w BuildWindow) nextOpen(now time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// the window is open if it opened within the last duration
+	if opened := schedule.Next(now.UTC().Add(-w.Duration.Duration)); !opened.After(now) {
+		return now, nil
+	}
+	return schedule.Next(now.UTC()), nil
+}
+
+func (bw BuildWindows) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, w := range bw {
+		errs = errs.Also(w.Validate(ctx).ViaIndex(i))
+	}
+	return errs
+}
+
+func (w BuildWindow) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if len(w.Reasons) == 0 {
+		errs = errs.Also(apis.ErrMissingField("reasons"))
+	}
+	for i, reason := range w.Reasons {
+		if !windowReasons[reason] {
+			errs = errs.Also(apis.ErrInvalidArrayValue(reason, "reasons", i))
+		}
+	}
+
+	if w.Schedule == "" {
+		errs = errs.Also(apis.ErrMissingField("schedule"))
+	} else if _, err := cron.ParseStandard(w.Schedule); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(w.Schedule, "schedule", err.Error()))
+	}
+
+	if w.Duration.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(w.Duration.Duration.String(), "duration", "must be greater than 0"))
+	}
+	return errs
+}
@@ -0,0 +1,125 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestBuildWindows(t *testing.T) {
+	spec.Run(t, "Build Windows", testBuildWindows)
+}
+
+func testBuildWindows(t *testing.T, when spec.G, it spec.S) {
+	// opens at 22:00 and closes at 04:00 every day
+	nightly := BuildWindow{
+		Reasons:  []string{BuildReasonStack, BuildReasonBuildpack},
+		Schedule: "0 22 * * *",
+		Duration: metav1.Duration{Duration: 6 * time.Hour},
+	}
+
+	// opens at 12:00 and closes at 13:00 on saturdays
+	weekend := BuildWindow{
+		Reasons:  []string{BuildReasonBuildpack},
+		Schedule: "0 12 * * 6",
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+
+	windows := BuildWindows{nightly, weekend}
+
+	// wednesday
+	afternoon := time.Date(2024, time.January, 10, 15, 0, 0, 0, time.UTC)
+	night := time.Date(2024, time.January, 11, 2, 0, 0, 0, time.UTC)
+
+	when("#DeferredUntil", func() {
+		it("defers builds until the window opens", func() {
+			deferredUntil, err := windows.DeferredUntil(BuildReasonStack, afternoon)
+			require.NoError(t, err)
+			require.NotNil(t, deferredUntil)
+			assert.Equal(t, time.Date(2024, time.January, 10, 22, 0, 0, 0, time.UTC), *deferredUntil)
+		})
+
+		it("does not defer builds while a window is open", func() {
+			deferredUntil, err := windows.DeferredUntil(BuildReasonStack, night)
+			require.NoError(t, err)
+			assert.Nil(t, deferredUntil)
+		})
+
+		it("does not defer builds once the window duration has passed and it reopens", func() {
+			deferredUntil, err := windows.DeferredUntil(BuildReasonStack, time.Date(2024, time.January, 11, 4, 0, 0, 0, time.UTC))
+			require.NoError(t, err)
+			require.NotNil(t, deferredUntil)
+			assert.Equal(t, time.Date(2024, time.January, 11, 22, 0, 0, 0, time.UTC), *deferredUntil)
+		})
+
+		it("uses the earliest window for a reason", func() {
+			deferredUntil, err := BuildWindows{weekend, nightly}.DeferredUntil(BuildReasonBuildpack, afternoon)
+			require.NoError(t, err)
+			require.NotNil(t, deferredUntil)
+			assert.Equal(t, time.Date(2024, time.January, 10, 22, 0, 0, 0, time.UTC), *deferredUntil)
+		})
+
+		it("does not defer reasons without a window", func() {
+			deferredUntil, err := windows.DeferredUntil(BuildReasonConfig, afternoon)
+			require.NoError(t, err)
+			assert.Nil(t, deferredUntil)
+
+			deferredUntil, err = windows.DeferredUntil("STACK,CONFIG", afternoon)
+			require.NoError(t, err)
+			assert.Nil(t, deferredUntil)
+		})
+
+		it("does not defer trigger or commit builds", func() {
+			deferredUntil, err := windows.DeferredUntil("TRIGGER,STACK", afternoon)
+			require.NoError(t, err)
+			assert.Nil(t, deferredUntil)
+
+			deferredUntil, err = windows.DeferredUntil("COMMIT,STACK", afternoon)
+			require.NoError(t, err)
+			assert.Nil(t, deferredUntil)
+		})
+	})
+
+	when("#ParseBuildWindows", func() {
+		it("parses build windows", func() {
+			parsed, err := ParseBuildWindows(`[{"reasons":["STACK","BUILDPACK"],"schedule":"0 22 * * *","duration":"6h"}]`)
+			require.NoError(t, err)
+			assert.Equal(t, BuildWindows{nightly}, parsed)
+		})
+
+		it("errors on invalid build windows", func() {
+			_, err := ParseBuildWindows(`[{"reasons":["COMMIT"],"schedule":"0 22 * * *","duration":"6h"}]`)
+			require.EqualError(t, err, "invalid value: COMMIT: [0].reasons[0]")
+
+			_, err = ParseBuildWindows(`not json`)
+			require.Error(t, err)
+		})
+	})
+
+	when("#Validate", func() {
+		it("requires reasons, a schedule and a duration", func() {
+			err := BuildWindow{}.Validate(context.TODO())
+			assert.EqualError(t, err, apis.ErrMissingField("reasons", "schedule").Also(apis.ErrInvalidValue("0s", "duration", "must be greater than 0")).Error())
+		})
+
+		it("validates the schedule is a cron expression", func() {
+			window := nightly
+			window.Schedule = "nightly"
+			err := window.Validate(context.TODO())
+			assert.EqualError(t, err, apis.ErrInvalidValue("nightly", "schedule", "expected exactly 5 fields, found 1: [nightly]").Error())
+		})
+
+		it("validates reasons may be deferred", func() {
+			window := nightly
+			window.Reasons = []string{BuildReasonTrigger, "SOMETHING"}
+			err := window.Validate(context.TODO())
+			assert.EqualError(t, err, apis.ErrInvalidArrayValue("TRIGGER", "reasons", 0).Also(apis.ErrInvalidArrayValue("SOMETHING", "reasons", 1)).Error())
+		})
+	})
+}
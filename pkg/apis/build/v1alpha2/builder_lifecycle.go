@@ -14,10 +14,13 @@ type BuilderRecord struct {
 	Lifecycle               ResolvedClusterLifecycle
 	Buildpacks              corev1alpha1.BuildpackMetadataList
 	Order                   []corev1alpha1.OrderEntry
+	Extensions              corev1alpha1.BuildpackMetadataList
+	OrderExtensions         []corev1alpha1.OrderEntry
 	ObservedStoreGeneration int64
 	ObservedStackGeneration int64
 	OS                      string
 	SignaturePaths          []CosignSignature
+	PlatformImages          []BuilderPlatformImage
 }
 
 func (bs *BuilderStatus) BuilderRecord(record BuilderRecord) {
@@ -38,10 +41,13 @@ func (bs *BuilderStatus) BuilderRecord(record BuilderRecord) {
 		},
 	}
 	bs.Order = record.Order
+	bs.ExtensionMetadata = record.Extensions
+	bs.OrderExtensions = record.OrderExtensions
 	bs.ObservedStoreGeneration = record.ObservedStoreGeneration
 	bs.ObservedStackGeneration = record.ObservedStackGeneration
 	bs.OS = record.OS
 	bs.SignaturePaths = record.SignaturePaths
+	bs.PlatformImages = record.PlatformImages
 }
 
 func (bs *BuilderStatus) ErrorCreate(err error) {
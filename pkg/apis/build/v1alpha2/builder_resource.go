@@ -13,4 +13,6 @@ type BuilderResource interface {
 	LifecycleVersion() string
 	GetKind() string
 	ConditionReadyMessage() string
+	RolloutPending(image *Image) bool
+	Platforms() []string
 }
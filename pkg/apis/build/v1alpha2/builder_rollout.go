@@ -0,0 +1,110 @@
+package v1alpha2
+
+import (
+	"hash/fnv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	RolloutPhaseCanary   = "Canary"
+	RolloutPhasePromoted = "Promoted"
+	RolloutPhaseHalted   = "Halted"
+
+	defaultRolloutSuccessThreshold = 100
+)
+
+// +k8s:openapi-gen=true
+type BuilderRollout struct {
+	// Selector selects the canary Images that rebuild first when the latest image of the builder changes
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Percentage of the Images built with the builder, chosen by name, that rebuild first
+	Percentage int64 `json:"percentage,omitempty"`
+	// SuccessThreshold is the percentage of canary Images that must build successfully before the remaining
+	// Images rebuild. Defaults to 100.
+	SuccessThreshold int64 `json:"successThreshold,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuilderRolloutStatus struct {
+	// Image is the latest image of the builder that is being rolled out
+	Image string `json:"image,omitempty"`
+	// Phase is Canary while canary Images rebuild, Promoted once the remaining Images may rebuild and Halted if
+	// too many canary builds failed
+	Phase           string `json:"phase,omitempty"`
+	CanaryImages    int64  `json:"canaryImages,omitempty"`
+	SucceededImages int64  `json:"succeededImages,omitempty"`
+	FailedImages    int64  `json:"failedImages,omitempty"`
+	// SkippedImages are canary Images with a pending build that is deferred by a build window or not allowed by
+	// their allowed build reasons. They do not count towards the success threshold.
+	SkippedImages int64 `json:"skippedImages,omitempty"`
+}
+
+// IsCanary is true if the image is selected by the label selector or is within the percentage of canary Images
+func (r *BuilderRollout) IsCanary(image *Image) bool {
+	if r.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(r.Selector)
+		if err == nil && !selector.Empty() && selector.Matches(labels.Set(image.Labels)) {
+			return true
+		}
+	}
+
+	if r.Percentage <= 0 {
+		return false
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(image.Namespace + "/" + image.Name))
+	return int64(hash.Sum32()%100) < r.Percentage
+}
+
+// Progress records the canary build results of the rollout and promotes or halts it. Skipped canary Images are
+// left out, so canaries that cannot build do not keep the rollout in the Canary phase.
+func (r *BuilderRollout) Progress(status *BuilderRolloutStatus, canaryImages, succeededImages, failedImages, skippedImages int64) {
+	status.CanaryImages = canaryImages
+	status.SucceededImages = succeededImages
+	status.FailedImages = failedImages
+	status.SkippedImages = skippedImages
+
+	buildingImages := canaryImages - skippedImages
+	switch {
+	case succeededImages*100 >= r.successThreshold()*buildingImages:
+		status.Phase = RolloutPhasePromoted
+	case succeededImages+failedImages >= buildingImages:
+		status.Phase = RolloutPhaseHalted
+	default:
+		status.Phase = RolloutPhaseCanary
+	}
+}
+
+func (r *BuilderRollout) successThreshold() int64 {
+	if r.SuccessThreshold == 0 {
+		return defaultRolloutSuccessThreshold
+	}
+	return r.SuccessThreshold
+}
+
+func (r *BuilderRollout) Validate() *apis.FieldError {
+	if r == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if r.Selector == nil && r.Percentage == 0 {
+		errs = errs.Also(apis.ErrMissingOneOf("selector", "percentage"))
+	}
+	if r.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Selector); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "selector"))
+		}
+	}
+	if r.Percentage < 0 || r.Percentage > 100 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(r.Percentage, 0, 100, "percentage"))
+	}
+	if r.SuccessThreshold < 0 || r.SuccessThreshold > 100 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(r.SuccessThreshold, 0, 100, "successThreshold"))
+	}
+	return errs
+}
@@ -0,0 +1,82 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuilderRollout(t *testing.T) {
+	spec.Run(t, "Builder Rollout", testBuilderRollout)
+}
+
+func testBuilderRollout(t *testing.T, when spec.G, it spec.S) {
+	when("IsCanary", func() {
+		it("selects images matching the selector", func() {
+			rollout := &BuilderRollout{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "canary"}}}
+
+			assert.True(t, rollout.IsCanary(&Image{ObjectMeta: metav1.ObjectMeta{Name: "some-image", Labels: map[string]string{"tier": "canary"}}}))
+			assert.False(t, rollout.IsCanary(&Image{ObjectMeta: metav1.ObjectMeta{Name: "some-image"}}))
+		})
+
+		it("selects a stable percentage of images", func() {
+			rollout := &BuilderRollout{Percentage: 25}
+
+			var canaries int
+			for i := 0; i < 1000; i++ {
+				image := &Image{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("image-%d", i), Namespace: "some-namespace"}}
+				if rollout.IsCanary(image) {
+					canaries++
+				}
+				assert.Equal(t, rollout.IsCanary(image), rollout.IsCanary(image))
+			}
+			assert.InDelta(t, 250, canaries, 50)
+
+			assert.False(t, (&BuilderRollout{Percentage: 0}).IsCanary(&Image{ObjectMeta: metav1.ObjectMeta{Name: "image-1"}}))
+			assert.True(t, (&BuilderRollout{Percentage: 100}).IsCanary(&Image{ObjectMeta: metav1.ObjectMeta{Name: "image-1"}}))
+		})
+	})
+
+	when("Progress", func() {
+		rollout := &BuilderRollout{Percentage: 10, SuccessThreshold: 50}
+
+		it("waits for canary builds", func() {
+			status := &BuilderRolloutStatus{}
+			rollout.Progress(status, 4, 1, 1, 0)
+			assert.Equal(t, &BuilderRolloutStatus{Phase: RolloutPhaseCanary, CanaryImages: 4, SucceededImages: 1, FailedImages: 1}, status)
+		})
+
+		it("promotes once the success threshold is met", func() {
+			status := &BuilderRolloutStatus{}
+			rollout.Progress(status, 4, 2, 0, 0)
+			assert.Equal(t, RolloutPhasePromoted, status.Phase)
+		})
+
+		it("halts when every canary has built without meeting the success threshold", func() {
+			status := &BuilderRolloutStatus{}
+			rollout.Progress(status, 4, 1, 3, 0)
+			assert.Equal(t, RolloutPhaseHalted, status.Phase)
+		})
+
+		it("leaves skipped canaries out of the success threshold", func() {
+			status := &BuilderRolloutStatus{}
+			(&BuilderRollout{Percentage: 10}).Progress(status, 4, 3, 0, 1)
+			assert.Equal(t, &BuilderRolloutStatus{Phase: RolloutPhasePromoted, CanaryImages: 4, SucceededImages: 3, SkippedImages: 1}, status)
+		})
+
+		it("halts when every canary that is not skipped has built without meeting the success threshold", func() {
+			status := &BuilderRolloutStatus{}
+			rollout.Progress(status, 4, 1, 2, 1)
+			assert.Equal(t, RolloutPhaseHalted, status.Phase)
+		})
+
+		it("defaults the success threshold to every canary", func() {
+			status := &BuilderRolloutStatus{}
+			(&BuilderRollout{Percentage: 10}).Progress(status, 4, 3, 0, 0)
+			assert.Equal(t, RolloutPhaseCanary, status.Phase)
+		})
+	})
+}
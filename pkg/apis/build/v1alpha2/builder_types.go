@@ -37,8 +37,26 @@ type BuilderSpec struct {
 	Lifecycle corev1.ObjectReference `json:"lifecycle,omitempty"`
 	Store     corev1.ObjectReference `json:"store,omitempty"`
 	// +listType
-	Order            []BuilderOrderEntry `json:"order,omitempty"`
+	Order []BuilderOrderEntry `json:"order,omitempty"`
+	// OrderExtensions is the order of the image extensions that generate Dockerfiles to extend the build image
+	// +listType
+	OrderExtensions  []BuilderOrderEntry `json:"orderExtensions,omitempty"`
 	AdditionalLabels map[string]string   `json:"additionalLabels,omitempty"`
+	// Rollout stages rebuilds of the Images built with the builder when its latest image changes
+	Rollout *BuilderRollout `json:"rollout,omitempty"`
+	// Platforms, such as linux/amd64 and linux/arm64, each get a builder image created from the images of the
+	// platform in the stack, lifecycle and buildpacks. The builder is pushed as an image index of the platform images.
+	// +listType
+	Platforms []string `json:"platforms,omitempty"`
+	// BuildImage and RunImage reference the stack images directly instead of a ClusterStack, the run image target
+	// is used in place of a stack id
+	BuildImage ClusterStackSpecImage `json:"buildImage,omitempty"`
+	RunImage   ClusterStackSpecImage `json:"runImage,omitempty"`
+}
+
+// ReferencesStackImages returns true if the builder references its build and run images instead of a ClusterStack
+func (s *BuilderSpec) ReferencesStackImages() bool {
+	return s.BuildImage.Image != "" || s.RunImage.Image != ""
 }
 
 // +k8s:openapi-gen=true
@@ -72,6 +90,8 @@ type BuilderStatus struct {
 	corev1alpha1.Status     `json:",inline"`
 	BuilderMetadata         corev1alpha1.BuildpackMetadataList `json:"builderMetadata,omitempty"`
 	Order                   []corev1alpha1.OrderEntry          `json:"order,omitempty"`
+	ExtensionMetadata       corev1alpha1.BuildpackMetadataList `json:"extensionMetadata,omitempty"`
+	OrderExtensions         []corev1alpha1.OrderEntry          `json:"orderExtensions,omitempty"`
 	Stack                   corev1alpha1.BuildStack            `json:"stack,omitempty"`
 	Lifecycle               ResolvedClusterLifecycle           `json:"lifecycle,omitempty"`
 	LatestImage             string                             `json:"latestImage,omitempty"`
@@ -79,6 +99,17 @@ type BuilderStatus struct {
 	ObservedStoreGeneration int64                              `json:"observedStoreGeneration,omitempty"`
 	OS                      string                             `json:"os,omitempty"`
 	SignaturePaths          []CosignSignature                  `json:"signaturePaths,omitempty"`
+	// Rollout is the progress of the rollout of the latest image to the Images built with the builder
+	Rollout *BuilderRolloutStatus `json:"rollout,omitempty"`
+	// +listType
+	PlatformImages []BuilderPlatformImage `json:"platformImages,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuilderPlatformImage struct {
+	Platform string `json:"platform"`
+	Image    string `json:"image"`
+	RunImage string `json:"runImage"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
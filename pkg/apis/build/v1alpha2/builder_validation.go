@@ -35,9 +35,12 @@ func (cb *Builder) Validate(ctx context.Context) *apis.FieldError {
 
 func (s *BuilderSpec) Validate(ctx context.Context) *apis.FieldError {
 	return validate.Tag(s.Tag).
-		Also(validateStack(s.Stack).ViaField("stack")).
+		Also(s.validateStack()).
 		Also(validateStore(s.Store).ViaField("store")).
-		Also(validateOrder(s.Order).ViaField("order"))
+		Also(validateOrder(s.Order, []string{BuildpackKind, ClusterBuildpackKind}).ViaField("order")).
+		Also(validateOrder(s.OrderExtensions, []string{ExtensionKind, ClusterExtensionKind}).ViaField("orderExtensions")).
+		Also(s.Rollout.Validate().ViaField("rollout")).
+		Also(validatePlatforms(s.Platforms).ViaField("platforms"))
 }
 
 func (s *NamespacedBuilderSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -45,6 +48,20 @@ func (s *NamespacedBuilderSpec) Validate(ctx context.Context) *apis.FieldError {
 		Also(validate.FieldNotEmpty(s.ServiceAccount(), "serviceAccountName"))
 }
 
+func (s *BuilderSpec) validateStack() *apis.FieldError {
+	if !s.ReferencesStackImages() {
+		return validateStack(s.Stack).ViaField("stack")
+	}
+
+	var errs *apis.FieldError
+	if s.Stack.Name != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("stack", "buildImage"))
+	}
+	return errs.
+		Also(validate.FieldNotEmpty(s.BuildImage.Image, "image").ViaField("buildImage")).
+		Also(validate.FieldNotEmpty(s.RunImage.Image, "image").ViaField("runImage"))
+}
+
 func validateStack(stack v1.ObjectReference) *apis.FieldError {
 	if stack.Name == "" {
 		return apis.ErrMissingField("name")
@@ -65,26 +82,26 @@ func validateStore(store v1.ObjectReference) *apis.FieldError {
 	return validateObjectRef(store, []string{ClusterStoreKind})
 }
 
-func validateOrder(order []BuilderOrderEntry) *apis.FieldError {
+func validateOrder(order []BuilderOrderEntry, kinds []string) *apis.FieldError {
 	var errs *apis.FieldError
 	for i, s := range order {
-		errs = errs.Also(validateGroup(s).ViaIndex(i))
+		errs = errs.Also(validateGroup(s, kinds).ViaIndex(i))
 	}
 	return errs
 }
 
-func validateGroup(group BuilderOrderEntry) *apis.FieldError {
+func validateGroup(group BuilderOrderEntry, kinds []string) *apis.FieldError {
 	var errs *apis.FieldError
 	for i, s := range group.Group {
-		errs = errs.Also(validateBuildpackRef(s).ViaIndex(i).ViaField("group"))
+		errs = errs.Also(validateBuildpackRef(s, kinds).ViaIndex(i).ViaField("group"))
 	}
 	return errs
 }
 
-func validateBuildpackRef(ref BuilderBuildpackRef) *apis.FieldError {
+func validateBuildpackRef(ref BuilderBuildpackRef, kinds []string) *apis.FieldError {
 	var errs *apis.FieldError
 	if ref.Name != "" || ref.Kind != "" {
-		errs = errs.Also(validateObjectRef(ref.ObjectReference, []string{BuildpackKind, ClusterBuildpackKind}))
+		errs = errs.Also(validateObjectRef(ref.ObjectReference, kinds))
 	}
 
 	switch {
@@ -141,11 +141,42 @@ func testBuilderValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(builder, apis.ErrInvalidValue("FakeStack", "kind").ViaField("spec", "stack"))
 		})
 
+		it("allows build and run images instead of a stack", func() {
+			builder.Spec.Stack.Name = ""
+			builder.Spec.BuildImage.Image = "some-registry.io/build"
+			builder.Spec.RunImage.Image = "some-registry.io/run"
+			assert.Nil(t, builder.Validate(context.TODO()))
+		})
+
+		it("requires both build and run images without a stack", func() {
+			builder.Spec.Stack.Name = ""
+			builder.Spec.BuildImage.Image = "some-registry.io/build"
+			assertValidationError(builder, apis.ErrMissingField("image").ViaField("spec", "runImage"))
+		})
+
+		it("does not allow a stack with build and run images", func() {
+			builder.Spec.BuildImage.Image = "some-registry.io/build"
+			builder.Spec.RunImage.Image = "some-registry.io/run"
+			assertValidationError(builder, apis.ErrMultipleOneOf("stack", "buildImage").ViaField("spec"))
+		})
+
 		it("invalid store kind", func() {
 			builder.Spec.Store.Kind = "FakeStore"
 			assertValidationError(builder, apis.ErrInvalidValue("FakeStore", "kind", "must be one of ClusterStore").ViaField("spec", "store"))
 		})
 
+		it("validates rollout", func() {
+			builder.Spec.Rollout = &BuilderRollout{Percentage: 10, SuccessThreshold: 80}
+			assert.Nil(t, builder.Validate(context.TODO()))
+
+			builder.Spec.Rollout = &BuilderRollout{}
+			assertValidationError(builder, apis.ErrMissingOneOf("selector", "percentage").ViaField("spec", "rollout"))
+
+			builder.Spec.Rollout = &BuilderRollout{Percentage: 110, SuccessThreshold: -1}
+			assertValidationError(builder, apis.ErrOutOfBoundsValue(110, 0, 100, "percentage").
+				Also(apis.ErrOutOfBoundsValue(-1, 0, 100, "successThreshold")).ViaField("spec", "rollout"))
+		})
+
 		when("order", func() {
 			assertValidationError = func(builder *Builder, expectedError *apis.FieldError) {
 				t.Helper()
@@ -218,5 +249,59 @@ func testBuilderValidation(t *testing.T, when spec.G, it spec.S) {
 				assert.Nil(t, builder.Validate(context.TODO()))
 			})
 		})
+
+		when("orderExtensions", func() {
+			it("valid with extension refs", func() {
+				builder.Spec.OrderExtensions = []BuilderOrderEntry{{Group: []BuilderBuildpackRef{
+					{
+						BuildpackRef: v1alpha1.BuildpackRef{
+							BuildpackInfo: v1alpha1.BuildpackInfo{Id: "some-extension"},
+						},
+					},
+					{
+						ObjectReference: corev1.ObjectReference{
+							Name: "some-extension",
+							Kind: "Extension",
+						},
+					},
+					{
+						ObjectReference: corev1.ObjectReference{
+							Name: "some-clusterextension",
+							Kind: "ClusterExtension",
+						},
+					},
+				}}}
+				assert.Nil(t, builder.Validate(context.TODO()))
+			})
+
+			it("invalid with a buildpack kind", func() {
+				builder.Spec.OrderExtensions = []BuilderOrderEntry{{Group: []BuilderBuildpackRef{{
+					ObjectReference: corev1.ObjectReference{
+						Name: "some-buildpack",
+						Kind: "Buildpack",
+					},
+				}}}}
+
+				err := builder.Validate(context.TODO())
+				assert.EqualError(t, err,
+					apis.ErrInvalidValue("Buildpack", "kind", "must be one of Extension, ClusterExtension").
+						ViaIndex(0).ViaField("group").
+						ViaIndex(0).ViaField("spec", "orderExtensions").Error(),
+				)
+			})
+		})
+
+		it("validates platforms", func() {
+			builder.Spec.Platforms = []string{"linux/amd64", "linux/arm64"}
+			assert.Nil(t, builder.Validate(context.TODO()))
+
+			builder.Spec.Platforms = []string{"linux", "windows/amd64", "linux/amd64", "linux/amd64"}
+			err := builder.Validate(context.TODO())
+			assert.EqualError(t, err,
+				apis.ErrInvalidArrayValue("linux", "spec.platforms", 0).
+					Also(apis.ErrGeneric("only linux platforms are supported", "spec.platforms[1]")).
+					Also(apis.ErrGeneric("duplicate platform", "spec.platforms[3]")).Error(),
+			)
+		})
 	})
 }
@@ -54,6 +54,8 @@ type ResolvedClusterLifecycle struct {
 type ClusterLifecycleStatusImage struct {
 	LatestImage string `json:"latestImage,omitempty"`
 	Image       string `json:"image,omitempty"`
+	// IndexImage is the image index the latest image was resolved from, it is empty if the image is not an index
+	IndexImage string `json:"indexImage,omitempty"`
 }
 
 type LifecycleAPI struct {
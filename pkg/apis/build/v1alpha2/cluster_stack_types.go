@@ -54,12 +54,16 @@ type ResolvedClusterStack struct {
 	Mixins  []string `json:"mixins,omitempty"`
 	UserID  int      `json:"userId,omitempty"`
 	GroupID int      `json:"groupId,omitempty"`
+	// Target is the os, arch and distribution of the run image
+	Target *corev1alpha1.BuildpackTarget `json:"target,omitempty"`
 }
 
 // +k8s:openapi-gen=true
 type ClusterStackStatusImage struct {
 	LatestImage string `json:"latestImage,omitempty"`
 	Image       string `json:"image,omitempty"`
+	// IndexImage is the image index the latest image was resolved from, it is empty if the image is not an index
+	IndexImage string `json:"indexImage,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -25,8 +25,7 @@ func (ss *ClusterStackSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
-	return validate.FieldNotEmpty(ss.Id, "id").
-		Also(ss.BuildImage.Validate(ctx).ViaField("buildImage")).
+	return ss.BuildImage.Validate(ctx).ViaField("buildImage").
 		Also(ss.RunImage.Validate(ctx).ViaField("runImage"))
 }
 
@@ -43,10 +43,10 @@ func testClusterStackValidation(t *testing.T, when spec.G, it spec.S) {
 			assert.Nil(t, clusterStack.Validate(context.TODO()))
 		})
 
-		it("missing id", func() {
+		it("allows a stack without an id", func() {
 			clusterStack.Spec.Id = ""
 
-			assertValidationError(clusterStack, apis.ErrMissingField("id").ViaField("spec"))
+			assert.Nil(t, clusterStack.Validate(context.TODO()))
 		})
 
 		it("invalid build image", func() {
@@ -0,0 +1,22 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"github.com/pivotal/kpack/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+func (cb *Extension) SetDefaults(context.Context) {
+	if cb.Spec.ServiceAccountName == "" {
+		cb.Spec.ServiceAccountName = "default"
+	}
+}
+
+func (cb *Extension) Validate(ctx context.Context) *apis.FieldError {
+	return cb.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (s *ExtensionSpec) Validate(ctx context.Context) *apis.FieldError {
+	return validate.Image(s.Image)
+}
@@ -0,0 +1,71 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestExtensionValidation(t *testing.T) {
+	spec.Run(t, "Extension Validation", testExtensionValidation)
+}
+
+func testExtensionValidation(t *testing.T, when spec.G, it spec.S) {
+	extension := &Extension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "custom-builder-name",
+			Namespace: "custom-builder-namespace",
+		},
+		Spec: ExtensionSpec{
+			ImageSource: corev1alpha1.ImageSource{
+				Image: "some-registry.io/store-image-1@sha256:78c1b9419976227e05be9d243b7fa583bea44a5258e52018b2af4cdfe23d148d",
+			},
+			ServiceAccountName: "some-service-account",
+		},
+	}
+
+	when("Default", func() {
+		it("does not modify already set fields", func() {
+			oldExtension := extension.DeepCopy()
+			extension.SetDefaults(context.TODO())
+
+			assert.Equal(t, extension, oldExtension)
+		})
+
+		it("defaults service account to default", func() {
+			extension.Spec.ServiceAccountName = ""
+			extension.SetDefaults(context.TODO())
+			assert.Equal(t, extension.Spec.ServiceAccountName, "default")
+		})
+	})
+
+	when("Validate", func() {
+		it("returns nil on no validation error", func() {
+			assert.Nil(t, extension.Validate(context.TODO()))
+		})
+
+		assertValidationError := func(extension *Extension, expectedError *apis.FieldError) {
+			t.Helper()
+			err := extension.Validate(context.TODO())
+			assert.EqualError(t, err, expectedError.Error())
+		}
+
+		it("missing source image", func() {
+			extension.Spec.Image = ""
+			assertValidationError(extension, apis.ErrMissingField("image").ViaField("spec"))
+		})
+
+		it("invalid source image", func() {
+			extension.Spec.Image = "ftp//invalid/tag@@"
+
+			assertValidationError(extension,
+				apis.ErrInvalidValue(extension.Spec.Image, "image").ViaField("spec"),
+			)
+		})
+	})
+}
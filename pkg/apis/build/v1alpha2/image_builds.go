@@ -18,9 +18,11 @@ const (
 	ImageLabel           = "image.kpack.io/image"
 	ImageGenerationLabel = "image.kpack.io/imageGeneration"
 
-	BuildReasonAnnotation  = "image.kpack.io/reason"
-	BuildChangesAnnotation = "image.kpack.io/buildChanges"
-	BuildNeededAnnotation  = "image.kpack.io/additionalBuildNeeded"
+	BuildReasonAnnotation    = "image.kpack.io/reason"
+	BuildChangesAnnotation   = "image.kpack.io/buildChanges"
+	BuildNeededAnnotation    = "image.kpack.io/additionalBuildNeeded"
+	UpstreamImagesAnnotation = "image.kpack.io/upstreamImages"
+	BuildAttemptAnnotation   = "image.kpack.io/buildAttempt"
 
 	BuilderNameAnnotation = "image.kpack.io/builderName"
 	BuilderKindAnnotation = "image.kpack.io/builderKind"
@@ -31,10 +33,33 @@ const (
 	BuildReasonStack     = "STACK"
 	BuildReasonLifecycle = "LIFECYCLE"
 	BuildReasonTrigger   = "TRIGGER"
+	BuildReasonBlob      = "BLOB"
+	BuildReasonRegistry  = "REGISTRY"
+	BuildReasonVolume    = "VOLUME"
+	BuildReasonScheduled = "SCHEDULED"
+	BuildReasonUpstream  = "UPSTREAM"
+	BuildReasonRetry     = "RETRY"
+	BuildReasonRequest   = "REQUEST"
 )
 
 type BuildReason string
 
+var buildReasons = map[string]bool{
+	BuildReasonConfig:    true,
+	BuildReasonCommit:    true,
+	BuildReasonBuildpack: true,
+	BuildReasonStack:     true,
+	BuildReasonLifecycle: true,
+	BuildReasonTrigger:   true,
+	BuildReasonBlob:      true,
+	BuildReasonRegistry:  true,
+	BuildReasonVolume:    true,
+	BuildReasonScheduled: true,
+	BuildReasonUpstream:  true,
+	BuildReasonRetry:     true,
+	BuildReasonRequest:   true,
+}
+
 func (im *Image) Build(sourceResolver *SourceResolver, builder BuilderResource, latestBuild *Build, reasons, changes string, nextBuildNumber int64, priorityClass string) *Build {
 	buildNumber := strconv.Itoa(int(nextBuildNumber))
 	return &Build{
@@ -68,6 +93,7 @@ func (im *Image) Build(sourceResolver *SourceResolver, builder BuilderResource,
 			Services:              im.Services(),
 			CNBBindings:           im.CNBBindings(),
 			Env:                   im.Env(),
+			EnvFrom:               im.EnvFrom(),
 			ProjectDescriptorPath: im.Spec.ProjectDescriptorPath,
 			Resources:             im.Resources(),
 			LastBuild:             lastBuild(latestBuild),
@@ -125,6 +151,10 @@ func lastBuild(latestBuild *Build) *LastBuild {
 }
 
 func (im *Image) LatestForImage(build *Build) string {
+	// platform builds push platform images, the latest image is the image index of the platform images
+	if build.Platform() != "" {
+		return im.Status.LatestImage
+	}
 	if build.IsSuccess() {
 		return build.BuiltImage()
 	}
@@ -153,6 +183,13 @@ func (im *Image) Env() []corev1.EnvVar {
 	return im.Spec.Build.Env
 }
 
+func (im *Image) EnvFrom() []corev1.EnvFromSource {
+	if im.Spec.Build == nil {
+		return nil
+	}
+	return im.Spec.Build.EnvFrom
+}
+
 func (im *Image) Resources() corev1.ResourceRequirements {
 	if im.Spec.Build == nil {
 		return corev1.ResourceRequirements{}
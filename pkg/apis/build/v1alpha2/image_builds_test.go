@@ -368,6 +368,80 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 		})
 
 	})
+
+	when("#platformBuild", func() {
+		it.Before(func() {
+			sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
+				Git: &corev1alpha1.ResolvedGitSource{
+					URL:      "https://some.git/url",
+					Revision: "revision",
+					Type:     corev1alpha1.Commit,
+				},
+			}
+			image.Name = "image-name"
+			image.Spec.Tag = "gcr.io/imagename/foo:test"
+			image.Spec.AdditionalTags = []string{"gcr.io/imagename/foo:other"}
+			image.Spec.Platforms = []string{"linux/amd64", "linux/arm64"}
+			image.Spec.Cache = nil
+			image.Spec.Build = &ImageBuild{
+				NodeSelector: map[string]string{"foo": "bar"},
+			}
+		})
+
+		it.After(func() {
+			image.Spec.AdditionalTags = nil
+			image.Spec.Platforms = nil
+		})
+
+		it("generates a build name with build number and platform", func() {
+			build := image.PlatformBuild("linux/arm64", sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, "image-name-build-27-linux-arm64", build.Name)
+		})
+
+		it("adds the platform annotations", func() {
+			build := image.PlatformBuild("linux/arm64", sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, "linux/arm64", build.Platform())
+			assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, build.Platforms())
+		})
+
+		it("only tags the platform image with a platform tag", func() {
+			build := image.PlatformBuild("linux/arm64", sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, []string{"gcr.io/imagename/foo:test-linux-arm64"}, build.Spec.Tags)
+		})
+
+		it("does not add the latest tag to the platform tag", func() {
+			image.Spec.Tag = "gcr.io/imagename/foo:latest"
+			build := image.PlatformBuild("linux/arm64", sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, []string{"gcr.io/imagename/foo:linux-arm64"}, build.Spec.Tags)
+
+			image.Spec.Tag = "localhost:5000/imagename/foo"
+			build = image.PlatformBuild("linux/arm64", sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, []string{"localhost:5000/imagename/foo:linux-arm64"}, build.Spec.Tags)
+		})
+
+		it("uses a platform tag for the registry cache", func() {
+			image.Spec.Cache = &ImageCacheConfig{
+				Registry: &RegistryCache{Tag: "gcr.io/imagename/cache"},
+			}
+			build := image.PlatformBuild("linux/arm64", sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, "gcr.io/imagename/cache:linux-arm64", build.Spec.Cache.Registry.Tag)
+			assert.Equal(t, "gcr.io/imagename/cache", image.Spec.Cache.Registry.Tag)
+		})
+
+		it("selects nodes with the platform architecture", func() {
+			build := image.PlatformBuild("linux/arm64", sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, map[string]string{"foo": "bar", "kubernetes.io/arch": "arm64"}, build.Spec.NodeSelector)
+			assert.Equal(t, map[string]string{"foo": "bar"}, image.Spec.Build.NodeSelector)
+		})
+
+		it("pushes the image index to the tag and additional tags", func() {
+			tags := image.IndexTags(27)
+			require.Len(t, tags, 3)
+			assert.Equal(t, "gcr.io/imagename/foo:test", tags[0])
+			require.Regexp(t, "gcr.io/imagename/foo:test-b27\\.\\d{8}\\.\\d{6}", tags[1])
+			assert.Equal(t, "gcr.io/imagename/foo:other", tags[2])
+		})
+	})
 }
 
 type TestBuilderResource struct {
@@ -425,3 +499,11 @@ func (t TestBuilderResource) GetKind() string {
 func (t TestBuilderResource) GetNamespace() string {
 	return t.Namespace
 }
+
+func (t TestBuilderResource) RolloutPending(*Image) bool {
+	return false
+}
+
+func (t TestBuilderResource) Platforms() []string {
+	return nil
+}
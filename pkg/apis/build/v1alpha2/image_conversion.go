@@ -25,6 +25,13 @@ const (
 	projectDescriptorPathConversionAnnotation = "kpack.io/projectDescriptorPath"
 	cosignAnnotationConversionAnnotation      = "kpack.io/cosignAnnotation"
 	defaultProcessConversionAnnotation        = "kpack.io/defaultProcess"
+	scheduleConversionAnnotation              = "kpack.io/schedule"
+	buildWindowsConversionAnnotation          = "kpack.io/buildWindows"
+	upstreamImagesConversionAnnotation        = "kpack.io/upstreamImages"
+	allowedBuildReasonsConversionAnnotation   = "kpack.io/allowedBuildReasons"
+	retryPolicyConversionAnnotation           = "kpack.io/retryPolicy"
+	envFromConversionAnnotation               = "kpack.io/envFrom"
+	platformsConversionAnnotation             = "kpack.io/platforms"
 )
 
 func (i *Image) ConvertTo(_ context.Context, to apis.Convertible) error {
@@ -151,6 +158,28 @@ func (i *Image) convertFromAnnotations(fromAnnotations *map[string]string) error
 		is.Build.BuildTimeout = &temp
 		delete(ia, buildTimeoutConversionAnnotation)
 	}
+	if retryPolicyJson, ok := (*fromAnnotations)[retryPolicyConversionAnnotation]; ok {
+		if is.Build == nil {
+			is.Build = &ImageBuild{}
+		}
+		var retryPolicy *BuildRetryPolicy
+		if err := json.Unmarshal([]byte(retryPolicyJson), &retryPolicy); err != nil {
+			return err
+		}
+		is.Build.RetryPolicy = retryPolicy
+		delete(ia, retryPolicyConversionAnnotation)
+	}
+	if envFromJson, ok := (*fromAnnotations)[envFromConversionAnnotation]; ok {
+		if is.Build == nil {
+			is.Build = &ImageBuild{}
+		}
+		var envFrom []corev1.EnvFromSource
+		if err := json.Unmarshal([]byte(envFromJson), &envFrom); err != nil {
+			return err
+		}
+		is.Build.EnvFrom = envFrom
+		delete(ia, envFromConversionAnnotation)
+	}
 	if storageClassName, ok := (*fromAnnotations)[storageClassNameConversionAnnotation]; ok {
 		if is.Cache == nil {
 			is.Cache = &ImageCacheConfig{}
@@ -190,6 +219,42 @@ func (i *Image) convertFromAnnotations(fromAnnotations *map[string]string) error
 		is.DefaultProcess = defaultProcess
 		delete(ia, defaultProcessConversionAnnotation)
 	}
+	if schedule, ok := (*fromAnnotations)[scheduleConversionAnnotation]; ok {
+		is.Schedule = schedule
+		delete(ia, scheduleConversionAnnotation)
+	}
+	if buildWindowsJson, ok := (*fromAnnotations)[buildWindowsConversionAnnotation]; ok {
+		var buildWindows BuildWindows
+		if err := json.Unmarshal([]byte(buildWindowsJson), &buildWindows); err != nil {
+			return err
+		}
+		is.BuildWindows = buildWindows
+		delete(ia, buildWindowsConversionAnnotation)
+	}
+	if upstreamImagesJson, ok := (*fromAnnotations)[upstreamImagesConversionAnnotation]; ok {
+		var upstreamImages []corev1.LocalObjectReference
+		if err := json.Unmarshal([]byte(upstreamImagesJson), &upstreamImages); err != nil {
+			return err
+		}
+		is.UpstreamImages = upstreamImages
+		delete(ia, upstreamImagesConversionAnnotation)
+	}
+	if allowedBuildReasonsJson, ok := (*fromAnnotations)[allowedBuildReasonsConversionAnnotation]; ok {
+		var allowedBuildReasons []string
+		if err := json.Unmarshal([]byte(allowedBuildReasonsJson), &allowedBuildReasons); err != nil {
+			return err
+		}
+		is.AllowedBuildReasons = allowedBuildReasons
+		delete(ia, allowedBuildReasonsConversionAnnotation)
+	}
+	if platformsJson, ok := (*fromAnnotations)[platformsConversionAnnotation]; ok {
+		var platforms []string
+		if err := json.Unmarshal([]byte(platformsJson), &platforms); err != nil {
+			return err
+		}
+		is.Platforms = platforms
+		delete(ia, platformsConversionAnnotation)
+	}
 	return nil
 }
 
@@ -234,6 +299,20 @@ func (is *ImageSpec) convertToAnnotations(toAnnotations map[string]string) error
 		if build.BuildTimeout != nil {
 			toAnnotations[buildTimeoutConversionAnnotation] = strconv.FormatInt(*build.BuildTimeout, 10)
 		}
+		if build.RetryPolicy != nil {
+			bytes, err := json.Marshal(build.RetryPolicy)
+			if err != nil {
+				return err
+			}
+			toAnnotations[retryPolicyConversionAnnotation] = string(bytes)
+		}
+		if len(build.EnvFrom) > 0 {
+			bytes, err := json.Marshal(build.EnvFrom)
+			if err != nil {
+				return err
+			}
+			toAnnotations[envFromConversionAnnotation] = string(bytes)
+		}
 	}
 	if is.Cache != nil {
 		if is.Cache.Volume != nil && is.Cache.Volume.StorageClassName != "" {
@@ -258,6 +337,37 @@ func (is *ImageSpec) convertToAnnotations(toAnnotations map[string]string) error
 	if is.DefaultProcess != "" {
 		toAnnotations[defaultProcessConversionAnnotation] = is.DefaultProcess
 	}
+	if is.Schedule != "" {
+		toAnnotations[scheduleConversionAnnotation] = is.Schedule
+	}
+	if len(is.BuildWindows) > 0 {
+		bytes, err := json.Marshal(is.BuildWindows)
+		if err != nil {
+			return err
+		}
+		toAnnotations[buildWindowsConversionAnnotation] = string(bytes)
+	}
+	if len(is.UpstreamImages) > 0 {
+		bytes, err := json.Marshal(is.UpstreamImages)
+		if err != nil {
+			return err
+		}
+		toAnnotations[upstreamImagesConversionAnnotation] = string(bytes)
+	}
+	if len(is.AllowedBuildReasons) > 0 {
+		bytes, err := json.Marshal(is.AllowedBuildReasons)
+		if err != nil {
+			return err
+		}
+		toAnnotations[allowedBuildReasonsConversionAnnotation] = string(bytes)
+	}
+	if len(is.Platforms) > 0 {
+		bytes, err := json.Marshal(is.Platforms)
+		if err != nil {
+			return err
+		}
+		toAnnotations[platformsConversionAnnotation] = string(bytes)
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package v1alpha2
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
@@ -83,6 +84,17 @@ func testImageConversion(t *testing.T, when spec.G, it spec.S) {
 					RuntimeClassName: &runtimeClassName,
 					SchedulerName:    "some-scheduler-name",
 					BuildTimeout:     &buildTimeout,
+					RetryPolicy: &BuildRetryPolicy{
+						MaxAttempts: 3,
+						Backoff:     &metav1.Duration{Duration: 2 * time.Minute},
+					},
+					EnvFrom: []corev1.EnvFromSource{
+						{
+							ConfigMapRef: &corev1.ConfigMapEnvSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "some-config-map"},
+							},
+						},
+					},
 				},
 				Notary: &corev1alpha1.NotaryConfig{
 					V1: &corev1alpha1.NotaryV1Config{
@@ -102,6 +114,17 @@ func testImageConversion(t *testing.T, when spec.G, it spec.S) {
 					},
 				},
 				DefaultProcess: "some-default-process",
+				Schedule:       "@weekly",
+				BuildWindows: BuildWindows{
+					{
+						Reasons:  []string{"STACK"},
+						Schedule: "0 22 * * *",
+						Duration: metav1.Duration{Duration: 6 * time.Hour},
+					},
+				},
+				UpstreamImages:      []corev1.LocalObjectReference{{Name: "some-upstream-image"}},
+				AllowedBuildReasons: []string{"COMMIT", "CONFIG"},
+				Platforms:           []string{"linux/amd64", "linux/arm64"},
 			},
 			Status: ImageStatus{
 				Status: corev1alpha1.Status{
@@ -135,11 +158,18 @@ func testImageConversion(t *testing.T, when spec.G, it spec.S) {
 					"kpack.io/runtimeClassName":              "some-runtime-class-name",
 					"kpack.io/schedulerName":                 "some-scheduler-name",
 					"kpack.io/buildTimeout":                  "7",
+					"kpack.io/retryPolicy":                   `{"maxAttempts":3,"backoff":"2m0s"}`,
+					"kpack.io/envFrom":                       `[{"configMapRef":{"name":"some-config-map"}}]`,
 					"kpack.io/cache.volume.storageClassName": "some-storage-class",
 					"kpack.io/cache.registry.tag":            "some-tag",
 					"kpack.io/projectDescriptorPath":         "some-project-descriptor-path",
 					"kpack.io/cosignAnnotation":              `[{"name":"some-cosign-name","value":"some-cosign-value"}]`,
 					"kpack.io/defaultProcess":                "some-default-process",
+					"kpack.io/schedule":                      "@weekly",
+					"kpack.io/buildWindows":                  `[{"reasons":["STACK"],"schedule":"0 22 * * *","duration":"6h0m0s"}]`,
+					"kpack.io/upstreamImages":                `[{"name":"some-upstream-image"}]`,
+					"kpack.io/allowedBuildReasons":           `["COMMIT","CONFIG"]`,
+					"kpack.io/platforms":                     `["linux/amd64","linux/arm64"]`,
 				},
 			},
 			Spec: v1alpha1.ImageSpec{
@@ -294,6 +324,11 @@ func testImageConversion(t *testing.T, when spec.G, it spec.S) {
 			v1alpha2Image.Spec.ProjectDescriptorPath = ""
 			v1alpha2Image.Spec.Cosign = nil
 			v1alpha2Image.Spec.DefaultProcess = ""
+			v1alpha2Image.Spec.Schedule = ""
+			v1alpha2Image.Spec.BuildWindows = nil
+			v1alpha2Image.Spec.UpstreamImages = nil
+			v1alpha2Image.Spec.AllowedBuildReasons = nil
+			v1alpha2Image.Spec.Platforms = nil
 
 			testV1alpha1Image := &v1alpha1.Image{}
 			err := v1alpha2Image.ConvertTo(context.TODO(), testV1alpha1Image)
@@ -0,0 +1,112 @@
+package v1alpha2
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// BuildPlatformAnnotation is the platform built by a build of an image with platforms
+	BuildPlatformAnnotation = "image.kpack.io/platform"
+	// BuildPlatformsAnnotation are all the platforms of the image when the build was created
+	BuildPlatformsAnnotation = "image.kpack.io/platforms"
+
+	k8sArchLabel = "kubernetes.io/arch"
+)
+
+// PlatformBuild is the build of the image for one of its platforms. The build runs on a node of the platform and
+// pushes the platform image to a platform specific tag, latestBuild is the last build of the same platform.
+func (im *Image) PlatformBuild(platform string, sourceResolver *SourceResolver, builder BuilderResource, latestBuild *Build, reasons, changes string, nextBuildNumber int64, priorityClass string) *Build {
+	build := im.Build(sourceResolver, builder, latestBuild, reasons, changes, nextBuildNumber, priorityClass)
+	build.Name = im.generateBuildName(strconv.Itoa(int(nextBuildNumber)) + "-" + platformSuffix(platform))
+	build.Annotations[BuildPlatformAnnotation] = platform
+	build.Annotations[BuildPlatformsAnnotation] = strings.Join(im.Spec.Platforms, ",")
+	build.Spec.Tags = []string{platformTag(im.Spec.Tag, platform)}
+	if im.Spec.NeedRegistryCache() {
+		build.Spec.Cache.Registry.Tag = platformTag(im.Spec.Cache.Registry.Tag, platform)
+	}
+
+	if p, err := ggcrv1.ParsePlatform(platform); err == nil {
+		build.Spec.NodeSelector = combine(build.Spec.NodeSelector, map[string]string{k8sArchLabel: p.Architecture})
+	}
+	return build
+}
+
+// IndexTags are the tags the image index of a build number is pushed to
+func (im *Image) IndexTags(buildNumber int64) []string {
+	return im.generateTags(strconv.Itoa(int(buildNumber)))
+}
+
+// Platform is the platform built by the build, empty if the image of the build has no platforms
+func (b *Build) Platform() string {
+	if b == nil {
+		return ""
+	}
+	return b.Annotations[BuildPlatformAnnotation]
+}
+
+// Platforms are the platforms of the image when the build was created
+func (b *Build) Platforms() []string {
+	if b == nil || b.Annotations[BuildPlatformsAnnotation] == "" {
+		return nil
+	}
+	return strings.Split(b.Annotations[BuildPlatformsAnnotation], ",")
+}
+
+// platformTag appends the platform to the tag name, such as some/image:v1-linux-arm64 for some/image:v1
+func platformTag(tag, platform string) string {
+	ref, err := name.NewTag(tag, name.WeakValidation)
+	if err != nil {
+		return tag
+	}
+
+	repository := strings.TrimSuffix(tag, ":"+ref.TagStr())
+	if repository == tag || ref.TagStr() == "latest" {
+		return repository + ":" + platformSuffix(platform)
+	}
+	return repository + ":" + ref.TagStr() + "-" + platformSuffix(platform)
+}
+
+func platformSuffix(platform string) string {
+	return strings.ReplaceAll(platform, "/", "-")
+}
+
+func (is *ImageSpec) validatePlatforms() *apis.FieldError {
+	if len(is.Platforms) == 0 {
+		return nil
+	}
+
+	errs := validatePlatforms(is.Platforms).ViaField("platforms")
+	if is.NeedVolumeCache() {
+		errs = errs.Also(apis.ErrGeneric("volume cache cannot be shared by the platform builds, use a registry cache", "cache.volume"))
+	}
+
+	if is.Build != nil {
+		if _, ok := is.Build.NodeSelector[k8sArchLabel]; ok {
+			errs = errs.Also(apis.ErrInvalidKeyName(k8sArchLabel, "build.nodeSelector", "arch is determined by platforms"))
+		}
+	}
+	return errs
+}
+
+func validatePlatforms(platforms []string) *apis.FieldError {
+	var errs *apis.FieldError
+	seen := map[string]bool{}
+	for i, platform := range platforms {
+		p, err := ggcrv1.ParsePlatform(platform)
+		switch {
+		case err != nil || p.OS == "" || p.Architecture == "":
+			errs = errs.Also(apis.ErrInvalidArrayValue(platform, apis.CurrentField, i))
+		case p.OS != "linux":
+			errs = errs.Also(apis.ErrGeneric("only linux platforms are supported", apis.CurrentField).ViaIndex(i))
+		case seen[platform]:
+			errs = errs.Also(apis.ErrGeneric("duplicate platform", apis.CurrentField).ViaIndex(i))
+		}
+		seen[platform] = true
+	}
+	return errs
+}
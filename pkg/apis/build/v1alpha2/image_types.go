@@ -17,6 +17,7 @@
 package v1alpha2
 
 import (
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -60,6 +61,24 @@ type ImageSpec struct {
 	DefaultProcess           string                            `json:"defaultProcess,omitempty"`
 	// +listType
 	AdditionalTags []string `json:"additionalTags,omitempty"`
+	// Schedule is a cron expression, such as "0 4 * * 1" or "@weekly", on which the image is rebuilt
+	Schedule string `json:"schedule,omitempty"`
+	// BuildWindows defer automatic builds for the listed reasons until a window is open
+	// +listType
+	BuildWindows BuildWindows `json:"buildWindows,omitempty"`
+	// UpstreamImages are Images in the same namespace that this image is built from, such as a base image. The
+	// image is rebuilt when the latest image of an upstream Image changes.
+	// +listType
+	UpstreamImages []corev1.LocalObjectReference `json:"upstreamImages,omitempty"`
+	// AllowedBuildReasons are the build reasons, such as COMMIT or STACK, that build the image automatically.
+	// Changes for other reasons are reported as a pending build until they are approved with a triggered build.
+	// All reasons are allowed if unset.
+	// +listType
+	AllowedBuildReasons []string `json:"allowedBuildReasons,omitempty"`
+	// Platforms, such as linux/amd64 and linux/arm64, are each built by a separate build on a node of the platform.
+	// The tag is pushed as an image index of the platform images once every platform build succeeds.
+	// +listType
+	Platforms []string `json:"platforms,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -69,7 +88,11 @@ type ImageBuild struct {
 	// +listType
 	CNBBindings corev1alpha1.CNBBindings `json:"cnbBindings,omitempty"`
 	// +listType
-	Env       []corev1.EnvVar             `json:"env,omitempty"`
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom adds the data of Secrets and ConfigMaps to the build env. The image is rebuilt when the data of a
+	// Secret or ConfigMap referenced by Env or EnvFrom changes.
+	// +listType
+	EnvFrom   []corev1.EnvFromSource      `json:"envFrom,omitempty"`
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 	// +listType
 	Tolerations          []corev1.Toleration `json:"tolerations,omitempty"`
@@ -79,6 +102,16 @@ type ImageBuild struct {
 	SchedulerName        string              `json:"schedulerName,omitempty"`
 	BuildTimeout         *int64              `json:"buildTimeout,omitempty"`
 	CreationTime         string              `json:"creationTime,omitempty"`
+	// RetryPolicy recreates builds that fail for a transient reason
+	RetryPolicy *BuildRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuildRetryPolicy struct {
+	// MaxAttempts is the most builds created for the same changes, including the build that first failed
+	MaxAttempts int64 `json:"maxAttempts"`
+	// Backoff is how long to wait before the first retry. It doubles after every failed retry. Defaults to 1m.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -114,6 +147,29 @@ type ImageStatus struct {
 	BuildCounter               int64  `json:"buildCounter,omitempty"`
 	BuildCacheName             string `json:"buildCacheName,omitempty"`
 	LatestBuildReason          string `json:"latestBuildReason,omitempty"`
+	// LastScheduledBuildTime is when the last build for the schedule was created
+	LastScheduledBuildTime *metav1.Time `json:"lastScheduledBuildTime,omitempty"`
+	// LastBuildRequestID is the ID of the last build request a build was created for
+	LastBuildRequestID string `json:"lastBuildRequestID,omitempty"`
+	// PendingBuild is a required build that has been deferred by a build window or is waiting for approval
+	PendingBuild *PendingBuild `json:"pendingBuild,omitempty"`
+	// LatestPlatformImages are the platform images of the latest image index
+	// +listType
+	LatestPlatformImages []PlatformImage `json:"latestPlatformImages,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type PlatformImage struct {
+	Platform string `json:"platform"`
+	Image    string `json:"image"`
+}
+
+// +k8s:openapi-gen=true
+type PendingBuild struct {
+	Reason  string `json:"reason,omitempty"`
+	Changes string `json:"changes,omitempty"`
+	// DeferredUntil is when the earliest build window for the reasons opens, unset if the build waits for approval
+	DeferredUntil *metav1.Time `json:"deferredUntil,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -135,5 +191,11 @@ func (i *Image) NamespacedName() types.NamespacedName {
 	return types.NamespacedName{Namespace: i.Namespace, Name: i.Name}
 }
 
+// ParseSchedule parses the standard five field cron format, descriptors such as @weekly and an optional CRON_TZ= prefix
+func (is *ImageSpec) ParseSchedule() (cron.Schedule, error) {
+	return cron.ParseStandard(is.Schedule)
+}
+
 const ConditionBuilderReady corev1alpha1.ConditionType = "BuilderReady"
 const ConditionBuilderUpToDate corev1alpha1.ConditionType = "BuilderUpToDate"
+const ConditionBuildPending corev1alpha1.ConditionType = "BuildPending"
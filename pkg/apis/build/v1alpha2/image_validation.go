@@ -21,8 +21,13 @@ type ImageContextKey string
 const (
 	HasDefaultStorageClass ImageContextKey = "hasDefaultStorageClass"
 	IsExpandable           ImageContextKey = "isExpandable"
+	UpstreamImageLookup    ImageContextKey = "upstreamImageLookup"
 )
 
+// ImageLookup returns the Image with the name in the namespace, or nil if it does not exist. The webhook sets an
+// ImageLookup on the context with the UpstreamImageLookup key to detect cycles of upstream images.
+type ImageLookup func(namespace, name string) (*Image, error)
+
 var (
 	defaultFailedBuildHistoryLimit     int64 = 10
 	defaultSuccessfulBuildHistoryLimit int64 = 10
@@ -50,7 +55,8 @@ func (i *Image) SetDefaults(ctx context.Context) {
 		i.Spec.SuccessBuildHistoryLimit = &defaultSuccessfulBuildHistoryLimit
 	}
 
-	if i.Spec.Cache == nil && ctx.Value(HasDefaultStorageClass) != nil {
+	// the platform builds of an image run concurrently and cannot share a volume cache
+	if i.Spec.Cache == nil && ctx.Value(HasDefaultStorageClass) != nil && len(i.Spec.Platforms) == 0 {
 		i.Spec.Cache = &ImageCacheConfig{
 			Volume: &ImagePersistentVolumeCache{
 				Size: &defaultCacheSize,
@@ -61,11 +67,24 @@ func (i *Image) SetDefaults(ctx context.Context) {
 
 func (i *Image) Validate(ctx context.Context) *apis.FieldError {
 	return i.Spec.ValidateSpec(ctx).ViaField("spec").
+		Also(i.validateUpstreamCycle(ctx).ViaField("spec", "upstreamImages")).
+		Also(validateBuildEnvSecretRefs(ctx, i.Namespace, i.Env(), i.EnvFrom()).ViaField("spec", "build")).
 		Also(i.ValidateMetadata(ctx).ViaField("metadata"))
 }
 
 func (i *Image) ValidateMetadata(ctx context.Context) *apis.FieldError {
-	return i.validateName(i.Name).ViaField("name")
+	return i.validateName(i.Name).ViaField("name").
+		Also(i.validateBuildRequest().ViaFieldKey("annotations", BuildRequestAnnotation))
+}
+
+func (i *Image) validateBuildRequest() *apis.FieldError {
+	request, err := i.BuildRequest()
+	if err != nil {
+		return apis.ErrInvalidValue(i.Annotations[BuildRequestAnnotation], "", err.Error())
+	} else if request == nil {
+		return nil
+	}
+	return request.Validate(&i.Spec)
 }
 
 func (i *Image) validateName(imageName string) *apis.FieldError {
@@ -90,7 +109,12 @@ func (is *ImageSpec) ValidateSpec(ctx context.Context) *apis.FieldError {
 		Also(is.validateVolumeCache(ctx)).
 		Also(validateNotary(ctx, is.Notary).ViaField("notary")).
 		Also(is.Cosign.Validate(ctx).ViaField("cosign")).
-		Also(is.validateBuildHistoryLimit())
+		Also(is.validateBuildHistoryLimit()).
+		Also(is.validateSchedule()).
+		Also(is.BuildWindows.Validate(ctx).ViaField("buildWindows")).
+		Also(is.validateUpstreamImages()).
+		Also(is.validateAllowedBuildReasons()).
+		Also(is.validatePlatforms())
 }
 
 func (is *ImageSpec) validateTag(ctx context.Context) *apis.FieldError {
@@ -177,7 +201,8 @@ func (ib *ImageBuild) Validate(ctx context.Context) *apis.FieldError {
 
 	return ib.Services.Validate(ctx).ViaField("services").
 		Also(validateCnbBindings(ctx, ib.CNBBindings).ViaField("cnbBindings")).
-		Also(validateBuildEnvSecretKeyRefs(ib.Env).ViaField("env"))
+		Also(validateBuildEnvFrom(ib.EnvFrom).ViaField("envFrom")).
+		Also(ib.RetryPolicy.Validate().ViaField("retryPolicy"))
 }
 
 func validateBuilder(builder v1.ObjectReference) *apis.FieldError {
@@ -231,3 +256,83 @@ func validateCnbBindings(ctx context.Context, bindings corev1alpha1.CNBBindings)
 
 	return bindings.Validate(ctx)
 }
+
+func (is *ImageSpec) validateSchedule() *apis.FieldError {
+	if is.Schedule == "" {
+		return nil
+	}
+
+	if _, err := is.ParseSchedule(); err != nil {
+		return apis.ErrInvalidValue(is.Schedule, "schedule", err.Error())
+	}
+	return nil
+}
+
+func (is *ImageSpec) validateUpstreamImages() *apis.FieldError {
+	var errs *apis.FieldError
+	names := map[string]int{}
+	for i, upstream := range is.UpstreamImages {
+		if n, ok := names[upstream.Name]; ok {
+			errs = errs.Also(
+				apis.ErrGeneric(
+					fmt.Sprintf("duplicate upstream image name %q", upstream.Name),
+					fmt.Sprintf("[%d].name", n),
+					fmt.Sprintf("[%d].name", i),
+				),
+			)
+		}
+		names[upstream.Name] = i
+		if upstream.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(i))
+		}
+	}
+	return errs.ViaField("upstreamImages")
+}
+
+// validateUpstreamCycle walks the upstream images of the image and errors if any of them depend on the image.
+// Upstream images are only followed when the context has an ImageLookup and are skipped if they do not exist yet.
+func (i *Image) validateUpstreamCycle(ctx context.Context) *apis.FieldError {
+	lookup, _ := ctx.Value(UpstreamImageLookup).(ImageLookup)
+
+	visited := map[string]bool{}
+	var path []string
+	var visit func(upstreams []v1.LocalObjectReference) bool
+	visit = func(upstreams []v1.LocalObjectReference) bool {
+		for _, upstream := range upstreams {
+			path = append(path, upstream.Name)
+			if upstream.Name == i.Name {
+				return true
+			}
+
+			if lookup != nil && !visited[upstream.Name] {
+				visited[upstream.Name] = true
+				image, err := lookup(i.Namespace, upstream.Name)
+				if err == nil && image != nil && visit(image.Spec.UpstreamImages) {
+					return true
+				}
+			}
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+
+	if !visit(i.Spec.UpstreamImages) {
+		return nil
+	}
+
+	return &apis.FieldError{
+		Message: "upstream images cannot depend on the image",
+		Paths:   []string{""},
+		Details: fmt.Sprintf("cycle: %s -> %s", i.Name, strings.Join(path, " -> ")),
+	}
+}
+
+func (is *ImageSpec) validateAllowedBuildReasons() *apis.FieldError {
+	var errs *apis.FieldError
+	for i, reason := range is.AllowedBuildReasons {
+		if !buildReasons[reason] {
+			errs = errs.Also(apis.ErrInvalidArrayValue(reason, "allowedBuildReasons", i))
+		}
+	}
+	return errs
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -121,6 +122,17 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the image has platforms", func() {
+			image.Spec.Cache = nil
+			image.Spec.Platforms = []string{"linux/amd64", "linux/arm64"}
+
+			it("does not default volume cache", func() {
+				image.SetDefaults(ctx)
+
+				assert.Nil(t, image.Spec.Cache)
+			})
+		})
+
 		when("registry cache is provided", func() {
 			image.Spec.Cache = &ImageCacheConfig{
 				Registry: &RegistryCache{
@@ -211,7 +223,7 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			image.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry", "volume").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
@@ -253,6 +265,60 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(image, ctx, apis.ErrInvalidValue(image.Spec.Source.Registry.Image, "image").ViaField("spec", "source", "registry"))
 		})
 
+		it("validates volume sources", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.Volume = &corev1alpha1.Volume{}
+			assertValidationError(image, ctx, apis.ErrMissingOneOf("persistentVolumeClaim", "configMap").ViaField("spec", "source", "volume"))
+
+			image.Spec.Source.Volume = &corev1alpha1.Volume{
+				PersistentVolumeClaim: &corev1alpha1.PersistentVolumeClaimSource{ClaimName: "some-claim"},
+				ConfigMap:             &corev1alpha1.ConfigMapSource{Name: "some-config-map", Key: "source.tar.gz"},
+			}
+			assertValidationError(image, ctx, apis.ErrMultipleOneOf("persistentVolumeClaim", "configMap").ViaField("spec", "source", "volume"))
+
+			image.Spec.Source.Volume = &corev1alpha1.Volume{
+				PersistentVolumeClaim: &corev1alpha1.PersistentVolumeClaimSource{Path: "../other"},
+			}
+			assertValidationError(image, ctx, apis.ErrMissingField("claimName").
+				Also(apis.ErrInvalidValue("../other", "path", "must be a relative path within the claim")).
+				ViaField("spec", "source", "volume", "persistentVolumeClaim"))
+
+			image.Spec.Source.Volume = &corev1alpha1.Volume{
+				ConfigMap: &corev1alpha1.ConfigMapSource{Name: "some-config-map"},
+			}
+			assertValidationError(image, ctx, apis.ErrMissingField("key").ViaField("spec", "source", "volume", "configMap"))
+
+			image.Spec.Source.Volume = &corev1alpha1.Volume{
+				PersistentVolumeClaim: &corev1alpha1.PersistentVolumeClaimSource{ClaimName: "some-claim", Path: "some/path"},
+			}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
+		it("only allows path filters for git sources", func() {
+			image.Spec.Source.IncludePaths = []string{"services/api/"}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Source.Git = nil
+			image.Spec.Source.Blob = &corev1alpha1.Blob{URL: "http://blob.com/url"}
+			assertValidationError(image, ctx, apis.ErrGeneric("path filters are only supported for git sources", "includePaths", "excludePaths").ViaField("spec", "source"))
+		})
+
+		it("only allows sparse checkout paths with sparse checkout enabled", func() {
+			image.Spec.Source.Git.SparseCheckoutPaths = []string{"shared/"}
+			assertValidationError(image, ctx, apis.ErrGeneric("sparseCheckoutPaths requires sparseCheckout to be enabled", "sparseCheckoutPaths").ViaField("spec", "source", "git"))
+
+			image.Spec.Source.Git.SparseCheckout = true
+			assert.Nil(t, image.Validate(ctx))
+		})
+
+		it("requires a secret for source verification", func() {
+			image.Spec.Source.Git.Verification = &corev1alpha1.SourceVerification{}
+			assertValidationError(image, ctx, apis.ErrMissingField("secretRef.name").ViaField("spec", "source", "git", "verification"))
+
+			image.Spec.Source.Git.Verification.SecretRef.Name = "trusted-keys"
+			assert.Nil(t, image.Validate(ctx))
+		})
+
 		it("validates service bindings", func() {
 			image.Spec.Build.Services = Services{
 				{Kind: "Secret"},
@@ -482,6 +548,88 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(image, ctx, apis.ErrGeneric("secretKeyRef is not supported for build environment variables", "spec.build.env[0].valueFrom.secretKeyRef"))
 		})
 
+		when("the user access to secrets is reviewed", func() {
+			var reviewed []string
+			allowed := map[string]bool{"some-secret": true}
+
+			it.Before(func() {
+				image.Namespace = "some-namespace"
+				ctx = apis.WithUserInfo(context.WithValue(ctx, SecretAccessReview, SecretAccess(func(_ context.Context, user *authv1.UserInfo, namespace, name string) (bool, error) {
+					reviewed = append(reviewed, fmt.Sprintf("%s/%s/%s", user.Username, namespace, name))
+					if name == "broken-secret" {
+						return false, errors.New("some error")
+					}
+					return allowed[name], nil
+				})), &authv1.UserInfo{Username: "some-user"})
+			})
+
+			it("allows secret and config map refs the user can read", func() {
+				image.Spec.Build.Env = []corev1.EnvVar{
+					{
+						Name: "NPM_TOKEN",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret"},
+								Key:                  "token",
+							},
+						},
+					},
+				}
+				image.Spec.Build.EnvFrom = []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-config-map"}}},
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret"}}},
+				}
+
+				assert.Nil(t, image.Validate(ctx))
+				assert.Equal(t, []string{"some-user/some-namespace/some-secret", "some-user/some-namespace/some-secret"}, reviewed)
+			})
+
+			it("disallows secret refs the user cannot read", func() {
+				image.Spec.Build.Env = []corev1.EnvVar{
+					{
+						Name: "SECRET_TOKEN",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "victim-secret"},
+								Key:                  "token",
+							},
+						},
+					},
+				}
+				image.Spec.Build.EnvFrom = []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "broken-secret"}}},
+				}
+
+				assertValidationError(image, ctx, apis.ErrGeneric("user is not allowed to get secret victim-secret", "spec.build.env[0].valueFrom.secretKeyRef").
+					Also(apis.ErrGeneric("unable to check access to secret broken-secret: some error", "spec.build.envFrom[0].secretRef")))
+			})
+
+			it("allows secret refs in images updated by the kpack controller", func() {
+				ctx = apis.WithUserInfo(ctx, &authv1.UserInfo{Username: kpackControllerServiceAccountUsername})
+				image.Spec.Build.EnvFrom = []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "victim-secret"}}},
+				}
+
+				assert.Nil(t, image.Validate(ctx))
+				assert.Empty(t, reviewed)
+			})
+		})
+
+		it("validates build env from sources", func() {
+			image.Spec.Build.EnvFrom = []corev1.EnvFromSource{
+				{},
+				{
+					SecretRef:    &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret"}},
+					ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-config-map"}},
+				},
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{}},
+			}
+
+			assertValidationError(image, ctx, apis.ErrMissingOneOf("spec.build.envFrom[0].secretRef", "spec.build.envFrom[0].configMapRef").
+				Also(apis.ErrMultipleOneOf("spec.build.envFrom[1].secretRef", "spec.build.envFrom[1].configMapRef")).
+				Also(apis.ErrMissingField("spec.build.envFrom[2].configMapRef.name")))
+		})
+
 		when("validating cnb bindings if they have been created by the kpack controller", func() {
 			ctx := apis.WithUserInfo(ctx, &authv1.UserInfo{Username: kpackControllerServiceAccountUsername})
 			it("validates cnb bindings have a name", func() {
@@ -562,5 +710,127 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			image.Spec.Build.NodeSelector = map[string]string{k8sOSLabel: "some-os"}
 			assertValidationError(image, ctx, apis.ErrInvalidKeyName(k8sOSLabel, "spec.build.nodeSelector", "os is determined automatically"))
 		})
+
+		it("validates platforms", func() {
+			image.Spec.Cache = &ImageCacheConfig{Registry: &RegistryCache{Tag: "some/cache"}}
+			image.Spec.Platforms = []string{"linux/amd64", "linux/arm64/v8"}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Platforms = []string{"linux/amd64", "amd64", "windows/amd64", "linux/amd64"}
+			assertValidationError(image, ctx, apis.ErrInvalidArrayValue("amd64", "spec.platforms", 1).
+				Also(apis.ErrGeneric("only linux platforms are supported", "spec.platforms[2]")).
+				Also(apis.ErrGeneric("duplicate platform", "spec.platforms[3]")))
+		})
+
+		it("validates platforms are not built with a volume cache", func() {
+			image.Spec.Platforms = []string{"linux/amd64", "linux/arm64"}
+			assertValidationError(image, ctx, apis.ErrGeneric("volume cache cannot be shared by the platform builds, use a registry cache", "spec.cache.volume"))
+		})
+
+		it("validates kubernetes.io/arch node selector is unset with platforms", func() {
+			image.Spec.Build.NodeSelector = map[string]string{k8sArchLabel: "arm64"}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Cache = nil
+			image.Spec.Platforms = []string{"linux/amd64", "linux/arm64"}
+			assertValidationError(image, ctx, apis.ErrInvalidKeyName(k8sArchLabel, "spec.build.nodeSelector", "arch is determined by platforms"))
+		})
+
+		it("validates schedule is a cron expression", func() {
+			image.Spec.Schedule = "0 4 * * 1"
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Schedule = "@weekly"
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Schedule = "every tuesday"
+			assertValidationError(image, ctx, apis.ErrInvalidValue("every tuesday", "spec.schedule", "expected exactly 5 fields, found 2: [every tuesday]"))
+		})
+
+		it("validates build windows", func() {
+			image.Spec.BuildWindows = BuildWindows{
+				{
+					Reasons:  []string{BuildReasonStack},
+					Schedule: "0 22 * * *",
+					Duration: metav1.Duration{Duration: 6 * time.Hour},
+				},
+			}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.BuildWindows[0].Reasons = []string{BuildReasonCommit}
+			assertValidationError(image, ctx, apis.ErrInvalidArrayValue(BuildReasonCommit, "spec.buildWindows[0].reasons", 0))
+		})
+
+		it("validates upstream images have unique names", func() {
+			image.Spec.UpstreamImages = []corev1.LocalObjectReference{{Name: "base"}}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.UpstreamImages = []corev1.LocalObjectReference{{Name: "base"}, {Name: ""}, {Name: "base"}}
+			assertValidationError(image, ctx, apis.ErrMissingField("spec.upstreamImages[1].name").
+				Also(apis.ErrGeneric(`duplicate upstream image name "base"`, "spec.upstreamImages[0].name", "spec.upstreamImages[2].name")))
+		})
+
+		it("validates allowed build reasons", func() {
+			image.Spec.AllowedBuildReasons = []string{BuildReasonCommit, BuildReasonTrigger}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.AllowedBuildReasons = []string{BuildReasonCommit, "SOMETIMES"}
+			assertValidationError(image, ctx, apis.ErrInvalidArrayValue("SOMETIMES", "spec.allowedBuildReasons", 1))
+		})
+
+		it("validates retry policy", func() {
+			image.Spec.Build.RetryPolicy = &BuildRetryPolicy{MaxAttempts: 3, Backoff: &metav1.Duration{Duration: time.Minute}}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Build.RetryPolicy = &BuildRetryPolicy{MaxAttempts: 0, Backoff: &metav1.Duration{Duration: -time.Minute}}
+			assertValidationError(image, ctx, apis.ErrInvalidValue(int64(0), "spec.build.retryPolicy.maxAttempts", "must be at least 1").
+				Also(apis.ErrInvalidValue("-1m0s", "spec.build.retryPolicy.backoff", "must not be negative")))
+
+			image.Spec.Build.RetryPolicy = &BuildRetryPolicy{MaxAttempts: 101}
+			assertValidationError(image, ctx, apis.ErrInvalidValue(int64(101), "spec.build.retryPolicy.maxAttempts", "must be at most 100"))
+		})
+
+		it("validates the build request annotation", func() {
+			image.Annotations = map[string]string{BuildRequestAnnotation: `{"id":"some-request","revision":"some-revision","logLevel":"debug"}`}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Annotations = map[string]string{BuildRequestAnnotation: `{"env":[{"value":"some-value"}],"logLevel":"trace"}`}
+			assertValidationError(image, ctx, apis.ErrMissingField("metadata.annotations.[image.kpack.io/buildRequest].id").
+				Also(apis.ErrMissingField("metadata.annotations.[image.kpack.io/buildRequest].env[0].name")).
+				Also(apis.ErrInvalidValue("trace", "metadata.annotations.[image.kpack.io/buildRequest].logLevel")))
+
+			image.Annotations = map[string]string{BuildRequestAnnotation: "not-json"}
+			assert.NotNil(t, image.Validate(ctx))
+		})
+
+		it("validates upstream images do not include the image", func() {
+			image.Spec.UpstreamImages = []corev1.LocalObjectReference{{Name: "image-name"}}
+			assertValidationError(image, ctx, &apis.FieldError{
+				Message: "upstream images cannot depend on the image",
+				Paths:   []string{"spec.upstreamImages"},
+				Details: "cycle: image-name -> image-name",
+			})
+		})
+
+		it("validates upstream images do not form a cycle", func() {
+			images := map[string]*Image{
+				"base":       {Spec: ImageSpec{UpstreamImages: []corev1.LocalObjectReference{{Name: "os"}}}},
+				"os":         {Spec: ImageSpec{UpstreamImages: []corev1.LocalObjectReference{{Name: "image-name"}}}},
+				"standalone": {},
+			}
+			lookupCtx := context.WithValue(ctx, UpstreamImageLookup, ImageLookup(func(namespace, name string) (*Image, error) {
+				return images[name], nil
+			}))
+
+			image.Spec.UpstreamImages = []corev1.LocalObjectReference{{Name: "standalone"}, {Name: "missing"}}
+			assert.Nil(t, image.Validate(lookupCtx))
+
+			image.Spec.UpstreamImages = []corev1.LocalObjectReference{{Name: "standalone"}, {Name: "base"}}
+			assertValidationError(image, lookupCtx, &apis.FieldError{
+				Message: "upstream images cannot depend on the image",
+				Paths:   []string{"spec.upstreamImages"},
+				Details: "cycle: image-name -> base -> os -> image-name",
+			})
+		})
 	})
 }
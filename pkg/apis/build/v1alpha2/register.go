@@ -67,6 +67,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterBuildpackList{},
 		&ClusterBuilder{},
 		&ClusterBuilderList{},
+		&Extension{},
+		&ExtensionList{},
+		&ClusterExtension{},
+		&ClusterExtensionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -1,6 +1,8 @@
 package v1alpha2
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -8,6 +10,9 @@ import (
 
 const ActivePolling = "ActivePolling"
 
+// NoMatchingRevisionReason is the reason of a source resolver that is polling a revision selector without a matching tag
+const NoMatchingRevisionReason = "NoMatchingRevision"
+
 func (sr *SourceResolver) ResolvedSource(config corev1alpha1.ResolvedSourceConfig) {
 	resolvedSource := config.ResolvedSource()
 
@@ -22,6 +27,15 @@ func (sr *SourceResolver) ResolvedSource(config corev1alpha1.ResolvedSourceConfi
 		Status: corev1.ConditionTrue,
 	}}
 
+	if config.Git != nil && config.Git.Revision == "" {
+		sr.Status.Conditions = []corev1alpha1.Condition{{
+			Type:    corev1alpha1.ConditionReady,
+			Status:  corev1.ConditionUnknown,
+			Reason:  NoMatchingRevisionReason,
+			Message: fmt.Sprintf("no tag matches revision \"%s\"", sr.Spec.Source.Git.Revision),
+		}}
+	}
+
 	pollingStatus := corev1.ConditionFalse
 	if resolvedSource.IsPollable() {
 		pollingStatus = corev1.ConditionTrue
@@ -53,6 +67,10 @@ func (sr SourceResolver) IsRegistry() bool {
 	return sr.Spec.Source.Registry != nil
 }
 
+func (sr SourceResolver) IsVolume() bool {
+	return sr.Spec.Source.Volume != nil
+}
+
 func (st *SourceResolver) SourceConfig() corev1alpha1.SourceConfig {
 	return st.Status.Source.ResolvedSource().SourceConfig()
 }
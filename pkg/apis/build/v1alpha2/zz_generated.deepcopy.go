@@ -144,6 +144,22 @@ func (in *BuildCacheConfig) DeepCopy() *BuildCacheConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildEnvSource) DeepCopyInto(out *BuildEnvSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildEnvSource.
+func (in *BuildEnvSource) DeepCopy() *BuildEnvSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildEnvSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildList) DeepCopyInto(out *BuildList) {
 	*out = *in
@@ -230,6 +246,50 @@ func (in *BuildPodImages) DeepCopy() *BuildPodImages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRequest) DeepCopyInto(out *BuildRequest) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRequest.
+func (in *BuildRequest) DeepCopy() *BuildRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRetryPolicy) DeepCopyInto(out *BuildRetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRetryPolicy.
+func (in *BuildRetryPolicy) DeepCopy() *BuildRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 	*out = *in
@@ -270,6 +330,18 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvSources != nil {
+		in, out := &in.EnvSources, &out.EnvSources
+		*out = make([]BuildEnvSource, len(*in))
+		copy(*out, *in)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.LastBuild != nil {
 		in, out := &in.LastBuild, &out.LastBuild
@@ -390,6 +462,50 @@ func (in *BuildStatus) DeepCopy() *BuildStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildWindow) DeepCopyInto(out *BuildWindow) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildWindow.
+func (in *BuildWindow) DeepCopy() *BuildWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in BuildWindows) DeepCopyInto(out *BuildWindows) {
+	{
+		in := &in
+		*out = make(BuildWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildWindows.
+func (in BuildWindows) DeepCopy() BuildWindows {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildWindows)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Builder) DeepCopyInto(out *Builder) {
 	*out = *in
@@ -498,6 +614,22 @@ func (in *BuilderOrderEntry) DeepCopy() *BuilderOrderEntry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuilderPlatformImage) DeepCopyInto(out *BuilderPlatformImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderPlatformImage.
+func (in *BuilderPlatformImage) DeepCopy() *BuilderPlatformImage {
+	if in == nil {
+		return nil
+	}
+	out := new(BuilderPlatformImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuilderRecord) DeepCopyInto(out *BuilderRecord) {
 	*out = *in
@@ -515,11 +647,28 @@ func (in *BuilderRecord) DeepCopyInto(out *BuilderRecord) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(v1alpha1.BuildpackMetadataList, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrderExtensions != nil {
+		in, out := &in.OrderExtensions, &out.OrderExtensions
+		*out = make([]v1alpha1.OrderEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SignaturePaths != nil {
 		in, out := &in.SignaturePaths, &out.SignaturePaths
 		*out = make([]CosignSignature, len(*in))
 		copy(*out, *in)
 	}
+	if in.PlatformImages != nil {
+		in, out := &in.PlatformImages, &out.PlatformImages
+		*out = make([]BuilderPlatformImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -533,6 +682,43 @@ func (in *BuilderRecord) DeepCopy() *BuilderRecord {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuilderRollout) DeepCopyInto(out *BuilderRollout) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderRollout.
+func (in *BuilderRollout) DeepCopy() *BuilderRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(BuilderRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuilderRolloutStatus) DeepCopyInto(out *BuilderRolloutStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderRolloutStatus.
+func (in *BuilderRolloutStatus) DeepCopy() *BuilderRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuilderRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuilderSpec) DeepCopyInto(out *BuilderSpec) {
 	*out = *in
@@ -546,6 +732,13 @@ func (in *BuilderSpec) DeepCopyInto(out *BuilderSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OrderExtensions != nil {
+		in, out := &in.OrderExtensions, &out.OrderExtensions
+		*out = make([]BuilderOrderEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AdditionalLabels != nil {
 		in, out := &in.AdditionalLabels, &out.AdditionalLabels
 		*out = make(map[string]string, len(*in))
@@ -553,6 +746,18 @@ func (in *BuilderSpec) DeepCopyInto(out *BuilderSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(BuilderRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.BuildImage = in.BuildImage
+	out.RunImage = in.RunImage
 	return
 }
 
@@ -582,6 +787,18 @@ func (in *BuilderStatus) DeepCopyInto(out *BuilderStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExtensionMetadata != nil {
+		in, out := &in.ExtensionMetadata, &out.ExtensionMetadata
+		*out = make(v1alpha1.BuildpackMetadataList, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrderExtensions != nil {
+		in, out := &in.OrderExtensions, &out.OrderExtensions
+		*out = make([]v1alpha1.OrderEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Stack = in.Stack
 	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
 	if in.SignaturePaths != nil {
@@ -589,6 +806,16 @@ func (in *BuilderStatus) DeepCopyInto(out *BuilderStatus) {
 		*out = make([]CosignSignature, len(*in))
 		copy(*out, *in)
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(BuilderRolloutStatus)
+		**out = **in
+	}
+	if in.PlatformImages != nil {
+		in, out := &in.PlatformImages, &out.PlatformImages
+		*out = make([]BuilderPlatformImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -914,6 +1141,121 @@ func (in *ClusterBuildpackStatus) DeepCopy() *ClusterBuildpackStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtension) DeepCopyInto(out *ClusterExtension) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtension.
+func (in *ClusterExtension) DeepCopy() *ClusterExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObjectMetaAccessor is an autogenerated deepcopy function, copying the receiver, creating a new metav1.ObjectMetaAccessor.
+func (in *ClusterExtension) DeepCopyObjectMetaAccessor() metav1.ObjectMetaAccessor {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterExtension) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionList) DeepCopyInto(out *ClusterExtensionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionList.
+func (in *ClusterExtensionList) DeepCopy() *ClusterExtensionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterExtensionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionSpec) DeepCopyInto(out *ClusterExtensionSpec) {
+	*out = *in
+	out.ImageSource = in.ImageSource
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionSpec.
+func (in *ClusterExtensionSpec) DeepCopy() *ClusterExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionStatus) DeepCopyInto(out *ClusterExtensionStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]v1alpha1.BuildpackStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionStatus.
+func (in *ClusterExtensionStatus) DeepCopy() *ClusterExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterLifecycle) DeepCopyInto(out *ClusterLifecycle) {
 	*out = *in
@@ -1353,6 +1695,116 @@ func (in *CosignSignature) DeepCopy() *CosignSignature {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Extension) DeepCopyInto(out *Extension) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Extension.
+func (in *Extension) DeepCopy() *Extension {
+	if in == nil {
+		return nil
+	}
+	out := new(Extension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObjectMetaAccessor is an autogenerated deepcopy function, copying the receiver, creating a new metav1.ObjectMetaAccessor.
+func (in *Extension) DeepCopyObjectMetaAccessor() metav1.ObjectMetaAccessor {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Extension) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionList) DeepCopyInto(out *ExtensionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Extension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionList.
+func (in *ExtensionList) DeepCopy() *ExtensionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExtensionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionSpec) DeepCopyInto(out *ExtensionSpec) {
+	*out = *in
+	out.ImageSource = in.ImageSource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionSpec.
+func (in *ExtensionSpec) DeepCopy() *ExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionStatus) DeepCopyInto(out *ExtensionStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]v1alpha1.BuildpackStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionStatus.
+func (in *ExtensionStatus) DeepCopy() *ExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
@@ -1403,6 +1855,13 @@ func (in *ImageBuild) DeepCopyInto(out *ImageBuild) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
@@ -1433,6 +1892,11 @@ func (in *ImageBuild) DeepCopyInto(out *ImageBuild) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(BuildRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1583,6 +2047,28 @@ func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BuildWindows != nil {
+		in, out := &in.BuildWindows, &out.BuildWindows
+		*out = make(BuildWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpstreamImages != nil {
+		in, out := &in.UpstreamImages, &out.UpstreamImages
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedBuildReasons != nil {
+		in, out := &in.AllowedBuildReasons, &out.AllowedBuildReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1600,6 +2086,20 @@ func (in *ImageSpec) DeepCopy() *ImageSpec {
 func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.LastScheduledBuildTime != nil {
+		in, out := &in.LastScheduledBuildTime, &out.LastScheduledBuildTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingBuild != nil {
+		in, out := &in.PendingBuild, &out.PendingBuild
+		*out = new(PendingBuild)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LatestPlatformImages != nil {
+		in, out := &in.LatestPlatformImages, &out.LatestPlatformImages
+		*out = make([]PlatformImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1681,6 +2181,42 @@ func (in *NamespacedBuilderSpec) DeepCopy() *NamespacedBuilderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingBuild) DeepCopyInto(out *PendingBuild) {
+	*out = *in
+	if in.DeferredUntil != nil {
+		in, out := &in.DeferredUntil, &out.DeferredUntil
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingBuild.
+func (in *PendingBuild) DeepCopy() *PendingBuild {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingBuild)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformImage) DeepCopyInto(out *PlatformImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformImage.
+func (in *PlatformImage) DeepCopy() *PlatformImage {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryCache) DeepCopyInto(out *RegistryCache) {
 	*out = *in
@@ -1726,6 +2262,11 @@ func (in *ResolvedClusterStack) DeepCopyInto(out *ResolvedClusterStack) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(v1alpha1.BuildpackTarget)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -19,10 +19,14 @@ type BuildpackStatus struct {
 	Size          int64            `json:"size,omitempty"`
 	API           string           `json:"api,omitempty"`
 	Homepage      string           `json:"homepage,omitempty"`
+	// StoreIndexImage is the image index the store image was resolved from, it is empty if the store image is not an index
+	StoreIndexImage string `json:"storeIndexImage,omitempty"`
 	// +listType
 	Order []OrderEntry `json:"order,omitempty"`
 	// +listType
 	Stacks []BuildpackStack `json:"stacks,omitempty"`
+	// +listType
+	Targets []BuildpackTarget `json:"targets,omitempty"`
 }
 
 type Order []OrderEntry
@@ -60,3 +64,23 @@ type BuildpackStack struct {
 	// +listType
 	Mixins []string `json:"mixins,omitempty"`
 }
+
+// BuildpackTarget is an os, arch and distribution supported by a buildpack. Targets replace stacks as of buildpack
+// api 0.10, empty fields match any value.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type BuildpackTarget struct {
+	OS          string `json:"os,omitempty"`
+	Arch        string `json:"arch,omitempty"`
+	ArchVariant string `json:"variant,omitempty"`
+
+	// +listType
+	Distros []BuildpackDistro `json:"distros,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type BuildpackDistro struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
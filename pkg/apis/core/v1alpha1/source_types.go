@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 )
@@ -12,7 +13,15 @@ type SourceConfig struct {
 	Git      *Git      `json:"git,omitempty"`
 	Blob     *Blob     `json:"blob,omitempty"`
 	Registry *Registry `json:"registry,omitempty"`
+	Volume   *Volume   `json:"volume,omitempty"`
 	SubPath  string    `json:"subPath,omitempty"`
+	// IncludePaths are gitignore style patterns, relative to the repository root, of the
+	// files that trigger a build when changed by a new git commit
+	// +listType
+	IncludePaths []string `json:"includePaths,omitempty"`
+	// ExcludePaths are gitignore style patterns of files that never trigger a build when changed
+	// +listType
+	ExcludePaths []string `json:"excludePaths,omitempty"`
 }
 
 func (sc *SourceConfig) Source() Source {
@@ -22,13 +31,58 @@ func (sc *SourceConfig) Source() Source {
 		return sc.Blob
 	} else if sc.Registry != nil {
 		return sc.Registry
+	} else if sc.Volume != nil {
+		return sc.Volume
 	}
 	return nil
 }
 
+func (sc *SourceConfig) HasPathFilters() bool {
+	return len(sc.IncludePaths) > 0 || len(sc.ExcludePaths) > 0
+}
+
 type Source interface {
 	BuildEnvVars() []corev1.EnvVar
 	ImagePullSecretsVolume(name string) corev1.Volume
+	VerificationSecretVolume(name string) corev1.Volume
+	SourceVolume(name string) corev1.Volume
+}
+
+func emptyDirVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type SourceVerification struct {
+	// SecretRef references a secret with the public keys trusted to sign the source. Git commits are
+	// verified with the gpg-keys and ssh-keys entries and registry images with the cosign.pub entry.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+func (v *SourceVerification) volume(name string) corev1.Volume {
+	if v == nil {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: v.SecretRef.Name,
+			},
+		},
+	}
 }
 
 // +k8s:openapi-gen=true
@@ -37,6 +91,14 @@ type Git struct {
 	URL                  string `json:"url"`
 	Revision             string `json:"revision"`
 	InitializeSubmodules bool   `json:"initializeSubmodules,omitempty"`
+	// LFS downloads the content of Git LFS tracked files when fetching the source
+	LFS bool `json:"lfs,omitempty"`
+	// SparseCheckout only checks out the SubPath and SparseCheckoutPaths directories of the repository
+	SparseCheckout bool `json:"sparseCheckout,omitempty"`
+	// +listType
+	SparseCheckoutPaths []string `json:"sparseCheckoutPaths,omitempty"`
+	// Verification requires the commit to carry a GPG or SSH signature from a trusted key
+	Verification *SourceVerification `json:"verification,omitempty"`
 }
 
 func (g *Git) BuildEnvVars() []corev1.EnvVar {
@@ -53,6 +115,22 @@ func (g *Git) BuildEnvVars() []corev1.EnvVar {
 			Name:  "GIT_INITIALIZE_SUBMODULES",
 			Value: strconv.FormatBool(g.InitializeSubmodules),
 		},
+		{
+			Name:  "GIT_LFS",
+			Value: strconv.FormatBool(g.LFS),
+		},
+		{
+			Name:  "GIT_SPARSE_CHECKOUT",
+			Value: strconv.FormatBool(g.SparseCheckout),
+		},
+		{
+			Name:  "GIT_SPARSE_CHECKOUT_PATHS",
+			Value: strings.Join(g.SparseCheckoutPaths, ","),
+		},
+		{
+			Name:  "GIT_VERIFY_SIGNATURE",
+			Value: strconv.FormatBool(g.Verification != nil),
+		},
 	}
 }
 
@@ -65,6 +143,14 @@ func (in *Git) ImagePullSecretsVolume(name string) corev1.Volume {
 	}
 }
 
+func (in *Git) VerificationSecretVolume(name string) corev1.Volume {
+	return in.Verification.volume(name)
+}
+
+func (in *Git) SourceVolume(name string) corev1.Volume {
+	return emptyDirVolume(name)
+}
+
 type BlobAuthKind string
 
 const (
@@ -79,6 +165,8 @@ type Blob struct {
 	URL             string `json:"url"`
 	Auth            string `json:"auth,omitempty"`
 	StripComponents int64  `json:"stripComponents,omitempty"`
+	// Version is populated by the source resolver and identifies the content of the blob at the url
+	Version string `json:"version,omitempty"`
 }
 
 func (b *Blob) ImagePullSecretsVolume(name string) corev1.Volume {
@@ -90,6 +178,19 @@ func (b *Blob) ImagePullSecretsVolume(name string) corev1.Volume {
 	}
 }
 
+func (b *Blob) VerificationSecretVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func (b *Blob) SourceVolume(name string) corev1.Volume {
+	return emptyDirVolume(name)
+}
+
 func (b *Blob) BuildEnvVars() []corev1.EnvVar {
 	return []corev1.EnvVar{
 		{
@@ -111,10 +212,22 @@ func (b *Blob) BuildEnvVars() []corev1.EnvVar {
 // +k8s:deepcopy-gen=true
 type Registry struct {
 	Image string `json:"image"`
+	// Digest is populated by the source resolver and pins the image to the digest it resolved to
+	Digest string `json:"digest,omitempty"`
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	// +listType
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,15,rep,name=imagePullSecrets"`
+	// Verification requires the image to carry a cosign signature from a trusted key
+	Verification *SourceVerification `json:"verification,omitempty"`
+}
+
+func (r *Registry) VerificationSecretVolume(name string) corev1.Volume {
+	return r.Verification.volume(name)
+}
+
+func (r *Registry) SourceVolume(name string) corev1.Volume {
+	return emptyDirVolume(name)
 }
 
 func (r *Registry) ImagePullSecretsVolume(name string) corev1.Volume {
@@ -143,6 +256,104 @@ func (r *Registry) BuildEnvVars() []corev1.EnvVar {
 			Name:  "REGISTRY_IMAGE",
 			Value: r.Image,
 		},
+		{
+			Name:  "REGISTRY_DIGEST",
+			Value: r.Digest,
+		},
+		{
+			Name:  "REGISTRY_VERIFY_SIGNATURE",
+			Value: strconv.FormatBool(r.Verification != nil),
+		},
+	}
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type Volume struct {
+	// PersistentVolumeClaim copies the source from a directory of a claim in the namespace of the Image
+	PersistentVolumeClaim *PersistentVolumeClaimSource `json:"persistentVolumeClaim,omitempty"`
+	// ConfigMap extracts the source from a zip, tar or tar.gz archive stored in a key of a ConfigMap
+	ConfigMap *ConfigMapSource `json:"configMap,omitempty"`
+	// Digest is populated by the source resolver and identifies the content of the volume
+	Digest string `json:"digest,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type PersistentVolumeClaimSource struct {
+	ClaimName string `json:"claimName"`
+	// Path is the directory within the claim that contains the source
+	Path string `json:"path,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type ConfigMapSource struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// Location identifies the volume source in logs and project metadata
+func (v *Volume) Location() string {
+	if v.PersistentVolumeClaim != nil {
+		return strings.TrimSuffix("persistentvolumeclaim/"+v.PersistentVolumeClaim.ClaimName+"/"+v.PersistentVolumeClaim.Path, "/")
+	}
+	return "configmap/" + v.ConfigMap.Name + "/" + v.ConfigMap.Key
+}
+
+func (v *Volume) ImagePullSecretsVolume(name string) corev1.Volume {
+	return emptyDirVolume(name)
+}
+
+func (v *Volume) VerificationSecretVolume(name string) corev1.Volume {
+	return emptyDirVolume(name)
+}
+
+func (v *Volume) SourceVolume(name string) corev1.Volume {
+	if v.PersistentVolumeClaim != nil {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: v.PersistentVolumeClaim.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: v.ConfigMap.Name},
+				Items:                []corev1.KeyToPath{{Key: v.ConfigMap.Key, Path: v.ConfigMap.Key}},
+			},
+		},
+	}
+}
+
+func (v *Volume) BuildEnvVars() []corev1.EnvVar {
+	path, archive := "", false
+	if v.PersistentVolumeClaim != nil {
+		path = v.PersistentVolumeClaim.Path
+	} else if v.ConfigMap != nil {
+		path, archive = v.ConfigMap.Key, true
+	}
+
+	return []corev1.EnvVar{
+		{
+			Name:  "VOLUME_SOURCE",
+			Value: v.Location(),
+		},
+		{
+			Name:  "VOLUME_SOURCE_PATH",
+			Value: path,
+		},
+		{
+			Name:  "VOLUME_SOURCE_ARCHIVE",
+			Value: strconv.FormatBool(archive),
+		},
 	}
 }
 
@@ -152,6 +363,7 @@ type ResolvedSourceConfig struct {
 	Git      *ResolvedGitSource      `json:"git,omitempty"`
 	Blob     *ResolvedBlobSource     `json:"blob,omitempty"`
 	Registry *ResolvedRegistrySource `json:"registry,omitempty"`
+	Volume   *ResolvedVolumeSource   `json:"volume,omitempty"`
 }
 
 func (sc ResolvedSourceConfig) ResolvedSource() ResolvedSource {
@@ -161,6 +373,8 @@ func (sc ResolvedSourceConfig) ResolvedSource() ResolvedSource {
 		return sc.Blob
 	} else if sc.Registry != nil {
 		return sc.Registry
+	} else if sc.Volume != nil {
+		return sc.Volume
 	}
 	return nil
 }
@@ -189,6 +403,13 @@ type ResolvedGitSource struct {
 	Tree                 string        `json:"tree,omitempty"`
 	Type                 GitSourceKind `json:"type"`
 	InitializeSubmodules bool          `json:"initializeSubmodules,omitempty"`
+	LFS                  bool          `json:"lfs,omitempty"`
+	SparseCheckout       bool          `json:"sparseCheckout,omitempty"`
+	// +listType
+	SparseCheckoutPaths []string `json:"sparseCheckoutPaths,omitempty"`
+	// Tag is the tag selected when the revision is a glob or semver constraint
+	Tag          string              `json:"tag,omitempty"`
+	Verification *SourceVerification `json:"verification,omitempty"`
 }
 
 func (gs *ResolvedGitSource) SourceConfig() SourceConfig {
@@ -197,6 +418,10 @@ func (gs *ResolvedGitSource) SourceConfig() SourceConfig {
 			URL:                  gs.URL,
 			Revision:             gs.Revision,
 			InitializeSubmodules: gs.InitializeSubmodules,
+			LFS:                  gs.LFS,
+			SparseCheckout:       gs.SparseCheckout,
+			SparseCheckoutPaths:  gs.SparseCheckoutPaths,
+			Verification:         gs.Verification,
 		},
 		SubPath: gs.SubPath,
 	}
@@ -210,13 +435,24 @@ func (gs *ResolvedGitSource) IsPollable() bool {
 	return gs.Type != Commit && gs.Type != Unknown
 }
 
+type BlobVersionKind string
+
+const (
+	BlobVersionUnknown      BlobVersionKind = "Unknown"
+	BlobVersionDigest       BlobVersionKind = "Digest"
+	BlobVersionETag         BlobVersionKind = "ETag"
+	BlobVersionLastModified BlobVersionKind = "LastModified"
+)
+
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedBlobSource struct {
-	URL             string `json:"url"`
-	Auth            string `json:"auth,omitempty"`
-	SubPath         string `json:"subPath,omitempty"`
-	StripComponents int64  `json:"stripComponents,omitempty"`
+	URL             string          `json:"url"`
+	Auth            string          `json:"auth,omitempty"`
+	SubPath         string          `json:"subPath,omitempty"`
+	StripComponents int64           `json:"stripComponents,omitempty"`
+	Version         string          `json:"version,omitempty"`
+	VersionKind     BlobVersionKind `json:"versionKind,omitempty"`
 }
 
 func (bs *ResolvedBlobSource) SourceConfig() SourceConfig {
@@ -225,44 +461,86 @@ func (bs *ResolvedBlobSource) SourceConfig() SourceConfig {
 			URL:             bs.URL,
 			Auth:            bs.Auth,
 			StripComponents: bs.StripComponents,
+			Version:         bs.Version,
 		},
 		SubPath: bs.SubPath,
 	}
 }
 
 func (bs *ResolvedBlobSource) IsUnknown() bool {
-	return false
+	return bs.VersionKind == BlobVersionUnknown
 }
 
 func (bs *ResolvedBlobSource) IsPollable() bool {
-	return false
+	return bs.VersionKind != "" && bs.VersionKind != BlobVersionUnknown
 }
 
+type RegistrySourceKind string
+
+const (
+	RegistryUnknown RegistrySourceKind = "Unknown"
+	RegistryTag     RegistrySourceKind = "Tag"
+	RegistryDigest  RegistrySourceKind = "Digest"
+)
+
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedRegistrySource struct {
-	Image   string `json:"image"`
-	SubPath string `json:"subPath,omitempty"`
+	Image   string             `json:"image"`
+	Digest  string             `json:"digest,omitempty"`
+	Type    RegistrySourceKind `json:"type,omitempty"`
+	SubPath string             `json:"subPath,omitempty"`
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	// +listType
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,15,rep,name=imagePullSecrets"`
+	Verification     *SourceVerification           `json:"verification,omitempty"`
 }
 
 func (rs *ResolvedRegistrySource) SourceConfig() SourceConfig {
 	return SourceConfig{
 		Registry: &Registry{
 			Image:            rs.Image,
+			Digest:           rs.Digest,
 			ImagePullSecrets: rs.ImagePullSecrets,
+			Verification:     rs.Verification,
 		},
 		SubPath: rs.SubPath,
 	}
 }
 
 func (rs *ResolvedRegistrySource) IsUnknown() bool {
-	return false
+	return rs.Type == RegistryUnknown
 }
 
 func (rs *ResolvedRegistrySource) IsPollable() bool {
-	return false
+	return rs.Type == RegistryTag
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type ResolvedVolumeSource struct {
+	PersistentVolumeClaim *PersistentVolumeClaimSource `json:"persistentVolumeClaim,omitempty"`
+	ConfigMap             *ConfigMapSource             `json:"configMap,omitempty"`
+	SubPath               string                       `json:"subPath,omitempty"`
+	Digest                string                       `json:"digest,omitempty"`
+}
+
+func (vs *ResolvedVolumeSource) SourceConfig() SourceConfig {
+	return SourceConfig{
+		Volume: &Volume{
+			PersistentVolumeClaim: vs.PersistentVolumeClaim,
+			ConfigMap:             vs.ConfigMap,
+			Digest:                vs.Digest,
+		},
+		SubPath: vs.SubPath,
+	}
+}
+
+func (vs *ResolvedVolumeSource) IsUnknown() bool {
+	return vs.Digest == ""
+}
+
+func (vs *ResolvedVolumeSource) IsPollable() bool {
+	return true
 }
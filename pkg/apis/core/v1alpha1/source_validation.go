@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"context"
+	"path"
+	"strings"
 
 	"knative.dev/pkg/apis"
 
@@ -9,7 +11,7 @@ import (
 )
 
 func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
-	sources := make([]string, 0, 3)
+	sources := make([]string, 0, 4)
 	if s.Git != nil {
 		sources = append(sources, "git")
 	}
@@ -19,18 +21,28 @@ func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
 	if s.Registry != nil {
 		sources = append(sources, "registry")
 	}
+	if s.Volume != nil {
+		sources = append(sources, "volume")
+	}
 
 	if len(sources) == 0 {
-		return apis.ErrMissingOneOf("git", "blob", "registry")
+		return apis.ErrMissingOneOf("git", "blob", "registry", "volume")
 	}
 
 	if len(sources) != 1 {
 		return apis.ErrMultipleOneOf(sources...)
 	}
 
-	return (s.Git.Validate(ctx).ViaField("git")).
+	var pathFilterErr *apis.FieldError
+	if s.Git == nil && s.HasPathFilters() {
+		pathFilterErr = apis.ErrGeneric("path filters are only supported for git sources", "includePaths", "excludePaths")
+	}
+
+	return pathFilterErr.
+		Also(s.Git.Validate(ctx).ViaField("git")).
 		Also(s.Blob.Validate(ctx).ViaField("blob")).
-		Also(s.Registry.Validate(ctx).ViaField("registry"))
+		Also(s.Registry.Validate(ctx).ViaField("registry")).
+		Also(s.Volume.Validate(ctx).ViaField("volume"))
 }
 
 func (g *Git) Validate(ctx context.Context) *apis.FieldError {
@@ -38,8 +50,15 @@ func (g *Git) Validate(ctx context.Context) *apis.FieldError {
 		return nil
 	}
 
+	var sparseCheckoutErr *apis.FieldError
+	if !g.SparseCheckout && len(g.SparseCheckoutPaths) > 0 {
+		sparseCheckoutErr = apis.ErrGeneric("sparseCheckoutPaths requires sparseCheckout to be enabled", "sparseCheckoutPaths")
+	}
+
 	return validate.FieldNotEmpty(g.URL, "url").
-		Also(validate.FieldNotEmpty(g.Revision, "revision"))
+		Also(validate.FieldNotEmpty(g.Revision, "revision")).
+		Also(sparseCheckoutErr).
+		Also(g.Verification.Validate(ctx).ViaField("verification"))
 }
 
 func (b *Blob) Validate(ctx context.Context) *apis.FieldError {
@@ -62,5 +81,53 @@ func (r *Registry) Validate(ctx context.Context) *apis.FieldError {
 		return nil
 	}
 
-	return validate.Image(r.Image)
+	return validate.Image(r.Image).
+		Also(r.Verification.Validate(ctx).ViaField("verification"))
+}
+
+func (v *SourceVerification) Validate(ctx context.Context) *apis.FieldError {
+	if v == nil {
+		return nil
+	}
+
+	return validate.FieldNotEmpty(v.SecretRef.Name, "secretRef.name")
+}
+
+func (v *Volume) Validate(ctx context.Context) *apis.FieldError {
+	if v == nil {
+		return nil
+	}
+
+	if v.PersistentVolumeClaim == nil && v.ConfigMap == nil {
+		return apis.ErrMissingOneOf("persistentVolumeClaim", "configMap")
+	}
+
+	if v.PersistentVolumeClaim != nil && v.ConfigMap != nil {
+		return apis.ErrMultipleOneOf("persistentVolumeClaim", "configMap")
+	}
+
+	return v.PersistentVolumeClaim.Validate(ctx).ViaField("persistentVolumeClaim").
+		Also(v.ConfigMap.Validate(ctx).ViaField("configMap"))
+}
+
+func (p *PersistentVolumeClaimSource) Validate(ctx context.Context) *apis.FieldError {
+	if p == nil {
+		return nil
+	}
+
+	var pathErr *apis.FieldError
+	if path.IsAbs(p.Path) || strings.HasPrefix(path.Clean(p.Path), "..") {
+		pathErr = apis.ErrInvalidValue(p.Path, "path", "must be a relative path within the claim")
+	}
+
+	return validate.FieldNotEmpty(p.ClaimName, "claimName").Also(pathErr)
+}
+
+func (c *ConfigMapSource) Validate(ctx context.Context) *apis.FieldError {
+	if c == nil {
+		return nil
+	}
+
+	return validate.FieldNotEmpty(c.Name, "name").
+		Also(validate.FieldNotEmpty(c.Key, "key"))
 }
@@ -83,6 +83,22 @@ func (in *BuildStack) DeepCopy() *BuildStack {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildpackDistro) DeepCopyInto(out *BuildpackDistro) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildpackDistro.
+func (in *BuildpackDistro) DeepCopy() *BuildpackDistro {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildpackDistro)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildpackInfo) DeepCopyInto(out *BuildpackInfo) {
 	*out = *in
@@ -173,6 +189,13 @@ func (in *BuildpackStatus) DeepCopyInto(out *BuildpackStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]BuildpackTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -186,6 +209,27 @@ func (in *BuildpackStatus) DeepCopy() *BuildpackStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildpackTarget) DeepCopyInto(out *BuildpackTarget) {
+	*out = *in
+	if in.Distros != nil {
+		in, out := &in.Distros, &out.Distros
+		*out = make([]BuildpackDistro, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildpackTarget.
+func (in *BuildpackTarget) DeepCopy() *BuildpackTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildpackTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildpackageInfo) DeepCopyInto(out *BuildpackageInfo) {
 	*out = *in
@@ -267,9 +311,35 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapSource) DeepCopyInto(out *ConfigMapSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapSource.
+func (in *ConfigMapSource) DeepCopy() *ConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Git) DeepCopyInto(out *Git) {
 	*out = *in
+	if in.SparseCheckoutPaths != nil {
+		in, out := &in.SparseCheckoutPaths, &out.SparseCheckoutPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(SourceVerification)
+		**out = **in
+	}
 	return
 }
 
@@ -374,6 +444,22 @@ func (in *OrderEntry) DeepCopy() *OrderEntry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimSource) DeepCopyInto(out *PersistentVolumeClaimSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimSource.
+func (in *PersistentVolumeClaimSource) DeepCopy() *PersistentVolumeClaimSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -382,6 +468,11 @@ func (in *Registry) DeepCopyInto(out *Registry) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(SourceVerification)
+		**out = **in
+	}
 	return
 }
 
@@ -414,6 +505,16 @@ func (in *ResolvedBlobSource) DeepCopy() *ResolvedBlobSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedGitSource) DeepCopyInto(out *ResolvedGitSource) {
 	*out = *in
+	if in.SparseCheckoutPaths != nil {
+		in, out := &in.SparseCheckoutPaths, &out.SparseCheckoutPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(SourceVerification)
+		**out = **in
+	}
 	return
 }
 
@@ -435,6 +536,11 @@ func (in *ResolvedRegistrySource) DeepCopyInto(out *ResolvedRegistrySource) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(SourceVerification)
+		**out = **in
+	}
 	return
 }
 
@@ -454,7 +560,7 @@ func (in *ResolvedSourceConfig) DeepCopyInto(out *ResolvedSourceConfig) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(ResolvedGitSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Blob != nil {
 		in, out := &in.Blob, &out.Blob
@@ -466,6 +572,11 @@ func (in *ResolvedSourceConfig) DeepCopyInto(out *ResolvedSourceConfig) {
 		*out = new(ResolvedRegistrySource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(ResolvedVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -479,13 +590,39 @@ func (in *ResolvedSourceConfig) DeepCopy() *ResolvedSourceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedVolumeSource) DeepCopyInto(out *ResolvedVolumeSource) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimSource)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedVolumeSource.
+func (in *ResolvedVolumeSource) DeepCopy() *ResolvedVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
 	*out = *in
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(Git)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Blob != nil {
 		in, out := &in.Blob, &out.Blob
@@ -497,6 +634,21 @@ func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
 		*out = new(Registry)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(Volume)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IncludePaths != nil {
+		in, out := &in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePaths != nil {
+		in, out := &in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -510,6 +662,23 @@ func (in *SourceConfig) DeepCopy() *SourceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceVerification) DeepCopyInto(out *SourceVerification) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceVerification.
+func (in *SourceVerification) DeepCopy() *SourceVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -549,3 +718,29 @@ func (in *VolatileTime) DeepCopy() *VolatileTime {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Volume) DeepCopyInto(out *Volume) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimSource)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
+func (in *Volume) DeepCopy() *Volume {
+	if in == nil {
+		return nil
+	}
+	out := new(Volume)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,23 +29,9 @@ func (f *Fetcher) Fetch(dir string, blobURL string, stripComponents int, metadat
 		return err
 	}
 
-	var headers map[string]string
-	if f.Keychain != nil {
-		var auth string
-		auth, headers, err = f.Keychain.Resolve(blobURL)
-		if err != nil {
-			return fmt.Errorf("failed to resolve creds: %v", err)
-		}
-
-		if headers == nil {
-			headers = make(map[string]string)
-		}
-		headers["Authorization"] = auth
-	}
-
 	f.Logger.Printf("Downloading %s%s...", u.Host, u.Path)
 
-	file, err := downloadBlob(blobURL, headers)
+	file, err := downloadBlob(blobURL, f.Keychain)
 	if err != nil {
 		return err
 	}
@@ -109,14 +95,16 @@ func (f *Fetcher) Fetch(dir string, blobURL string, stripComponents int, metadat
 	return nil
 }
 
-func downloadBlob(blobURL string, headers map[string]string) (*os.File, error) {
+func downloadBlob(blobURL string, keychain Keychain) (*os.File, error) {
 	req, err := http.NewRequest(http.MethodGet, blobURL, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	for k, v := range headers {
-		req.Header.Set(k, v)
+	if keychain != nil {
+		if err := authorize(req, keychain); err != nil {
+			return nil, fmt.Errorf("failed to resolve creds: %v", err)
+		}
 	}
 
 	resp, err := http.DefaultClient.Do(req)
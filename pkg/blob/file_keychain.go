@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -21,6 +22,7 @@ type fileCredential struct {
 	password      string
 	bearer        string
 	authorization string
+	s3            s3Credentials
 }
 
 type fileCreds struct {
@@ -36,19 +38,23 @@ func NewMountedSecretBlobKeychain(volumeName string, secrets []string) (*fileCre
 		}
 
 		dir := os.DirFS(filepath.Join(volumeName, splitSecret[0]))
-		username, err := readFile(dir, "username")
+		username, err := readFile(dir, secretUsernameKey)
 		if err != nil {
 			return nil, err
 		}
-		password, err := readFile(dir, "password")
+		password, err := readFile(dir, secretPasswordKey)
 		if err != nil {
 			return nil, err
 		}
-		bearer, err := readFile(dir, "bearer")
+		bearer, err := readFile(dir, secretBearerKey)
 		if err != nil {
 			return nil, err
 		}
-		authorization, err := readFile(dir, "authorization")
+		authorization, err := readFile(dir, secretAuthorizationKey)
+		if err != nil {
+			return nil, err
+		}
+		s3, err := readS3Credentials(dir)
 		if err != nil {
 			return nil, err
 		}
@@ -61,11 +67,31 @@ func NewMountedSecretBlobKeychain(volumeName string, secrets []string) (*fileCre
 			password:      password,
 			bearer:        bearer,
 			authorization: authorization,
+			s3:            s3,
 		})
 	}
 	return &fileCreds{creds}, nil
 }
 
+func readS3Credentials(dirFs fs.FS) (s3Credentials, error) {
+	var (
+		creds s3Credentials
+		err   error
+	)
+	for key, value := range map[string]*string{
+		secretAccessKeyKey:    &creds.accessKey,
+		secretSecretKeyKey:    &creds.secretKey,
+		secretSessionTokenKey: &creds.sessionToken,
+		secretRegionKey:       &creds.region,
+	} {
+		*value, err = readFile(dirFs, key)
+		if err != nil {
+			return s3Credentials{}, err
+		}
+	}
+	return creds, nil
+}
+
 func readFile(dirFs fs.FS, filename string) (string, error) {
 	_, err := fs.Stat(dirFs, filename)
 	if err != nil {
@@ -80,6 +106,26 @@ func readFile(dirFs fs.FS, filename string) (string, error) {
 	return string(buf), err
 }
 
+// Sign adds a SigV4 signature to requests for domains with an accessKey/secretKey
+func (f *fileCreds) Sign(req *http.Request) (bool, error) {
+	for _, cred := range f.creds {
+		if req.URL.Hostname() != cred.domain {
+			continue
+		}
+
+		if cred.s3.empty() {
+			return false, nil
+		}
+
+		if cred.username != "" || cred.password != "" || cred.bearer != "" || cred.authorization != "" {
+			return false, fmt.Errorf("multiple auths found for '%v', accessKey/secretKey cannot be combined with other auths", cred.secretName)
+		}
+
+		return true, cred.s3.sign(req)
+	}
+	return false, nil
+}
+
 func (f *fileCreds) Resolve(blobUrl string) (string, map[string]string, error) {
 	u, err := url.Parse(blobUrl)
 	if err != nil {
@@ -105,6 +151,13 @@ func (f *fileCreds) Resolve(blobUrl string) (string, map[string]string, error) {
 			authHeader = append(authHeader, cred.authorization)
 		}
 
+		if !cred.s3.empty() {
+			if len(authHeader) > 0 {
+				return "", nil, fmt.Errorf("multiple auths found for '%v', accessKey/secretKey cannot be combined with other auths", cred.secretName)
+			}
+			return "", nil, fmt.Errorf("accessKey/secretKey found for '%v', requests must be signed", cred.secretName)
+		}
+
 		switch len(authHeader) {
 		case 0:
 			return "", nil, fmt.Errorf("no auths found for '%v'", cred.secretName)
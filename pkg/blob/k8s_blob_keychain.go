@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/secret"
+)
+
+const (
+	secretUsernameKey      = "username"
+	secretPasswordKey      = "password"
+	secretBearerKey        = "bearer"
+	secretAuthorizationKey = "authorization"
+	secretAccessKeyKey     = "accessKey"
+	secretSecretKeyKey     = "secretKey"
+	secretSessionTokenKey  = "sessionToken"
+	secretRegionKey        = "region"
+)
+
+// k8sBlobKeychain resolves blob credentials from the service account secrets annotated with kpack.io/blob.
+// It is the controller side equivalent of the mounted secret keychain used in build-init.
+type k8sBlobKeychain struct {
+	secretFetcher secret.Fetcher
+}
+
+func newK8sBlobKeychain(k8sClient k8sclient.Interface) *k8sBlobKeychain {
+	return &k8sBlobKeychain{
+		secretFetcher: secret.Fetcher{Client: k8sClient},
+	}
+}
+
+func (k *k8sBlobKeychain) Keychain(ctx context.Context, namespace, serviceAccount string) (Keychain, error) {
+	secrets, err := k.secretFetcher.SecretsForServiceAccount(ctx, serviceAccount, namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	var creds []fileCredential
+	for _, s := range secrets {
+		domain := s.Annotations[buildapi.BlobSecretAnnotationPrefix]
+		if domain == "" {
+			continue
+		}
+
+		creds = append(creds, fileCredential{
+			domain:     domain,
+			secretName: s.Name,
+
+			username:      string(s.Data[secretUsernameKey]),
+			password:      string(s.Data[secretPasswordKey]),
+			bearer:        string(s.Data[secretBearerKey]),
+			authorization: string(s.Data[secretAuthorizationKey]),
+			s3: s3Credentials{
+				accessKey:    string(s.Data[secretAccessKeyKey]),
+				secretKey:    string(s.Data[secretSecretKeyKey]),
+				sessionToken: string(s.Data[secretSessionTokenKey]),
+				region:       string(s.Data[secretRegionKey]),
+			},
+		})
+	}
+	return &fileCreds{creds}, nil
+}
@@ -1,11 +1,41 @@
 package blob
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 type Keychain interface {
 	Resolve(url string) (authHeader string, headers map[string]string, err error)
 }
 
+// Signer is implemented by keychains with credentials that must be computed for each request,
+// such as AWS SigV4 signatures. Sign returns false if it has no credentials for the request.
+type Signer interface {
+	Sign(req *http.Request) (bool, error)
+}
+
+// authorize adds the credentials for the request url from the keychain to the request
+func authorize(req *http.Request, keychain Keychain) error {
+	if signer, ok := keychain.(Signer); ok {
+		signed, err := signer.Sign(req)
+		if err != nil || signed {
+			return err
+		}
+	}
+
+	auth, headers, err := keychain.Resolve(req.URL.String())
+	if err != nil {
+		return err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Authorization", auth)
+	return nil
+}
+
 var DefaultKeychain = NewMultiKeychain(
 	azKeychain{},
 	gcpKeychain{},
@@ -2,20 +2,55 @@ package blob
 
 import (
 	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	k8sclient "k8s.io/client-go/kubernetes"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
+// resolveTimeout limits the HEAD request made for every poll of the blob
+const resolveTimeout = 30 * time.Second
+
+// digestHeaders are checked in order for a checksum of the blob contents.
+var digestHeaders = []string{
+	"Digest",
+	"X-Checksum-Sha256",
+	"X-Amz-Checksum-Sha256",
+	"X-Goog-Hash",
+}
+
 type Resolver struct {
+	HelperKeychain Keychain
+	Client         *http.Client
+
+	secretKeychain *k8sBlobKeychain
+}
+
+func NewResolver(k8sClient k8sclient.Interface) *Resolver {
+	return &Resolver{
+		HelperKeychain: DefaultKeychain,
+		Client:         &http.Client{Timeout: resolveTimeout},
+		secretKeychain: newK8sBlobKeychain(k8sClient),
+	}
 }
 
-func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	version, kind, err := r.resolveVersion(ctx, sourceResolver)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
 	return corev1alpha1.ResolvedSourceConfig{
 		Blob: &corev1alpha1.ResolvedBlobSource{
-			URL:     sourceResolver.Spec.Source.Blob.URL,
-			Auth:    sourceResolver.Spec.Source.Blob.Auth,
-			SubPath: sourceResolver.Spec.Source.SubPath,
+			URL:         sourceResolver.Spec.Source.Blob.URL,
+			Auth:        sourceResolver.Spec.Source.Blob.Auth,
+			SubPath:     sourceResolver.Spec.Source.SubPath,
+			Version:     version,
+			VersionKind: kind,
 		},
 	}, nil
 }
@@ -23,3 +58,80 @@ func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceRes
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
 	return sourceResolver.IsBlob()
 }
+
+// resolveVersion issues a HEAD request for the blob and uses the response headers to identify its contents.
+// Blobs that cannot be identified are not pollable. Transient failures result in an Unknown version that keeps the
+// previously resolved version, or an error to retry the first resolution of the source.
+//
+// A blob without auth that rejects the request is not pollable, as stores such as s3 reject HEAD requests for
+// presigned GET urls. Credentials that are rejected are an error.
+func (r *Resolver) resolveVersion(ctx context.Context, sourceResolver *buildapi.SourceResolver) (string, corev1alpha1.BlobVersionKind, error) {
+	blobURL := sourceResolver.Spec.Source.Blob.URL
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, blobURL, nil)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "resolving blob %s", blobURL)
+	}
+
+	keychain, err := r.keychain(ctx, sourceResolver)
+	if err != nil {
+		return transientFailure(sourceResolver, errors.Wrapf(err, "getting credentials for blob %s", blobURL))
+	}
+
+	if keychain != nil {
+		if err := authorize(req, keychain); err != nil {
+			return transientFailure(sourceResolver, errors.Wrapf(err, "authorizing request for blob %s", blobURL))
+		}
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return transientFailure(sourceResolver, errors.Wrapf(err, "resolving blob %s", blobURL))
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests:
+		return transientFailure(sourceResolver, errors.Errorf("resolving blob %s: unexpected status %d", blobURL, resp.StatusCode))
+	case (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) && keychain != nil:
+		return "", "", errors.Errorf("resolving blob %s: credentials were rejected with status %d", blobURL, resp.StatusCode)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return "", "", nil
+	}
+
+	for _, header := range digestHeaders {
+		if digest := resp.Header.Get(header); digest != "" {
+			return digest, corev1alpha1.BlobVersionDigest, nil
+		}
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		return etag, corev1alpha1.BlobVersionETag, nil
+	}
+
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+		return lastModified, corev1alpha1.BlobVersionLastModified, nil
+	}
+
+	return "", "", nil
+}
+
+// transientFailure returns an Unknown version once the source resolver has resolved its current generation, the
+// previously resolved version is kept. The first resolution errors so that it is retried.
+func transientFailure(sourceResolver *buildapi.SourceResolver, err error) (string, corev1alpha1.BlobVersionKind, error) {
+	if sourceResolver.Status.Source.Blob == nil || sourceResolver.Status.ObservedGeneration != sourceResolver.Generation {
+		return "", "", err
+	}
+	return "", corev1alpha1.BlobVersionUnknown, nil
+}
+
+func (r *Resolver) keychain(ctx context.Context, sourceResolver *buildapi.SourceResolver) (Keychain, error) {
+	switch corev1alpha1.BlobAuthKind(sourceResolver.Spec.Source.Blob.Auth) {
+	case corev1alpha1.BlobAuthNone:
+		return nil, nil
+	case corev1alpha1.BlobAuthHelper:
+		return r.HelperKeychain, nil
+	default:
+		return r.secretKeychain.Keychain(ctx, sourceResolver.Namespace, sourceResolver.Spec.ServiceAccountName)
+	}
+}
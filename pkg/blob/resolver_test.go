@@ -0,0 +1,285 @@
+package blob_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/blob"
+)
+
+func TestBlobResolver(t *testing.T) {
+	spec.Run(t, "testBlobResolver", testBlobResolver)
+}
+
+func testBlobResolver(t *testing.T, when spec.G, it spec.S) {
+	const (
+		namespace      = "some-namespace"
+		serviceAccount = "some-service-account"
+	)
+
+	var (
+		responseHeaders map[string]string
+		responseStatus  int
+		requests        []*http.Request
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			for k, v := range responseHeaders {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(responseStatus)
+		}))
+
+		k8sClient = fake.NewSimpleClientset(
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: serviceAccount, Namespace: namespace},
+				Secrets:    []corev1.ObjectReference{{Name: "blob-secret"}, {Name: "other-secret"}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "blob-secret",
+					Namespace:   namespace,
+					Annotations: map[string]string{buildapi.BlobSecretAnnotationPrefix: "127.0.0.1"},
+				},
+				Data: map[string][]byte{"bearer": []byte("some-token")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: namespace},
+				Data:       map[string][]byte{"bearer": []byte("other-token")},
+			},
+		)
+
+		resolver = blob.NewResolver(k8sClient)
+	)
+
+	it.Before(func() {
+		responseHeaders = map[string]string{}
+		responseStatus = http.StatusOK
+		requests = nil
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	sourceResolver := func(auth string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: buildapi.SourceResolverSpec{
+				ServiceAccountName: serviceAccount,
+				Source: corev1alpha1.SourceConfig{
+					Blob: &corev1alpha1.Blob{
+						URL:  server.URL + "/latest.tar.gz",
+						Auth: auth,
+					},
+					SubPath: "some-path",
+				},
+			},
+		}
+	}
+
+	it("records the etag of the blob", func() {
+		responseHeaders["ETag"] = `"some-etag"`
+		responseHeaders["Last-Modified"] = "Wed, 21 Oct 2015 07:28:00 GMT"
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(""))
+		require.NoError(t, err)
+
+		require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+			Blob: &corev1alpha1.ResolvedBlobSource{
+				URL:         server.URL + "/latest.tar.gz",
+				SubPath:     "some-path",
+				Version:     `"some-etag"`,
+				VersionKind: corev1alpha1.BlobVersionETag,
+			},
+		}, resolved)
+		require.True(t, resolved.Blob.IsPollable())
+
+		require.Len(t, requests, 1)
+		require.Equal(t, http.MethodHead, requests[0].Method)
+		require.Empty(t, requests[0].Header.Get("Authorization"))
+	})
+
+	it("prefers a content digest", func() {
+		responseHeaders["ETag"] = `"some-etag"`
+		responseHeaders["X-Checksum-Sha256"] = "some-sha"
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(""))
+		require.NoError(t, err)
+		require.Equal(t, "some-sha", resolved.Blob.Version)
+		require.Equal(t, corev1alpha1.BlobVersionDigest, resolved.Blob.VersionKind)
+	})
+
+	it("falls back to last modified", func() {
+		responseHeaders["Last-Modified"] = "Wed, 21 Oct 2015 07:28:00 GMT"
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(""))
+		require.NoError(t, err)
+		require.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", resolved.Blob.Version)
+		require.Equal(t, corev1alpha1.BlobVersionLastModified, resolved.Blob.VersionKind)
+	})
+
+	it("is not pollable when the blob cannot be identified", func() {
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(""))
+		require.NoError(t, err)
+		require.Empty(t, resolved.Blob.Version)
+		require.False(t, resolved.Blob.IsPollable())
+		require.False(t, resolved.Blob.IsUnknown())
+	})
+
+	it("is not pollable when head requests are not allowed", func() {
+		responseStatus = http.StatusForbidden
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(""))
+		require.NoError(t, err)
+		require.False(t, resolved.Blob.IsPollable())
+		require.False(t, resolved.Blob.IsUnknown())
+	})
+
+	it("errors when the server fails before the source is resolved", func() {
+		responseStatus = http.StatusBadGateway
+
+		_, err := resolver.Resolve(context.TODO(), sourceResolver(""))
+		require.EqualError(t, err, "resolving blob "+server.URL+"/latest.tar.gz: unexpected status 502")
+	})
+
+	it("is unknown when the server fails after the source is resolved", func() {
+		responseStatus = http.StatusBadGateway
+
+		resolvedSourceResolver := sourceResolver("")
+		resolvedSourceResolver.Generation = 2
+		resolvedSourceResolver.Status.ObservedGeneration = 2
+		resolvedSourceResolver.Status.Source.Blob = &corev1alpha1.ResolvedBlobSource{Version: `"some-etag"`, VersionKind: corev1alpha1.BlobVersionETag}
+
+		resolved, err := resolver.Resolve(context.TODO(), resolvedSourceResolver)
+		require.NoError(t, err)
+		require.True(t, resolved.Blob.IsUnknown())
+	})
+
+	it("errors when the server does not respond before the source is resolved", func() {
+		responseHeaders["ETag"] = `"some-etag"`
+		resolver.Client = &http.Client{Timeout: time.Nanosecond}
+
+		_, err := resolver.Resolve(context.TODO(), sourceResolver(""))
+		require.ErrorContains(t, err, "resolving blob "+server.URL+"/latest.tar.gz")
+	})
+
+	it("errors for urls that cannot be requested", func() {
+		invalidSourceResolver := sourceResolver("")
+		invalidSourceResolver.Spec.Source.Blob.URL = "://some-invalid-url"
+
+		_, err := resolver.Resolve(context.TODO(), invalidSourceResolver)
+		require.ErrorContains(t, err, "resolving blob ://some-invalid-url")
+	})
+
+	it("errors when the blob secrets cannot be fetched before the source is resolved", func() {
+		k8sClient.PrependReactor("get", "serviceaccounts", func(clientgotesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("some api error")
+		})
+
+		_, err := resolver.Resolve(context.TODO(), sourceResolver("secret"))
+		require.EqualError(t, err, "getting credentials for blob "+server.URL+"/latest.tar.gz: some api error")
+		require.Empty(t, requests)
+	})
+
+	it("is unknown when the blob secrets cannot be fetched after the source is resolved", func() {
+		k8sClient.PrependReactor("get", "serviceaccounts", func(clientgotesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("some api error")
+		})
+
+		resolvedSourceResolver := sourceResolver("secret")
+		resolvedSourceResolver.Status.Source.Blob = &corev1alpha1.ResolvedBlobSource{Version: `"some-etag"`, VersionKind: corev1alpha1.BlobVersionETag}
+
+		resolved, err := resolver.Resolve(context.TODO(), resolvedSourceResolver)
+		require.NoError(t, err)
+		require.True(t, resolved.Blob.IsUnknown())
+	})
+
+	it("errors when the credentials are rejected", func() {
+		responseStatus = http.StatusUnauthorized
+
+		resolvedSourceResolver := sourceResolver("secret")
+		resolvedSourceResolver.Status.Source.Blob = &corev1alpha1.ResolvedBlobSource{Version: `"some-etag"`, VersionKind: corev1alpha1.BlobVersionETag}
+
+		_, err := resolver.Resolve(context.TODO(), resolvedSourceResolver)
+		require.EqualError(t, err, "resolving blob "+server.URL+"/latest.tar.gz: credentials were rejected with status 401")
+	})
+
+	it("uses service account blob secrets", func() {
+		responseHeaders["ETag"] = `"some-etag"`
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver("secret"))
+		require.NoError(t, err)
+		require.Equal(t, `"some-etag"`, resolved.Blob.Version)
+		require.Equal(t, "secret", resolved.Blob.Auth)
+
+		require.Len(t, requests, 1)
+		require.Equal(t, "Bearer some-token", requests[0].Header.Get("Authorization"))
+	})
+
+	it("signs requests to s3 compatible stores", func() {
+		s3 := newS3StandIn("some-access-key", "some-secret-key", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"some-s3-etag"`)
+		}))
+		defer s3.Close()
+
+		_, err := k8sClient.CoreV1().Secrets(namespace).Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "s3-secret",
+				Namespace:   namespace,
+				Annotations: map[string]string{buildapi.BlobSecretAnnotationPrefix: "localhost"},
+			},
+			Data: map[string][]byte{
+				"accessKey": []byte("some-access-key"),
+				"secretKey": []byte("some-secret-key"),
+				"region":    []byte("us-west-2"),
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		defer k8sClient.CoreV1().Secrets(namespace).Delete(context.TODO(), "s3-secret", metav1.DeleteOptions{})
+
+		serviceAccount, err := k8sClient.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), serviceAccount, metav1.GetOptions{})
+		require.NoError(t, err)
+		serviceAccount.Secrets = append(serviceAccount.Secrets, corev1.ObjectReference{Name: "s3-secret"})
+		_, err = k8sClient.CoreV1().ServiceAccounts(namespace).Update(context.TODO(), serviceAccount, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		s3SourceResolver := sourceResolver("secret")
+		s3SourceResolver.Spec.Source.Blob.URL = s3.url("latest.tar.gz")
+
+		resolved, err := resolver.Resolve(context.TODO(), s3SourceResolver)
+		require.NoError(t, err)
+		require.Equal(t, `"some-s3-etag"`, resolved.Blob.Version)
+
+		require.Len(t, s3.requests, 1)
+		require.Equal(t, http.MethodHead, s3.requests[0].method)
+		require.Equal(t, "us-west-2", s3.requests[0].region)
+	})
+
+	it("uses the helper keychain", func() {
+		responseHeaders["ETag"] = `"some-etag"`
+		resolver.HelperKeychain = &fakeKeychain{"some-helper-auth", map[string]string{"some-header": "some-value"}, nil}
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver("helper"))
+		require.NoError(t, err)
+		require.Equal(t, `"some-etag"`, resolved.Blob.Version)
+
+		require.Len(t, requests, 1)
+		require.Equal(t, "some-helper-auth", requests[0].Header.Get("Authorization"))
+		require.Equal(t, "some-value", requests[0].Header.Get("some-header"))
+	})
+}
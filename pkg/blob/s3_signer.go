@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	s3Service       = "s3"
+	s3DefaultRegion = "us-east-1"
+
+	// sha256 of an empty payload, blob requests never have a body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type s3Credentials struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	region       string
+}
+
+func (c s3Credentials) empty() bool {
+	return c.accessKey == "" && c.secretKey == ""
+}
+
+// sign adds an AWS SigV4 signature to the request. It is compatible with S3 and S3 compatible
+// stores such as MinIO and Ceph.
+func (c s3Credentials) sign(req *http.Request) error {
+	region := c.region
+	if region == "" {
+		region = s3DefaultRegion
+	}
+
+	// signers cache derived keys by access key only, a new signer ensures a rotated secret key is used
+	signer := v4.NewSigner(func(options *v4.SignerOptions) {
+		options.DisableURIPathEscaping = true
+	})
+
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	return signer.SignHTTP(req.Context(), aws.Credentials{
+		AccessKeyID:     c.accessKey,
+		SecretAccessKey: c.secretKey,
+		SessionToken:    c.sessionToken,
+	}, req, emptyPayloadHash, s3Service, region, time.Now())
+}
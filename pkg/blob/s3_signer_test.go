@@ -0,0 +1,192 @@
+package blob_test
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/blob"
+)
+
+func TestS3Signer(t *testing.T) {
+	spec.Run(t, "testS3Signer", testS3Signer)
+}
+
+func testS3Signer(t *testing.T, when spec.G, it spec.S) {
+	var (
+		s3         = newS3StandIn("some-access-key", "some-secret-key", http.FileServer(http.Dir("./testdata")))
+		testVolume string
+		testDir    string
+	)
+
+	it.Before(func() {
+		testVolume = t.TempDir()
+		testDir = filepath.Join(testVolume, "some-secret")
+		require.NoError(t, os.Mkdir(testDir, 0777))
+	})
+
+	it.After(func() {
+		s3.Close()
+	})
+
+	writeSecret := func(data map[string]string) blob.Keychain {
+		for k, v := range data {
+			require.NoError(t, os.WriteFile(filepath.Join(testDir, k), []byte(v), 0777))
+		}
+
+		keychain, err := blob.NewMountedSecretBlobKeychain(testVolume, []string{"some-secret=localhost"})
+		require.NoError(t, err)
+		return keychain
+	}
+
+	it("downloads blobs from the stand in with signed requests", func() {
+		keychain := writeSecret(map[string]string{
+			"accessKey":    "some-access-key",
+			"secretKey":    "some-secret-key",
+			"sessionToken": "some-session-token",
+			"region":       "eu-west-1",
+		})
+
+		fetcher := &blob.Fetcher{Logger: log.New(io.Discard, "", 0), Keychain: keychain}
+		err := fetcher.Fetch(t.TempDir(), s3.url("test.tar.gz"), 0, t.TempDir())
+		require.NoError(t, err)
+
+		require.Len(t, s3.requests, 1)
+		require.Equal(t, "eu-west-1", s3.requests[0].region)
+		require.Equal(t, "some-session-token", s3.requests[0].header.Get("X-Amz-Security-Token"))
+	})
+
+	it("defaults the region to us-east-1", func() {
+		keychain := writeSecret(map[string]string{
+			"accessKey": "some-access-key",
+			"secretKey": "some-secret-key",
+		})
+
+		fetcher := &blob.Fetcher{Logger: log.New(io.Discard, "", 0), Keychain: keychain}
+		err := fetcher.Fetch(t.TempDir(), s3.url("test.zip"), 0, t.TempDir())
+		require.NoError(t, err)
+
+		require.Len(t, s3.requests, 1)
+		require.Equal(t, "us-east-1", s3.requests[0].region)
+	})
+
+	it("fails with an invalid secret key", func() {
+		keychain := writeSecret(map[string]string{
+			"accessKey": "some-access-key",
+			"secretKey": "wrong-secret-key",
+		})
+
+		fetcher := &blob.Fetcher{Logger: log.New(io.Discard, "", 0), Keychain: keychain}
+		err := fetcher.Fetch(t.TempDir(), s3.url("test.zip"), 0, t.TempDir())
+		require.ErrorContains(t, err, "failed to get blob "+s3.url("test.zip")+": 403 Forbidden")
+		require.Empty(t, s3.requests)
+	})
+
+	it("does not allow combining access keys with other auths", func() {
+		keychain := writeSecret(map[string]string{
+			"accessKey": "some-access-key",
+			"secretKey": "some-secret-key",
+			"bearer":    "some-token",
+		})
+
+		fetcher := &blob.Fetcher{Logger: log.New(io.Discard, "", 0), Keychain: keychain}
+		err := fetcher.Fetch(t.TempDir(), s3.url("test.zip"), 0, t.TempDir())
+		require.EqualError(t, err, "failed to resolve creds: multiple auths found for 'some-secret', accessKey/secretKey cannot be combined with other auths")
+	})
+}
+
+type s3Request struct {
+	method string
+	region string
+	header http.Header
+}
+
+// s3StandIn is a local S3 compatible server that verifies SigV4 signatures
+type s3StandIn struct {
+	*httptest.Server
+	requests []s3Request
+}
+
+func newS3StandIn(accessKey, secretKey string, handler http.Handler) *s3StandIn {
+	s := &s3StandIn{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		region, err := verifySigV4(r, accessKey, secretKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		s.requests = append(s.requests, s3Request{method: r.Method, region: region, header: r.Header})
+		http.StripPrefix("/some-bucket", handler).ServeHTTP(w, r)
+	}))
+	return s
+}
+
+// url returns an object url with localhost as the host to match the blob secret domain
+func (s *s3StandIn) url(object string) string {
+	return strings.Replace(s.URL, "127.0.0.1", "localhost", 1) + "/some-bucket/" + object
+}
+
+func verifySigV4(r *http.Request, accessKey, secretKey string) (string, error) {
+	const prefix = "AWS4-HMAC-SHA256 Credential="
+
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, prefix) {
+		return "", fmt.Errorf("missing signature")
+	}
+
+	fields := strings.Split(strings.TrimPrefix(authorization, prefix), ", ")
+	if len(fields) != 3 {
+		return "", fmt.Errorf("invalid authorization")
+	}
+
+	scope := strings.Split(fields[0], "/")
+	if len(scope) != 5 || scope[0] != accessKey {
+		return "", fmt.Errorf("invalid credential scope")
+	}
+	region := scope[2]
+
+	signingTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return "", err
+	}
+
+	expected, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		return "", err
+	}
+	for _, h := range strings.Split(strings.TrimPrefix(fields[1], "SignedHeaders="), ";") {
+		if h != "host" {
+			expected.Header.Set(h, r.Header.Get(h))
+		}
+	}
+
+	signer := v4.NewSigner(func(options *v4.SignerOptions) {
+		options.DisableURIPathEscaping = true
+	})
+	err = signer.SignHTTP(r.Context(), aws.Credentials{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+		SessionToken:    r.Header.Get("X-Amz-Security-Token"),
+	}, expected, r.Header.Get("X-Amz-Content-Sha256"), "s3", region, signingTime)
+	if err != nil {
+		return "", err
+	}
+
+	if expected.Header.Get("Authorization") != authorization {
+		return "", fmt.Errorf("signature does not match")
+	}
+	return region, nil
+}
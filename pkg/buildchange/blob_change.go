@@ -0,0 +1,25 @@
+package buildchange
+
+import buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+
+func NewBlobChange(oldVersion, newVersion string) Change {
+	return blobChange{
+		oldVersion: oldVersion,
+		newVersion: newVersion,
+	}
+}
+
+type blobChange struct {
+	oldVersion string
+	newVersion string
+}
+
+func (b blobChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonBlob }
+
+func (b blobChange) IsBuildRequired() (bool, error) { return b.oldVersion != b.newVersion, nil }
+
+func (b blobChange) Old() interface{} { return b.oldVersion }
+
+func (b blobChange) New() interface{} { return b.newVersion }
+
+func (b blobChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
@@ -23,6 +23,22 @@ func NewChangeProcessor() *ChangeProcessor {
 type ChangeProcessor struct {
 	changes []GenericChange
 	errStrs []string
+	allowed map[string]bool
+}
+
+// Allow limits the reasons that may require a build on their own. Changes for other reasons are still summarized,
+// but are only built together with a change for an allowed reason. TRIGGER, REQUEST and RETRY are always allowed.
+// All reasons are allowed if Allow is not called.
+func (c *ChangeProcessor) Allow(reasons []string) *ChangeProcessor {
+	c.allowed = map[string]bool{
+		buildapi.BuildReasonTrigger: true,
+		buildapi.BuildReasonRequest: true,
+		buildapi.BuildReasonRetry:   true,
+	}
+	for _, reason := range reasons {
+		c.allowed[reason] = true
+	}
+	return c
 }
 
 func (c *ChangeProcessor) Process(change Change) *ChangeProcessor {
@@ -49,7 +65,7 @@ func (c *ChangeProcessor) Summarize() (ChangeSummary, error) {
 		c.errStrs = append(c.errStrs, err.Error())
 	}
 
-	summary, err := NewChangeSummary(c.hasChanges(), c.reasonsStr(), changesStr, c.priority())
+	summary, err := NewChangeSummary(c.hasChanges(), c.reasonsStr(), changesStr, c.priority(), c.suppressed())
 	if err != nil {
 		err := errors.Wrapf(err, "error summarizing changes")
 		c.errStrs = append(c.errStrs, err.Error())
@@ -66,6 +82,20 @@ func (c *ChangeProcessor) hasChanges() bool {
 	return len(c.changes) > 0
 }
 
+// suppressed is true if there are changes but none of them are for an allowed reason
+func (c *ChangeProcessor) suppressed() bool {
+	if c.allowed == nil || !c.hasChanges() {
+		return false
+	}
+
+	for _, change := range c.changes {
+		if c.allowed[change.Reason] {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *ChangeProcessor) reasonsStr() string {
 	if !c.hasChanges() {
 		return ""
@@ -591,4 +591,51 @@ func testChangeProcessor(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("reasons are allowed", func() {
+		commitChange := buildchange.NewCommitChange("old-revision", "new-revision")
+		stackChange := buildchange.NewStackChange(
+			"some.registry.io/run-image@sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+			"some.registry.io/run-image@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
+		)
+
+		it("suppresses changes when none of the reasons are allowed", func() {
+			summary, err := cp.Allow([]string{buildapi.BuildReasonCommit}).Process(stackChange).Summarize()
+			assert.NoError(t, err)
+			assert.True(t, summary.HasChanges)
+			assert.True(t, summary.Suppressed)
+			assert.Equal(t, "STACK", summary.ReasonsStr)
+		})
+
+		it("does not suppress changes built with an allowed reason", func() {
+			summary, err := cp.Allow([]string{buildapi.BuildReasonCommit}).Process(commitChange).Process(stackChange).Summarize()
+			assert.NoError(t, err)
+			assert.True(t, summary.HasChanges)
+			assert.False(t, summary.Suppressed)
+			assert.Equal(t, "COMMIT,STACK", summary.ReasonsStr)
+		})
+
+		it("always allows triggered builds", func() {
+			triggerChange := buildchange.NewTriggerChange("Fri, 20 Nov 2020 15:38:15 -0500")
+
+			summary, err := cp.Allow(nil).Process(triggerChange).Process(stackChange).Summarize()
+			assert.NoError(t, err)
+			assert.False(t, summary.Suppressed)
+			assert.Equal(t, "TRIGGER,STACK", summary.ReasonsStr)
+		})
+
+		it("always allows retried builds", func() {
+			summary, err := cp.Allow(nil).Process(buildchange.NewRetryChange(1, 2)).Summarize()
+			assert.NoError(t, err)
+			assert.False(t, summary.Suppressed)
+			assert.Equal(t, "RETRY", summary.ReasonsStr)
+		})
+
+		it("always allows requested builds", func() {
+			summary, err := cp.Allow(nil).Process(buildchange.NewRequestChange(buildapi.BuildRequest{}, buildapi.BuildRequest{ID: "some-request"})).Summarize()
+			assert.NoError(t, err)
+			assert.False(t, summary.Suppressed)
+			assert.Equal(t, "REQUEST", summary.ReasonsStr)
+		})
+	})
 }
@@ -10,14 +10,17 @@ type ChangeSummary struct {
 	ReasonsStr string
 	ChangesStr string
 	Priority   buildapi.BuildPriority
+	// Suppressed is true if none of the changes are for a reason that is allowed to build
+	Suppressed bool
 }
 
-func NewChangeSummary(hasChanges bool, reasonsStr, changesStr string, priority buildapi.BuildPriority) (ChangeSummary, error) {
+func NewChangeSummary(hasChanges bool, reasonsStr, changesStr string, priority buildapi.BuildPriority, suppressed bool) (ChangeSummary, error) {
 	cs := ChangeSummary{
 		HasChanges: hasChanges,
 		ReasonsStr: reasonsStr,
 		ChangesStr: changesStr,
 		Priority:   priority,
+		Suppressed: suppressed,
 	}
 
 	if !cs.IsValid() {
@@ -22,18 +22,27 @@ type configChange struct {
 
 type Config struct {
 	Env         []corev1.EnvVar             `json:"env,omitempty"`
+	EnvFrom     []corev1.EnvFromSource      `json:"envFrom,omitempty"`
+	EnvSources  []buildapi.BuildEnvSource   `json:"envSources,omitempty"`
 	Resources   corev1.ResourceRequirements `json:"resources,omitempty"`
 	Services    buildapi.Services           `json:"services,omitempty"`
 	CNBBindings corev1alpha1.CNBBindings    `json:"cnbBindings,omitempty"`
 	Source      corev1alpha1.SourceConfig   `json:"source,omitempty"`
+	Platforms   []string                    `json:"platforms,omitempty"`
 }
 
 func (c configChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonConfig }
 
 func (c configChange) IsBuildRequired() (bool, error) {
 	// Git revision changes are considered as COMMIT change
+	// Blob version changes are considered as BLOB change
+	// Registry digest changes are considered as REGISTRY change
+	// Volume digest changes are considered as VOLUME change
 	// Ignore them as part of CONFIG Change
 	var oldGitRevision, newGitRevision string
+	var oldBlobVersion, newBlobVersion string
+	var oldRegistryDigest, newRegistryDigest string
+	var oldVolumeDigest, newVolumeDigest string
 
 	if c.old.Source.Git != nil {
 		oldGitRevision = c.old.Source.Git.Revision
@@ -43,6 +52,30 @@ func (c configChange) IsBuildRequired() (bool, error) {
 		newGitRevision = c.new.Source.Git.Revision
 		c.new.Source.Git.Revision = ""
 	}
+	if c.old.Source.Blob != nil {
+		oldBlobVersion = c.old.Source.Blob.Version
+		c.old.Source.Blob.Version = ""
+	}
+	if c.new.Source.Blob != nil {
+		newBlobVersion = c.new.Source.Blob.Version
+		c.new.Source.Blob.Version = ""
+	}
+	if c.old.Source.Registry != nil {
+		oldRegistryDigest = c.old.Source.Registry.Digest
+		c.old.Source.Registry.Digest = ""
+	}
+	if c.new.Source.Registry != nil {
+		newRegistryDigest = c.new.Source.Registry.Digest
+		c.new.Source.Registry.Digest = ""
+	}
+	if c.old.Source.Volume != nil {
+		oldVolumeDigest = c.old.Source.Volume.Digest
+		c.old.Source.Volume.Digest = ""
+	}
+	if c.new.Source.Volume != nil {
+		newVolumeDigest = c.new.Source.Volume.Digest
+		c.new.Source.Volume.Digest = ""
+	}
 
 	valid := !equality.Semantic.DeepEqual(c.old, c.new)
 
@@ -52,6 +85,24 @@ func (c configChange) IsBuildRequired() (bool, error) {
 	if c.new.Source.Git != nil {
 		c.new.Source.Git.Revision = newGitRevision
 	}
+	if c.old.Source.Blob != nil {
+		c.old.Source.Blob.Version = oldBlobVersion
+	}
+	if c.new.Source.Blob != nil {
+		c.new.Source.Blob.Version = newBlobVersion
+	}
+	if c.old.Source.Registry != nil {
+		c.old.Source.Registry.Digest = oldRegistryDigest
+	}
+	if c.new.Source.Registry != nil {
+		c.new.Source.Registry.Digest = newRegistryDigest
+	}
+	if c.old.Source.Volume != nil {
+		c.old.Source.Volume.Digest = oldVolumeDigest
+	}
+	if c.new.Source.Volume != nil {
+		c.new.Source.Volume.Digest = newVolumeDigest
+	}
 	return valid, nil
 }
 
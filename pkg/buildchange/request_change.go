@@ -0,0 +1,25 @@
+package buildchange
+
+import buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+
+func NewRequestChange(replaced, request buildapi.BuildRequest) Change {
+	return requestChange{
+		replaced: replaced,
+		request:  request,
+	}
+}
+
+type requestChange struct {
+	replaced buildapi.BuildRequest
+	request  buildapi.BuildRequest
+}
+
+func (r requestChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonRequest }
+
+func (r requestChange) IsBuildRequired() (bool, error) { return true, nil }
+
+func (r requestChange) Old() interface{} { return r.replaced }
+
+func (r requestChange) New() interface{} { return r.request }
+
+func (r requestChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
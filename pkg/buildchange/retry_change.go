@@ -0,0 +1,25 @@
+package buildchange
+
+import buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+
+func NewRetryChange(lastAttempt, attempt int64) Change {
+	return retryChange{
+		lastAttempt: lastAttempt,
+		attempt:     attempt,
+	}
+}
+
+type retryChange struct {
+	lastAttempt int64
+	attempt     int64
+}
+
+func (r retryChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonRetry }
+
+func (r retryChange) IsBuildRequired() (bool, error) { return r.attempt > r.lastAttempt, nil }
+
+func (r retryChange) Old() interface{} { return r.lastAttempt }
+
+func (r retryChange) New() interface{} { return r.attempt }
+
+func (r retryChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityLow }
@@ -0,0 +1,25 @@
+package buildchange
+
+import buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+
+func NewScheduledChange(lastScheduled, scheduled string) Change {
+	return scheduledChange{
+		lastScheduled: lastScheduled,
+		scheduled:     scheduled,
+	}
+}
+
+type scheduledChange struct {
+	lastScheduled string
+	scheduled     string
+}
+
+func (s scheduledChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonScheduled }
+
+func (s scheduledChange) IsBuildRequired() (bool, error) { return s.scheduled != "", nil }
+
+func (s scheduledChange) Old() interface{} { return s.lastScheduled }
+
+func (s scheduledChange) New() interface{} { return s.scheduled }
+
+func (s scheduledChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityLow }
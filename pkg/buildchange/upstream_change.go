@@ -0,0 +1,38 @@
+package buildchange
+
+import buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+
+// NewUpstreamChange compares the latest image of each upstream Image when the last build was created with its
+// current latest image. Upstream Images that were not recorded by the last build, or that no longer have a latest
+// image, are not a change.
+func NewUpstreamChange(oldImages, newImages map[string]string) Change {
+	change := upstreamChange{
+		oldImages: map[string]string{},
+		newImages: map[string]string{},
+	}
+
+	for name, newImage := range newImages {
+		oldImage, ok := oldImages[name]
+		if !ok || newImage == "" || oldImage == newImage {
+			continue
+		}
+		change.oldImages[name] = oldImage
+		change.newImages[name] = newImage
+	}
+	return change
+}
+
+type upstreamChange struct {
+	oldImages map[string]string
+	newImages map[string]string
+}
+
+func (u upstreamChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonUpstream }
+
+func (u upstreamChange) IsBuildRequired() (bool, error) { return len(u.newImages) > 0, nil }
+
+func (u upstreamChange) Old() interface{} { return u.oldImages }
+
+func (u upstreamChange) New() interface{} { return u.newImages }
+
+func (u upstreamChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityLow }
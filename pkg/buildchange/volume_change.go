@@ -0,0 +1,25 @@
+package buildchange
+
+import buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+
+func NewVolumeChange(oldDigest, newDigest string) Change {
+	return volumeChange{
+		oldDigest: oldDigest,
+		newDigest: newDigest,
+	}
+}
+
+type volumeChange struct {
+	oldDigest string
+	newDigest string
+}
+
+func (v volumeChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonVolume }
+
+func (v volumeChange) IsBuildRequired() (bool, error) { return v.oldDigest != v.newDigest, nil }
+
+func (v volumeChange) Old() interface{} { return v.oldDigest }
+
+func (v volumeChange) New() interface{} { return v.newDigest }
+
+func (v volumeChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
@@ -224,9 +224,17 @@ func (g *Generator) fetchBuilderConfig(ctx context.Context, build BuildPodable)
 		return buildapi.BuildPodBuilderConfig{}, errors.Wrap(err, "unable to fetch remote builder image")
 	}
 
-	stackId, err := imagehelpers.GetStringLabel(image, platform.StackIDLabel)
+	// builders of stacks without an id, that only have targets, do not have a stack ID label
+	var stackId string
+	hasStackId, err := imagehelpers.HasLabel(image, platform.StackIDLabel)
 	if err != nil {
-		return buildapi.BuildPodBuilderConfig{}, errors.Wrap(err, "builder image stack ID label not present")
+		return buildapi.BuildPodBuilderConfig{}, err
+	}
+	if hasStackId {
+		stackId, err = imagehelpers.GetStringLabel(image, platform.StackIDLabel)
+		if err != nil {
+			return buildapi.BuildPodBuilderConfig{}, errors.Wrap(err, "builder image stack ID label not present")
+		}
 	}
 
 	var metadata cnb.BuilderImageMetadata
@@ -261,6 +269,7 @@ func (g *Generator) fetchBuilderConfig(ctx context.Context, build BuildPodable)
 		Uid:           uid,
 		Gid:           gid,
 		ResolvedImage: builderImageRef,
+		Extensions:    len(metadata.Extensions) > 0,
 	}, nil
 }
 
@@ -692,6 +692,55 @@ func testGenerator(t *testing.T, when spec.G, it spec.S) {
 			assert.True(t, build.buildPodCalls[0].BuildContext.InjectedSidecarSupport)
 		})
 
+		it("passes the image extensions flag through the build context", func() {
+			extensionsBuilderImage := "builder/extensions"
+			image, err := imagehelpers.SetStringLabel(createImage(t, "linux"), "io.buildpacks.builder.metadata", //language=json
+				`{ "stack": { "runImage": { "image": "some-registry.io/run-image"} }, "extensions": [ { "id": "some.extension", "version": "1.0.0" } ] }`)
+			require.NoError(t, err)
+			imageFetcher.AddImage(extensionsBuilderImage, image, keychain)
+
+			var build = &testBuildPodable{
+				serviceAccount: serviceAccountName,
+				namespace:      namespace,
+				buildBuilderSpec: corev1alpha1.BuildBuilderSpec{
+					Image:            extensionsBuilderImage,
+					ImagePullSecrets: builderPullSecrets,
+				},
+			}
+
+			_, err = generator.Generate(context.TODO(), build)
+			require.NoError(t, err)
+
+			require.Len(t, build.buildPodCalls, 1)
+			assert.True(t, build.buildPodCalls[0].BuildContext.BuildPodBuilderConfig.Extensions)
+		})
+
+		it("passes an empty stack id through the build context when the builder has no stack id", func() {
+			stacklessBuilderImage := "builder/stackless"
+			image := createImage(t, "linux")
+			config, err := image.ConfigFile()
+			require.NoError(t, err)
+			delete(config.Config.Labels, platform.StackIDLabel)
+			image, err = mutate.ConfigFile(image, config)
+			require.NoError(t, err)
+			imageFetcher.AddImage(stacklessBuilderImage, image, keychain)
+
+			var build = &testBuildPodable{
+				serviceAccount: serviceAccountName,
+				namespace:      namespace,
+				buildBuilderSpec: corev1alpha1.BuildBuilderSpec{
+					Image:            stacklessBuilderImage,
+					ImagePullSecrets: builderPullSecrets,
+				},
+			}
+
+			_, err = generator.Generate(context.TODO(), build)
+			require.NoError(t, err)
+
+			require.Len(t, build.buildPodCalls, 1)
+			assert.Equal(t, "", build.buildPodCalls[0].BuildContext.BuildPodBuilderConfig.StackID)
+		})
+
 		it("errors when the builder is windowa", func() {
 
 			var build = &testBuildPodable{
@@ -0,0 +1,185 @@
+package buildqueue
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+)
+
+// Limits caps the number of builds with a pod across the cluster, in a namespace and for a builder. A limit of 0 is
+// unlimited.
+type Limits struct {
+	Global       int
+	PerNamespace int
+	PerBuilder   int
+}
+
+func (l Limits) Enabled() bool {
+	return l.Global > 0 || l.PerNamespace > 0 || l.PerBuilder > 0
+}
+
+// Queue admits builds once their pod fits within the limits. Queued builds are admitted by priority, then in turn
+// across namespaces by the number of builds each namespace is running, then by age.
+type Queue struct {
+	Limits      Limits
+	BuildLister buildlisters.BuildLister
+}
+
+// Admit returns true if a pod may be created for the build, otherwise the 1-based position of the build in the queue
+func (q *Queue) Admit(build *buildapi.Build) (bool, int, error) {
+	if !q.Limits.Enabled() {
+		return true, 0, nil
+	}
+
+	builds, err := q.BuildLister.List(labels.Everything())
+	if err != nil {
+		return false, 0, err
+	}
+
+	running := newCounts()
+	var queued []*buildapi.Build
+	for _, b := range builds {
+		switch {
+		case b.Namespace == build.Namespace && b.Name == build.Name:
+			continue
+		case b.Finished():
+			continue
+		case b.Status.PodName != "":
+			running.add(b)
+		default:
+			queued = append(queued, b)
+		}
+	}
+	queued = append(queued, build)
+
+	admitted := running.copy()
+	for i, b := range order(queued, running) {
+		fits := admitted.fits(b, q.Limits)
+		if fits {
+			admitted.add(b)
+		}
+
+		if b == build {
+			return fits, i + 1, nil
+		}
+	}
+	return false, 0, fmt.Errorf("build '%s' was not ordered in the build queue", build.Name)
+}
+
+// order sorts queued builds by priority, then takes the oldest build from the namespace with the fewest running
+// builds so a namespace with many queued builds cannot starve the others
+func order(queued []*buildapi.Build, running counts) []*buildapi.Build {
+	sort.SliceStable(queued, func(i, j int) bool {
+		return before(queued[i], queued[j])
+	})
+
+	var namespaces []string
+	byNamespace := map[string][]*buildapi.Build{}
+	for _, b := range queued {
+		if _, ok := byNamespace[b.Namespace]; !ok {
+			namespaces = append(namespaces, b.Namespace)
+		}
+		byNamespace[b.Namespace] = append(byNamespace[b.Namespace], b)
+	}
+
+	scheduled := running.copy()
+	ordered := make([]*buildapi.Build, 0, len(queued))
+	for len(ordered) < len(queued) {
+		var next string
+		for _, namespace := range namespaces {
+			if len(byNamespace[namespace]) == 0 {
+				continue
+			}
+			if next == "" || fairer(byNamespace[namespace][0], byNamespace[next][0], scheduled) {
+				next = namespace
+			}
+		}
+
+		b := byNamespace[next][0]
+		byNamespace[next] = byNamespace[next][1:]
+		scheduled.namespaces[next]++
+		ordered = append(ordered, b)
+	}
+	return ordered
+}
+
+func fairer(b, other *buildapi.Build, scheduled counts) bool {
+	if b.Priority() != other.Priority() {
+		return b.Priority() > other.Priority()
+	}
+	if scheduled.namespaces[b.Namespace] != scheduled.namespaces[other.Namespace] {
+		return scheduled.namespaces[b.Namespace] < scheduled.namespaces[other.Namespace]
+	}
+	return before(b, other)
+}
+
+func before(b, other *buildapi.Build) bool {
+	if b.Priority() != other.Priority() {
+		return b.Priority() > other.Priority()
+	}
+	if !b.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return b.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	if b.Namespace != other.Namespace {
+		return b.Namespace < other.Namespace
+	}
+	return b.Name < other.Name
+}
+
+type counts struct {
+	global     int
+	namespaces map[string]int
+	builders   map[string]int
+}
+
+func newCounts() counts {
+	return counts{
+		namespaces: map[string]int{},
+		builders:   map[string]int{},
+	}
+}
+
+func (c counts) copy() counts {
+	copied := newCounts()
+	copied.global = c.global
+	for k, v := range c.namespaces {
+		copied.namespaces[k] = v
+	}
+	for k, v := range c.builders {
+		copied.builders[k] = v
+	}
+	return copied
+}
+
+func (c *counts) add(build *buildapi.Build) {
+	c.global++
+	c.namespaces[build.Namespace]++
+	c.builders[builderKey(build)]++
+}
+
+func (c counts) fits(build *buildapi.Build, limits Limits) bool {
+	return underLimit(c.global, limits.Global) &&
+		underLimit(c.namespaces[build.Namespace], limits.PerNamespace) &&
+		underLimit(c.builders[builderKey(build)], limits.PerBuilder)
+}
+
+func underLimit(count, limit int) bool {
+	return limit <= 0 || count < limit
+}
+
+// builderKey identifies the Builder or ClusterBuilder of builds created by an Image and the builder image otherwise
+func builderKey(build *buildapi.Build) string {
+	name := build.Annotations[buildapi.BuilderNameAnnotation]
+	switch build.Annotations[buildapi.BuilderKindAnnotation] {
+	case buildapi.ClusterBuilderKind:
+		return fmt.Sprintf("%s/%s", buildapi.ClusterBuilderKind, name)
+	case buildapi.BuilderKind:
+		return fmt.Sprintf("%s/%s/%s", buildapi.BuilderKind, build.Namespace, name)
+	default:
+		return build.Spec.Builder.Image
+	}
+}
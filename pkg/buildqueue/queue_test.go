@@ -0,0 +1,148 @@
+package buildqueue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildqueue"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+)
+
+func TestQueue(t *testing.T) {
+	spec.Run(t, "Build Queue", testQueue)
+}
+
+func testQueue(t *testing.T, when spec.G, it spec.S) {
+	created := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
+
+	newBuild := func(namespace, name, reasons string, age int) *buildapi.Build {
+		return &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(created.Add(-time.Duration(age) * time.Minute)),
+				Annotations: map[string]string{
+					buildapi.BuildReasonAnnotation: reasons,
+					buildapi.BuilderKindAnnotation: buildapi.ClusterBuilderKind,
+					buildapi.BuilderNameAnnotation: "some-builder",
+				},
+			},
+		}
+	}
+
+	running := func(build *buildapi.Build) *buildapi.Build {
+		build.Status.PodName = build.Name + "-build-pod"
+		return build
+	}
+
+	queue := func(limits buildqueue.Limits, builds ...*buildapi.Build) *buildqueue.Queue {
+		objects := make([]runtime.Object, 0, len(builds))
+		for _, b := range builds {
+			objects = append(objects, b)
+		}
+		listers := testhelpers.NewListers(objects)
+		return &buildqueue.Queue{
+			Limits:      limits,
+			BuildLister: listers.GetBuildLister(),
+		}
+	}
+
+	assertAdmit := func(q *buildqueue.Queue, build *buildapi.Build, expectedAdmitted bool, expectedPosition int) {
+		t.Helper()
+		admitted, position, err := q.Admit(build)
+		require.NoError(t, err)
+		assert.Equal(t, expectedAdmitted, admitted)
+		assert.Equal(t, expectedPosition, position)
+	}
+
+	it("admits every build without limits", func() {
+		build := newBuild("some-namespace", "build", "STACK", 0)
+		q := queue(buildqueue.Limits{}, build, running(newBuild("some-namespace", "running", "STACK", 10)))
+
+		assertAdmit(q, build, true, 0)
+	})
+
+	it("admits builds up to the global limit", func() {
+		first := newBuild("some-namespace", "first", "COMMIT", 2)
+		second := newBuild("some-namespace", "second", "COMMIT", 1)
+		q := queue(buildqueue.Limits{Global: 2}, first, second, running(newBuild("some-namespace", "running", "COMMIT", 10)))
+
+		assertAdmit(q, first, true, 1)
+		assertAdmit(q, second, false, 2)
+	})
+
+	it("orders queued builds by priority then age", func() {
+		stack := newBuild("some-namespace", "stack", "STACK", 10)
+		commit := newBuild("some-namespace", "commit", "COMMIT", 1)
+		older := newBuild("some-namespace", "older", "CONFIG", 5)
+		q := queue(buildqueue.Limits{Global: 1}, stack, commit, older, running(newBuild("some-namespace", "running", "COMMIT", 20)))
+
+		assertAdmit(q, older, false, 1)
+		assertAdmit(q, commit, false, 2)
+		assertAdmit(q, stack, false, 3)
+	})
+
+	it("takes builds in turn from each namespace", func() {
+		busy1 := newBuild("busy-namespace", "build-1", "STACK", 10)
+		busy2 := newBuild("busy-namespace", "build-2", "STACK", 9)
+		busy3 := newBuild("busy-namespace", "build-3", "STACK", 8)
+		quiet := newBuild("quiet-namespace", "build-1", "STACK", 1)
+		q := queue(buildqueue.Limits{Global: 1}, busy1, busy2, busy3, quiet)
+
+		assertAdmit(q, busy1, true, 1)
+		assertAdmit(q, quiet, false, 2)
+		assertAdmit(q, busy2, false, 3)
+		assertAdmit(q, busy3, false, 4)
+	})
+
+	it("prefers namespaces running fewer builds", func() {
+		busy := newBuild("busy-namespace", "build", "STACK", 10)
+		quiet := newBuild("quiet-namespace", "build", "STACK", 1)
+		q := queue(buildqueue.Limits{Global: 2}, busy, quiet, running(newBuild("busy-namespace", "running", "STACK", 20)))
+
+		assertAdmit(q, quiet, true, 1)
+		assertAdmit(q, busy, false, 2)
+	})
+
+	it("admits builds up to the namespace limit", func() {
+		blocked := newBuild("full-namespace", "build", "COMMIT", 10)
+		other := newBuild("other-namespace", "build", "STACK", 1)
+		q := queue(buildqueue.Limits{PerNamespace: 1}, blocked, other, running(newBuild("full-namespace", "running", "COMMIT", 20)))
+
+		assertAdmit(q, blocked, false, 1)
+		assertAdmit(q, other, true, 2)
+	})
+
+	it("admits builds up to the builder limit", func() {
+		blocked := newBuild("some-namespace", "build", "COMMIT", 10)
+		other := newBuild("some-namespace", "other-builder", "COMMIT", 1)
+		other.Annotations[buildapi.BuilderNameAnnotation] = "some-other-builder"
+		q := queue(buildqueue.Limits{PerBuilder: 1}, blocked, other, running(newBuild("another-namespace", "running", "COMMIT", 20)))
+
+		assertAdmit(q, blocked, false, 1)
+		assertAdmit(q, other, true, 2)
+	})
+
+	it("ignores finished builds", func() {
+		build := newBuild("some-namespace", "build", "COMMIT", 1)
+		finished := running(newBuild("some-namespace", "finished", "COMMIT", 10))
+		finished.Status.Conditions = corev1alpha1.Conditions{
+			{
+				Type:   corev1alpha1.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			},
+		}
+		q := queue(buildqueue.Limits{Global: 1}, build, finished)
+
+		assertAdmit(q, build, true, 1)
+	})
+}
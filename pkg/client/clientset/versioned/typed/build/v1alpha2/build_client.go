@@ -33,9 +33,11 @@ type KpackV1alpha2Interface interface {
 	BuildpacksGetter
 	ClusterBuildersGetter
 	ClusterBuildpacksGetter
+	ClusterExtensionsGetter
 	ClusterLifecyclesGetter
 	ClusterStacksGetter
 	ClusterStoresGetter
+	ExtensionsGetter
 	ImagesGetter
 	SourceResolversGetter
 }
@@ -65,6 +67,10 @@ func (c *KpackV1alpha2Client) ClusterBuildpacks() ClusterBuildpackInterface {
 	return newClusterBuildpacks(c)
 }
 
+func (c *KpackV1alpha2Client) ClusterExtensions() ClusterExtensionInterface {
+	return newClusterExtensions(c)
+}
+
 func (c *KpackV1alpha2Client) ClusterLifecycles() ClusterLifecycleInterface {
 	return newClusterLifecycles(c)
 }
@@ -77,6 +83,10 @@ func (c *KpackV1alpha2Client) ClusterStores() ClusterStoreInterface {
 	return newClusterStores(c)
 }
 
+func (c *KpackV1alpha2Client) Extensions(namespace string) ExtensionInterface {
+	return newExtensions(c, namespace)
+}
+
 func (c *KpackV1alpha2Client) Images(namespace string) ImageInterface {
 	return newImages(c, namespace)
 }
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	context "context"
+
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	scheme "github.com/pivotal/kpack/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterExtensionsGetter has a method to return a ClusterExtensionInterface.
+// A group's client should implement this interface.
+type ClusterExtensionsGetter interface {
+	ClusterExtensions() ClusterExtensionInterface
+}
+
+// ClusterExtensionInterface has methods to work with ClusterExtension resources.
+type ClusterExtensionInterface interface {
+	Create(ctx context.Context, clusterExtension *buildv1alpha2.ClusterExtension, opts v1.CreateOptions) (*buildv1alpha2.ClusterExtension, error)
+	Update(ctx context.Context, clusterExtension *buildv1alpha2.ClusterExtension, opts v1.UpdateOptions) (*buildv1alpha2.ClusterExtension, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterExtension *buildv1alpha2.ClusterExtension, opts v1.UpdateOptions) (*buildv1alpha2.ClusterExtension, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*buildv1alpha2.ClusterExtension, error)
+	List(ctx context.Context, opts v1.ListOptions) (*buildv1alpha2.ClusterExtensionList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *buildv1alpha2.ClusterExtension, err error)
+	ClusterExtensionExpansion
+}
+
+// clusterExtensions implements ClusterExtensionInterface
+type clusterExtensions struct {
+	*gentype.ClientWithList[*buildv1alpha2.ClusterExtension, *buildv1alpha2.ClusterExtensionList]
+}
+
+// newClusterExtensions returns a ClusterExtensions
+func newClusterExtensions(c *KpackV1alpha2Client) *clusterExtensions {
+	return &clusterExtensions{
+		gentype.NewClientWithList[*buildv1alpha2.ClusterExtension, *buildv1alpha2.ClusterExtensionList](
+			"clusterextensions",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *buildv1alpha2.ClusterExtension { return &buildv1alpha2.ClusterExtension{} },
+			func() *buildv1alpha2.ClusterExtensionList { return &buildv1alpha2.ClusterExtensionList{} },
+		),
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	context "context"
+
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	scheme "github.com/pivotal/kpack/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ExtensionsGetter has a method to return a ExtensionInterface.
+// A group's client should implement this interface.
+type ExtensionsGetter interface {
+	Extensions(namespace string) ExtensionInterface
+}
+
+// ExtensionInterface has methods to work with Extension resources.
+type ExtensionInterface interface {
+	Create(ctx context.Context, extension *buildv1alpha2.Extension, opts v1.CreateOptions) (*buildv1alpha2.Extension, error)
+	Update(ctx context.Context, extension *buildv1alpha2.Extension, opts v1.UpdateOptions) (*buildv1alpha2.Extension, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, extension *buildv1alpha2.Extension, opts v1.UpdateOptions) (*buildv1alpha2.Extension, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*buildv1alpha2.Extension, error)
+	List(ctx context.Context, opts v1.ListOptions) (*buildv1alpha2.ExtensionList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *buildv1alpha2.Extension, err error)
+	ExtensionExpansion
+}
+
+// extensions implements ExtensionInterface
+type extensions struct {
+	*gentype.ClientWithList[*buildv1alpha2.Extension, *buildv1alpha2.ExtensionList]
+}
+
+// newExtensions returns a Extensions
+func newExtensions(c *KpackV1alpha2Client, namespace string) *extensions {
+	return &extensions{
+		gentype.NewClientWithList[*buildv1alpha2.Extension, *buildv1alpha2.ExtensionList](
+			"extensions",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *buildv1alpha2.Extension { return &buildv1alpha2.Extension{} },
+			func() *buildv1alpha2.ExtensionList { return &buildv1alpha2.ExtensionList{} },
+		),
+	}
+}
@@ -48,6 +48,10 @@ func (c *FakeKpackV1alpha2) ClusterBuildpacks() v1alpha2.ClusterBuildpackInterfa
 	return newFakeClusterBuildpacks(c)
 }
 
+func (c *FakeKpackV1alpha2) ClusterExtensions() v1alpha2.ClusterExtensionInterface {
+	return newFakeClusterExtensions(c)
+}
+
 func (c *FakeKpackV1alpha2) ClusterLifecycles() v1alpha2.ClusterLifecycleInterface {
 	return newFakeClusterLifecycles(c)
 }
@@ -60,6 +64,10 @@ func (c *FakeKpackV1alpha2) ClusterStores() v1alpha2.ClusterStoreInterface {
 	return newFakeClusterStores(c)
 }
 
+func (c *FakeKpackV1alpha2) Extensions(namespace string) v1alpha2.ExtensionInterface {
+	return newFakeExtensions(c, namespace)
+}
+
 func (c *FakeKpackV1alpha2) Images(namespace string) v1alpha2.ImageInterface {
 	return newFakeImages(c, namespace)
 }
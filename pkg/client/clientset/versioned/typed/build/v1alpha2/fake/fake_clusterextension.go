@@ -0,0 +1,52 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/client/clientset/versioned/typed/build/v1alpha2"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterExtensions implements ClusterExtensionInterface
+type fakeClusterExtensions struct {
+	*gentype.FakeClientWithList[*v1alpha2.ClusterExtension, *v1alpha2.ClusterExtensionList]
+	Fake *FakeKpackV1alpha2
+}
+
+func newFakeClusterExtensions(fake *FakeKpackV1alpha2) buildv1alpha2.ClusterExtensionInterface {
+	return &fakeClusterExtensions{
+		gentype.NewFakeClientWithList[*v1alpha2.ClusterExtension, *v1alpha2.ClusterExtensionList](
+			fake.Fake,
+			"",
+			v1alpha2.SchemeGroupVersion.WithResource("clusterextensions"),
+			v1alpha2.SchemeGroupVersion.WithKind("ClusterExtension"),
+			func() *v1alpha2.ClusterExtension { return &v1alpha2.ClusterExtension{} },
+			func() *v1alpha2.ClusterExtensionList { return &v1alpha2.ClusterExtensionList{} },
+			func(dst, src *v1alpha2.ClusterExtensionList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha2.ClusterExtensionList) []*v1alpha2.ClusterExtension {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha2.ClusterExtensionList, items []*v1alpha2.ClusterExtension) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
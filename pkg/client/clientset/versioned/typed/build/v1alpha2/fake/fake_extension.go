@@ -0,0 +1,50 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/client/clientset/versioned/typed/build/v1alpha2"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeExtensions implements ExtensionInterface
+type fakeExtensions struct {
+	*gentype.FakeClientWithList[*v1alpha2.Extension, *v1alpha2.ExtensionList]
+	Fake *FakeKpackV1alpha2
+}
+
+func newFakeExtensions(fake *FakeKpackV1alpha2, namespace string) buildv1alpha2.ExtensionInterface {
+	return &fakeExtensions{
+		gentype.NewFakeClientWithList[*v1alpha2.Extension, *v1alpha2.ExtensionList](
+			fake.Fake,
+			namespace,
+			v1alpha2.SchemeGroupVersion.WithResource("extensions"),
+			v1alpha2.SchemeGroupVersion.WithKind("Extension"),
+			func() *v1alpha2.Extension { return &v1alpha2.Extension{} },
+			func() *v1alpha2.ExtensionList { return &v1alpha2.ExtensionList{} },
+			func(dst, src *v1alpha2.ExtensionList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha2.ExtensionList) []*v1alpha2.Extension { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1alpha2.ExtensionList, items []*v1alpha2.Extension) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -28,12 +28,16 @@ type ClusterBuilderExpansion interface{}
 
 type ClusterBuildpackExpansion interface{}
 
+type ClusterExtensionExpansion interface{}
+
 type ClusterLifecycleExpansion interface{}
 
 type ClusterStackExpansion interface{}
 
 type ClusterStoreExpansion interface{}
 
+type ExtensionExpansion interface{}
+
 type ImageExpansion interface{}
 
 type SourceResolverExpansion interface{}
@@ -34,12 +34,16 @@ type Interface interface {
 	ClusterBuilders() ClusterBuilderInformer
 	// ClusterBuildpacks returns a ClusterBuildpackInformer.
 	ClusterBuildpacks() ClusterBuildpackInformer
+	// ClusterExtensions returns a ClusterExtensionInformer.
+	ClusterExtensions() ClusterExtensionInformer
 	// ClusterLifecycles returns a ClusterLifecycleInformer.
 	ClusterLifecycles() ClusterLifecycleInformer
 	// ClusterStacks returns a ClusterStackInformer.
 	ClusterStacks() ClusterStackInformer
 	// ClusterStores returns a ClusterStoreInformer.
 	ClusterStores() ClusterStoreInformer
+	// Extensions returns a ExtensionInformer.
+	Extensions() ExtensionInformer
 	// Images returns a ImageInformer.
 	Images() ImageInformer
 	// SourceResolvers returns a SourceResolverInformer.
@@ -82,6 +86,11 @@ func (v *version) ClusterBuildpacks() ClusterBuildpackInformer {
 	return &clusterBuildpackInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterExtensions returns a ClusterExtensionInformer.
+func (v *version) ClusterExtensions() ClusterExtensionInformer {
+	return &clusterExtensionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterLifecycles returns a ClusterLifecycleInformer.
 func (v *version) ClusterLifecycles() ClusterLifecycleInformer {
 	return &clusterLifecycleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -97,6 +106,11 @@ func (v *version) ClusterStores() ClusterStoreInformer {
 	return &clusterStoreInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// Extensions returns a ExtensionInformer.
+func (v *version) Extensions() ExtensionInformer {
+	return &extensionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Images returns a ImageInformer.
 func (v *version) Images() ImageInformer {
 	return &imageInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
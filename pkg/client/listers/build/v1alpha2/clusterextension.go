@@ -0,0 +1,48 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterExtensionLister helps list ClusterExtensions.
+// All objects returned here must be treated as read-only.
+type ClusterExtensionLister interface {
+	// List lists all ClusterExtensions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*buildv1alpha2.ClusterExtension, err error)
+	// Get retrieves the ClusterExtension from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*buildv1alpha2.ClusterExtension, error)
+	ClusterExtensionListerExpansion
+}
+
+// clusterExtensionLister implements the ClusterExtensionLister interface.
+type clusterExtensionLister struct {
+	listers.ResourceIndexer[*buildv1alpha2.ClusterExtension]
+}
+
+// NewClusterExtensionLister returns a new ClusterExtensionLister.
+func NewClusterExtensionLister(indexer cache.Indexer) ClusterExtensionLister {
+	return &clusterExtensionLister{listers.New[*buildv1alpha2.ClusterExtension](indexer, buildv1alpha2.Resource("clusterextension"))}
+}
@@ -50,6 +50,10 @@ type ClusterBuilderListerExpansion interface{}
 // ClusterBuildpackLister.
 type ClusterBuildpackListerExpansion interface{}
 
+// ClusterExtensionListerExpansion allows custom methods to be added to
+// ClusterExtensionLister.
+type ClusterExtensionListerExpansion interface{}
+
 // ClusterLifecycleListerExpansion allows custom methods to be added to
 // ClusterLifecycleLister.
 type ClusterLifecycleListerExpansion interface{}
@@ -62,6 +66,14 @@ type ClusterStackListerExpansion interface{}
 // ClusterStoreLister.
 type ClusterStoreListerExpansion interface{}
 
+// ExtensionListerExpansion allows custom methods to be added to
+// ExtensionLister.
+type ExtensionListerExpansion interface{}
+
+// ExtensionNamespaceListerExpansion allows custom methods to be added to
+// ExtensionNamespaceLister.
+type ExtensionNamespaceListerExpansion interface{}
+
 // ImageListerExpansion allows custom methods to be added to
 // ImageLister.
 type ImageListerExpansion interface{}
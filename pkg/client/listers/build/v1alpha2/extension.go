@@ -0,0 +1,70 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ExtensionLister helps list Extensions.
+// All objects returned here must be treated as read-only.
+type ExtensionLister interface {
+	// List lists all Extensions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*buildv1alpha2.Extension, err error)
+	// Extensions returns an object that can list and get Extensions.
+	Extensions(namespace string) ExtensionNamespaceLister
+	ExtensionListerExpansion
+}
+
+// extensionLister implements the ExtensionLister interface.
+type extensionLister struct {
+	listers.ResourceIndexer[*buildv1alpha2.Extension]
+}
+
+// NewExtensionLister returns a new ExtensionLister.
+func NewExtensionLister(indexer cache.Indexer) ExtensionLister {
+	return &extensionLister{listers.New[*buildv1alpha2.Extension](indexer, buildv1alpha2.Resource("extension"))}
+}
+
+// Extensions returns an object that can list and get Extensions.
+func (s *extensionLister) Extensions(namespace string) ExtensionNamespaceLister {
+	return extensionNamespaceLister{listers.NewNamespaced[*buildv1alpha2.Extension](s.ResourceIndexer, namespace)}
+}
+
+// ExtensionNamespaceLister helps list and get Extensions.
+// All objects returned here must be treated as read-only.
+type ExtensionNamespaceLister interface {
+	// List lists all Extensions in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*buildv1alpha2.Extension, err error)
+	// Get retrieves the Extension from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*buildv1alpha2.Extension, error)
+	ExtensionNamespaceListerExpansion
+}
+
+// extensionNamespaceLister implements the ExtensionNamespaceLister
+// interface.
+type extensionNamespaceLister struct {
+	listers.ResourceIndexer[*buildv1alpha2.Extension]
+}
@@ -27,9 +27,12 @@ const (
 	platformDir                = "/platform"
 	platformEnvDir             = platformDir + "/env"
 	buildpacksDir              = "/cnb/buildpacks"
+	extensionsDir              = "/cnb/extensions"
 	orderTomlPath              = "/cnb/order.toml"
 	stackTomlPath              = "/cnb/stack.toml"
+	runTomlPath                = "/cnb/run.toml"
 	relaxedMixinMinPlatformAPI = "0.7"
+	extensionsMinPlatformAPI   = "0.10"
 )
 
 var (
@@ -44,11 +47,14 @@ type builderBlder struct {
 	stackId           string
 	order             []corev1alpha1.OrderEntry
 	buildpackLayers   map[DescriptiveBuildpackInfo]buildpackLayer
+	orderExtensions   []corev1alpha1.OrderEntry
+	extensionLayers   map[DescriptiveBuildpackInfo]buildpackLayer
 	cnbUserId         int
 	cnbGroupId        int
 	kpackVersion      string
 	runImage          string
 	mixins            []string
+	target            *corev1alpha1.BuildpackTarget
 	os                string
 	arch              string
 	archVariant       string
@@ -58,6 +64,7 @@ type builderBlder struct {
 func newBuilderBldr(kpackVersion string) *builderBlder {
 	return &builderBlder{
 		buildpackLayers: map[DescriptiveBuildpackInfo]buildpackLayer{},
+		extensionLayers: map[DescriptiveBuildpackInfo]buildpackLayer{},
 		kpackVersion:    kpackVersion,
 	}
 }
@@ -76,6 +83,14 @@ func (bb *builderBlder) AddStack(baseImage v1.Image, clusterStack *buildapi.Clus
 	bb.baseImage = baseImage
 	bb.stackId = clusterStack.Status.Id
 	bb.mixins = clusterStack.Status.Mixins
+	bb.target = &corev1alpha1.BuildpackTarget{
+		OS:          file.OS,
+		Arch:        file.Architecture,
+		ArchVariant: file.Variant,
+	}
+	if clusterStack.Status.Target != nil {
+		bb.target.Distros = clusterStack.Status.Target.Distros
+	}
 	bb.cnbUserId = clusterStack.Status.UserID
 	bb.cnbGroupId = clusterStack.Status.GroupID
 	return nil
@@ -98,14 +113,27 @@ func (bb *builderBlder) AddGroup(buildpacks ...RemoteBuildpackRef) {
 	bb.order = append(bb.order, corev1alpha1.OrderEntry{Group: group})
 }
 
+func (bb *builderBlder) AddExtensionGroup(extensions ...RemoteBuildpackRef) {
+	group := make([]corev1alpha1.BuildpackRef, 0, len(extensions))
+	for _, e := range extensions {
+		group = append(group, e.buildpackRef())
+
+		for _, layer := range e.Layers {
+			bb.extensionLayers[layer.BuildpackInfo] = layer
+		}
+	}
+	bb.orderExtensions = append(bb.orderExtensions, corev1alpha1.OrderEntry{Group: group})
+}
+
 func (bb *builderBlder) AddAdditionalLabels(additionalLabels map[string]string) {
 	bb.additionalLabels = additionalLabels
 }
 
 func (bb *builderBlder) WriteableImage() (v1.Image, error) {
 	buildpacks := bb.buildpacks()
+	extensions := bb.extensions()
 
-	err := bb.validateBuilder(buildpacks)
+	err := bb.validateBuilder(buildpacks, extensions)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +147,15 @@ func (bb *builderBlder) WriteableImage() (v1.Image, error) {
 		buildpackLayers = append(buildpackLayers, layer.v1Layer)
 	}
 
+	extensionLayerMetadata := BuildpackLayerMetadata{}
+	extensionLayers := make([]v1.Layer, 0, len(bb.extensionLayers))
+
+	for _, key := range extensions {
+		layer := bb.extensionLayers[key]
+		extensionLayerMetadata.add(layer)
+		extensionLayers = append(extensionLayers, layer.v1Layer)
+	}
+
 	defaultLayer, err := bb.defaultDirsLayer()
 	if err != nil {
 		return nil, err
@@ -129,6 +166,11 @@ func (bb *builderBlder) WriteableImage() (v1.Image, error) {
 		return nil, err
 	}
 
+	runLayer, err := bb.runLayer()
+	if err != nil {
+		return nil, err
+	}
+
 	orderLayer, err := bb.orderLayer()
 	if err != nil {
 		return nil, err
@@ -141,8 +183,10 @@ func (bb *builderBlder) WriteableImage() (v1.Image, error) {
 				bb.lifecycleLayer,
 			},
 			buildpackLayers,
+			extensionLayers,
 			[]v1.Layer{
 				stackLayer,
+				runLayer,
 				orderLayer,
 			},
 		))...)
@@ -165,6 +209,16 @@ func (bb *builderBlder) WriteableImage() (v1.Image, error) {
 		return nil, err
 	}
 
+	if len(extensions) > 0 {
+		image, err = imagehelpers.SetLabels(image, map[string]interface{}{
+			extensionOrderLabel:  bb.orderExtensions,
+			extensionLayersLabel: extensionLayerMetadata,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return imagehelpers.SetLabels(image, map[string]interface{}{
 		buildpackOrderLabel:  bb.order,
 		buildpackLayersLabel: buildpackLayerMetadata,
@@ -177,12 +231,17 @@ func (bb *builderBlder) WriteableImage() (v1.Image, error) {
 					Mirrors: nil,
 				},
 			},
+			RunImages: []RunImageMetadata{{
+				Image:   bb.runImage,
+				Mirrors: nil,
+			}},
 			Lifecycle: bb.LifecycleMetadata,
 			CreatedBy: CreatorMetadata{
 				Name:    "kpack Builder",
 				Version: bb.kpackVersion,
 			},
 			Buildpacks: buildpacks,
+			Extensions: extensions,
 		},
 	})
 }
@@ -191,7 +250,7 @@ func (bb *builderBlder) AddRunImage(runImage string) {
 	bb.runImage = runImage
 }
 
-func (bb *builderBlder) validateBuilder(sortedBuildpacks []DescriptiveBuildpackInfo) error {
+func (bb *builderBlder) validateBuilder(sortedBuildpacks, sortedExtensions []DescriptiveBuildpackInfo) error {
 	platformApis := append(bb.LifecycleMetadata.APIs.Platform.Deprecated, bb.LifecycleMetadata.APIs.Platform.Supported...)
 	err := validatePlatformApis(platformApis)
 	if err != nil {
@@ -201,11 +260,24 @@ func (bb *builderBlder) validateBuilder(sortedBuildpacks []DescriptiveBuildpackI
 	for _, bpInfo := range sortedBuildpacks {
 
 		bpLayerInfo := bb.buildpackLayers[bpInfo].BuildpackLayerInfo
-		err := bpLayerInfo.supports(buildpackApis, bb.stackId, bb.mixins, relaxedMixinContract(platformApis))
+		err := bpLayerInfo.supports(buildpackApis, bb.stackId, bb.mixins, relaxedMixinContract(platformApis), bb.target)
 		if err != nil {
 			return errors.Wrapf(err, "validating buildpack %s", bpInfo)
 		}
 	}
+
+	if len(sortedExtensions) == 0 {
+		return nil
+	}
+	if !supportsExtensions(platformApis) {
+		return errors.Errorf("image extensions require platform api %s or higher, lifecycle supports: %s", extensionsMinPlatformAPI, strings.Join(platformApis, ", "))
+	}
+	for _, extInfo := range sortedExtensions {
+		api := bb.extensionLayers[extInfo].BuildpackLayerInfo.API
+		if !present(buildpackApis, api) {
+			return errors.Errorf("validating extension %s: unsupported buildpack api: %s, expecting: %s", extInfo, api, strings.Join(buildpackApis, ", "))
+		}
+	}
 	return nil
 }
 
@@ -228,10 +300,23 @@ func relaxedMixinContract(builderSupportedApis []string) bool {
 	return false
 }
 
+func supportsExtensions(builderSupportedApis []string) bool {
+	for _, api := range builderSupportedApis {
+		if semver.MustParse(api).Compare(semver.MustParse(extensionsMinPlatformAPI)) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (bb *builderBlder) buildpacks() []DescriptiveBuildpackInfo {
 	return deterministicSortBySize(bb.buildpackLayers)
 }
 
+func (bb *builderBlder) extensions() []DescriptiveBuildpackInfo {
+	return deterministicSortBySize(bb.extensionLayers)
+}
+
 func (bb *builderBlder) stackLayer() (v1.Layer, error) {
 	type tomlRunImage struct {
 		Image string `toml:"image"`
@@ -254,6 +339,26 @@ func (bb *builderBlder) stackLayer() (v1.Layer, error) {
 	return bb.singeFileLayer(stackTomlPath, stackBuf.Bytes())
 }
 
+// runLayer references the run image in the run.toml read by platform api 0.12+ in place of the stack.toml
+func (bb *builderBlder) runLayer() (v1.Layer, error) {
+	type tomlRunImage struct {
+		Image string `toml:"image"`
+	}
+
+	type tomlRunFile struct {
+		Images []tomlRunImage `toml:"images"`
+	}
+
+	runBuf := &bytes.Buffer{}
+	err := toml.NewEncoder(runBuf).Encode(tomlRunFile{
+		Images: []tomlRunImage{{Image: bb.runImage}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bb.singeFileLayer(runTomlPath, runBuf.Bytes())
+}
+
 func (bb *builderBlder) orderLayer() (v1.Layer, error) {
 	type tomlBuildpack struct {
 		ID       string `toml:"id"`
@@ -268,25 +373,32 @@ func (bb *builderBlder) orderLayer() (v1.Layer, error) {
 	type tomlOrder []tomlOrderEntry
 
 	type tomlOrderFile struct {
-		Order tomlOrder `toml:"order"`
+		Order           tomlOrder `toml:"order"`
+		OrderExtensions tomlOrder `toml:"order-extensions,omitempty"`
+	}
+
+	toTomlOrder := func(entries []corev1alpha1.OrderEntry) tomlOrder {
+		order := make(tomlOrder, 0, len(entries))
+		for _, o := range entries {
+			bps := make([]tomlBuildpack, 0, len(o.Group))
+			for _, b := range o.Group {
+				bps = append(bps, tomlBuildpack{
+					ID:       b.Id,
+					Version:  b.Version,
+					Optional: b.Optional,
+				})
+			}
+			order = append(order, tomlOrderEntry{Group: bps})
+		}
+		return order
 	}
 
 	orderBuf := &bytes.Buffer{}
 
-	order := make(tomlOrder, 0, len(bb.order))
-	for _, o := range bb.order {
-		bps := make([]tomlBuildpack, 0, len(o.Group))
-		for _, b := range o.Group {
-			bps = append(bps, tomlBuildpack{
-				ID:       b.Id,
-				Version:  b.Version,
-				Optional: b.Optional,
-			})
-		}
-		order = append(order, tomlOrderEntry{Group: bps})
-	}
-
-	err := toml.NewEncoder(orderBuf).Encode(tomlOrderFile{order})
+	err := toml.NewEncoder(orderBuf).Encode(tomlOrderFile{
+		Order:           toTomlOrder(bb.order),
+		OrderExtensions: toTomlOrder(bb.orderExtensions),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -325,6 +437,9 @@ func (bb *builderBlder) defaultDirsLayer() (v1.Layer, error) {
 		bb.rootOwnedDir(platformDir),
 		bb.rootOwnedDir(platformEnvDir),
 	}
+	if len(bb.extensionLayers) > 0 {
+		dirs = append(dirs, bb.rootOwnedDir(extensionsDir))
+	}
 
 	b := &bytes.Buffer{}
 	tw := bb.layerWriter(b)
@@ -10,14 +10,17 @@ const (
 	buildpackMetadataLabel = "io.buildpacks.builder.metadata"
 	lifecycleVersionLabel  = "io.buildpacks.lifecycle.version"
 	lifecycleApisLabel     = "io.buildpacks.lifecycle.apis"
+	extensionOrderLabel    = "io.buildpacks.buildpack.order-extensions"
+	extensionLayersLabel   = "io.buildpacks.extension.layers"
 )
 
 type BuildpackLayerInfo struct {
-	API         string                        `json:"api"`
-	LayerDiffID string                        `json:"layerDiffID"`
-	Order       corev1alpha1.Order            `json:"order,omitempty"`
-	Stacks      []corev1alpha1.BuildpackStack `json:"stacks,omitempty"`
-	Homepage    string                        `json:"homepage,omitempty"`
+	API         string                         `json:"api"`
+	LayerDiffID string                         `json:"layerDiffID"`
+	Order       corev1alpha1.Order             `json:"order,omitempty"`
+	Stacks      []corev1alpha1.BuildpackStack  `json:"stacks,omitempty"`
+	Targets     []corev1alpha1.BuildpackTarget `json:"targets,omitempty"`
+	Homepage    string                         `json:"homepage,omitempty"`
 }
 
 type DescriptiveBuildpackInfo struct {
@@ -33,9 +36,11 @@ type Stack struct {
 type BuilderImageMetadata struct {
 	Description string                     `json:"description"`
 	Stack       StackMetadata              `json:"stack"`
+	RunImages   []RunImageMetadata         `json:"images,omitempty"`
 	Lifecycle   LifecycleMetadata          `json:"lifecycle"`
 	CreatedBy   CreatorMetadata            `json:"createdBy"`
 	Buildpacks  []DescriptiveBuildpackInfo `json:"buildpacks"`
+	Extensions  []DescriptiveBuildpackInfo `json:"extensions,omitempty"`
 }
 
 type StackMetadata struct {
@@ -5,11 +5,13 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
 var anyStackMinimumVersion = semver.MustParse("0.5")
 
-func (bl BuildpackLayerInfo) supports(buildpackApis []string, id string, mixins []string, relaxedMixinContract bool) error {
+func (bl BuildpackLayerInfo) supports(buildpackApis []string, id string, mixins []string, relaxedMixinContract bool, target *corev1alpha1.BuildpackTarget) error {
 	if len(bl.Order) != 0 {
 		return nil //ignore meta-buildpacks
 	}
@@ -18,9 +20,9 @@ func (bl BuildpackLayerInfo) supports(buildpackApis []string, id string, mixins
 		return errors.Errorf("unsupported buildpack api: %s, expecting: %s", bl.API, strings.Join(buildpackApis, ", "))
 	}
 
-	// as of buildpack API 0.10+ stacks are optional (and deprecated)
-	if len(bl.Stacks) == 0 {
-		return nil
+	// as of buildpack API 0.10+ stacks are optional (and deprecated) in favor of targets
+	if id == "" || len(bl.Stacks) == 0 {
+		return bl.supportsTarget(target)
 	}
 
 	for _, s := range bl.Stacks {
@@ -36,6 +38,60 @@ func (bl BuildpackLayerInfo) supports(buildpackApis []string, id string, mixins
 	return errors.Errorf("stack %s is not supported", id)
 }
 
+func (bl BuildpackLayerInfo) supportsTarget(target *corev1alpha1.BuildpackTarget) error {
+	if len(bl.Targets) == 0 || target == nil {
+		return nil
+	}
+
+	for _, t := range bl.Targets {
+		if targetMatches(t, target) {
+			return nil
+		}
+	}
+	return errors.Errorf("target %s is not supported", targetString(target))
+}
+
+func targetMatches(t corev1alpha1.BuildpackTarget, target *corev1alpha1.BuildpackTarget) bool {
+	if !matchesField(t.OS, target.OS) || !matchesField(t.Arch, target.Arch) || !matchesField(t.ArchVariant, target.ArchVariant) {
+		return false
+	}
+
+	if len(t.Distros) == 0 || len(target.Distros) == 0 {
+		return true
+	}
+
+	for _, d := range t.Distros {
+		for _, provided := range target.Distros {
+			if d.Name == provided.Name && matchesField(d.Version, provided.Version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesField matches an empty or wildcard field of a buildpack target to any value
+func matchesField(required, provided string) bool {
+	return required == "" || required == "*" || provided == "" || required == provided
+}
+
+func targetString(target *corev1alpha1.BuildpackTarget) string {
+	s := target.OS
+	if target.Arch != "" {
+		s += "/" + target.Arch
+	}
+	if target.ArchVariant != "" {
+		s += "/" + target.ArchVariant
+	}
+	for _, d := range target.Distros {
+		s += " " + d.Name
+		if d.Version != "" {
+			s += "@" + d.Version
+		}
+	}
+	return s
+}
+
 func validateRequiredMixins(providedMixins, requiredMixins []string, relaxedMixinContract bool) error {
 	var missing []string
 	for _, rm := range requiredMixins {
@@ -16,7 +16,10 @@ import (
 
 type RegistryClient interface {
 	Fetch(keychain authn.Keychain, repoName string) (ggcrv1.Image, string, error)
+	FetchIndexed(keychain authn.Keychain, repoName string) (ggcrv1.Image, string, string, error)
+	FetchPlatform(keychain authn.Keychain, repoName string, platform string) (ggcrv1.Image, string, error)
 	Save(keychain authn.Keychain, tag string, image ggcrv1.Image) (string, error)
+	SaveIndex(keychain authn.Keychain, tag string, index ggcrv1.ImageIndex) (string, error)
 }
 
 type RemoteBuilderCreator struct {
@@ -37,6 +40,10 @@ func (r *RemoteBuilderCreator) CreateBuilder(
 	serviceAccountSecrets []*corev1.Secret,
 	resolvedBuilderRef string,
 ) (buildapi.BuilderRecord, error) {
+	if len(spec.Platforms) > 0 {
+		return r.createPlatformsBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, clusterStack, clusterLifecycle, spec, serviceAccountSecrets, resolvedBuilderRef)
+	}
+
 	buildImage, _, err := r.RegistryClient.Fetch(stackKeychain, clusterStack.Status.BuildImage.LatestImage)
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
@@ -50,42 +57,12 @@ func (r *RemoteBuilderCreator) CreateBuilder(
 		return buildapi.BuilderRecord{}, err
 	}
 
-	builderBldr := newBuilderBldr(r.KpackVersion)
-
 	relocatedRunImage, err := r.RegistryClient.Save(builderKeychain, fmt.Sprintf("%s-run-image", resolvedBuilderRef), runImage)
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
 	}
-	builderBldr.AddRunImage(relocatedRunImage)
-
-	err = builderBldr.AddStack(buildImage, clusterStack)
-	if err != nil {
-		return buildapi.BuilderRecord{}, err
-	}
 
-	lifecycleLayer, lifecycleMetadata, err := getLifecycleLayer(clusterLifecycle, lifecycleImage, builderBldr)
-	if err != nil {
-		return buildapi.BuilderRecord{}, err
-	}
-	builderBldr.AddLifecycle(lifecycleLayer, lifecycleMetadata)
-
-	for _, group := range spec.Order {
-		buildpacks := make([]RemoteBuildpackRef, 0, len(group.Group))
-
-		for _, buildpack := range group.Group {
-			remoteBuildpack, err := fetcher.ResolveAndFetch(ctx, buildpack)
-			if err != nil {
-				return buildapi.BuilderRecord{}, err
-			}
-
-			buildpacks = append(buildpacks, remoteBuildpack.Optional(buildpack.Optional))
-		}
-		builderBldr.AddGroup(buildpacks...)
-	}
-
-	builderBldr.AddAdditionalLabels(spec.AdditionalLabels)
-
-	writeableImage, err := builderBldr.WriteableImage()
+	builderBldr, writeableImage, err := r.builderImage(ctx, fetcher, clusterStack, clusterLifecycle, spec, buildImage, lifecycleImage, relocatedRunImage)
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
 	}
@@ -124,6 +101,8 @@ func (r *RemoteBuilderCreator) CreateBuilder(
 		},
 		Buildpacks:              buildpackMetadata(builderBldr.buildpacks()),
 		Order:                   builderBldr.order,
+		Extensions:              extensionMetadata(builderBldr.extensions()),
+		OrderExtensions:         builderBldr.orderExtensions,
 		ObservedStackGeneration: clusterStack.Status.ObservedGeneration,
 		ObservedStoreGeneration: fetcher.ClusterStoreObservedGeneration(),
 		OS:                      config.OS,
@@ -133,6 +112,69 @@ func (r *RemoteBuilderCreator) CreateBuilder(
 	return builder, nil
 }
 
+// builderImage creates the builder image from the stack build image and the lifecycle image, adding the buildpacks
+// and extensions of the spec order.
+func (r *RemoteBuilderCreator) builderImage(
+	ctx context.Context,
+	fetcher RemoteBuildpackFetcher,
+	clusterStack *buildapi.ClusterStack,
+	clusterLifecycle *buildapi.ClusterLifecycle,
+	spec buildapi.BuilderSpec,
+	buildImage ggcrv1.Image,
+	lifecycleImage ggcrv1.Image,
+	relocatedRunImage string,
+) (*builderBlder, ggcrv1.Image, error) {
+	builderBldr := newBuilderBldr(r.KpackVersion)
+	builderBldr.AddRunImage(relocatedRunImage)
+
+	err := builderBldr.AddStack(buildImage, clusterStack)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lifecycleLayer, lifecycleMetadata, err := getLifecycleLayer(clusterLifecycle, lifecycleImage, builderBldr)
+	if err != nil {
+		return nil, nil, err
+	}
+	builderBldr.AddLifecycle(lifecycleLayer, lifecycleMetadata)
+
+	for _, group := range spec.Order {
+		buildpacks := make([]RemoteBuildpackRef, 0, len(group.Group))
+
+		for _, buildpack := range group.Group {
+			remoteBuildpack, err := fetcher.ResolveAndFetch(ctx, buildpack)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			buildpacks = append(buildpacks, remoteBuildpack.Optional(buildpack.Optional))
+		}
+		builderBldr.AddGroup(buildpacks...)
+	}
+
+	for _, group := range spec.OrderExtensions {
+		extensions := make([]RemoteBuildpackRef, 0, len(group.Group))
+
+		for _, extension := range group.Group {
+			remoteExtension, err := fetcher.ResolveAndFetchExtension(ctx, extension)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			extensions = append(extensions, remoteExtension.Optional(extension.Optional))
+		}
+		builderBldr.AddExtensionGroup(extensions...)
+	}
+
+	builderBldr.AddAdditionalLabels(spec.AdditionalLabels)
+
+	writeableImage, err := builderBldr.WriteableImage()
+	if err != nil {
+		return nil, nil, err
+	}
+	return builderBldr, writeableImage, nil
+}
+
 func getLifecycleLayer(clusterLifecycle *buildapi.ClusterLifecycle, lifecycleImage ggcrv1.Image, builderBlder *builderBlder) (lifecycleLayer ggcrv1.Layer, lifecycleMetadata LifecycleMetadata, err error) {
 	lifecycleMetadata = LifecycleMetadata{
 		LifecycleInfo: LifecycleInfo{
@@ -215,6 +257,13 @@ func toCNBAPISet(from buildapi.APISet) APISet {
 	return to
 }
 
+func extensionMetadata(extensions []DescriptiveBuildpackInfo) corev1alpha1.BuildpackMetadataList {
+	if len(extensions) == 0 {
+		return nil
+	}
+	return buildpackMetadata(extensions)
+}
+
 func buildpackMetadata(buildpacks []DescriptiveBuildpackInfo) corev1alpha1.BuildpackMetadataList {
 	m := make(corev1alpha1.BuildpackMetadataList, 0, len(buildpacks))
 	for _, b := range buildpacks {
@@ -55,7 +55,7 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 		runImageRef       = fmt.Sprintf("%s@%s", runImageTag, runImageDigest)
 		ctx               = context.Background()
 
-		fetcher = &fakeFetcher{buildpacks: map[string][]buildpackLayer{}, observedGeneration: 10}
+		fetcher = &fakeFetcher{buildpacks: map[string][]buildpackLayer{}, extensions: map[string][]buildpackLayer{}, observedGeneration: 10}
 
 		buildpack1Layer = &fakeLayer{
 			digest: "sha256:1bd8899667b8d1e6b124f663faca32903b470831e5e4e99265c839ab34628838",
@@ -413,12 +413,14 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			buildpackLayerCount := 3
 			defaultDirectoryLayerCount := 1
 			stackTomlLayerCount := 1
+			runTomlLayerCount := 1
 			orderTomlLayerCount := 1
 			assert.Len(t, layers,
 				buildImageLayers+
 					defaultDirectoryLayerCount+
 					lifecycleImageLayers+
 					stackTomlLayerCount+
+					runTomlLayerCount+
 					buildpackLayerCount+
 					orderTomlLayerCount)
 
@@ -505,6 +507,19 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			layerTester.testNextLayer("run Layer", func(index int) {
+				assertLayerContents(t, layers[index], map[string]content{
+					"/cnb/run.toml": {
+						typeflag: tar.TypeReg,
+						mode:     0644,
+						fileContent: //language=toml
+						fmt.Sprintf(`[[images]]
+  image = "%s@%s"
+`, relocatedRunImageTag, runImageDigest),
+					},
+				})
+			})
+
 			layerTester.testNextLayer("order Layer", func(index int) {
 				assert.Equal(t, len(layers)-1, index)
 
@@ -543,10 +558,16 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
   "description": "Custom Builder built with kpack",
   "stack": {
     "runImage": {
-      "image": "%s@%s",
+      "image": "%[1]s@%[2]s",
       "mirrors": null
     }
   },
+  "images": [
+    {
+      "image": "%[1]s@%[2]s",
+      "mirrors": null
+    }
+  ],
   "lifecycle": {
     "version": "0.5.0",
     "api": {
@@ -677,6 +698,178 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 
+		when("image extensions are in the order", func() {
+			extensionLayer := &fakeLayer{
+				digest: "sha256:5bd8899667b8d1e6b124f663faca32903b470831e5e4e99265c839ab34628838",
+				diffID: "sha256:5bf8899667b8d1e6b124f663faca32903b470831e5e4e992644ac5c839ab3462",
+				size:   50,
+			}
+
+			it.Before(func() {
+				fetcher.AddExtension(t, "io.extension.curl", "v1", []buildpackLayer{{
+					v1Layer: extensionLayer,
+					BuildpackInfo: DescriptiveBuildpackInfo{
+						BuildpackInfo: corev1alpha1.BuildpackInfo{
+							Id:      "io.extension.curl",
+							Version: "v1",
+						},
+						Homepage: "extension.curl.com",
+					},
+					BuildpackLayerInfo: BuildpackLayerInfo{
+						API:         "0.3",
+						LayerDiffID: extensionLayer.diffID,
+						Homepage:    "extension.curl.com",
+					},
+				}})
+
+				clusterBuilderSpec.OrderExtensions = []buildapi.BuilderOrderEntry{{
+					Group: []buildapi.BuilderBuildpackRef{{
+						BuildpackRef: corev1alpha1.BuildpackRef{
+							BuildpackInfo: corev1alpha1.BuildpackInfo{
+								Id:      "io.extension.curl",
+								Version: "v1",
+							},
+							Optional: true,
+						},
+					}},
+				}}
+
+				clusterLifecycle.Status.ResolvedClusterLifecycle.APIs.Platform.Supported = []string{"0.4", "0.10"}
+			})
+
+			it("adds the extensions and the order extensions to the builder", func() {
+				builderRecord, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+				require.NoError(t, err)
+
+				assert.Equal(t, corev1alpha1.BuildpackMetadataList{{Id: "io.extension.curl", Version: "v1", Homepage: "extension.curl.com"}}, builderRecord.Extensions)
+				assert.Equal(t, []corev1alpha1.OrderEntry{{
+					Group: []corev1alpha1.BuildpackRef{{
+						BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "io.extension.curl", Version: "v1"},
+						Optional:      true,
+					}},
+				}}, builderRecord.OrderExtensions)
+
+				savedImage := registryClient.SavedImages()[builderTag]
+				layers, err := savedImage.Layers()
+				require.NoError(t, err)
+				assert.Contains(t, layers, extensionLayer)
+
+				assertLayerContents(t, layers[buildImageLayers], map[string]content{
+					"/workspace": {
+						typeflag: tar.TypeDir,
+						mode:     0755,
+						uid:      cnbUserId,
+						gid:      cnbGroupId,
+					},
+					"/layers": {
+						typeflag: tar.TypeDir,
+						mode:     0755,
+						uid:      cnbUserId,
+						gid:      cnbGroupId,
+					},
+					"/cnb": {
+						typeflag: tar.TypeDir,
+						mode:     0755,
+					},
+					"/cnb/buildpacks": {
+						typeflag: tar.TypeDir,
+						mode:     0755,
+					},
+					"/cnb/extensions": {
+						typeflag: tar.TypeDir,
+						mode:     0755,
+					},
+					"/platform": {
+						typeflag: tar.TypeDir,
+						mode:     0755,
+					},
+					"/platform/env": {
+						typeflag: tar.TypeDir,
+						mode:     0755,
+					},
+				})
+
+				assertLayerContents(t, layers[len(layers)-1], map[string]content{
+					"/cnb/order.toml": {
+						typeflag: tar.TypeReg,
+						mode:     0644,
+						fileContent: //language=toml
+						`[[order]]
+
+  [[order.group]]
+    id = "io.buildpack.1"
+    version = "v1"
+
+  [[order.group]]
+    id = "io.buildpack.2"
+    version = "v2"
+    optional = true
+
+  [[order.group]]
+    id = "io.buildpack.4"
+    version = "v4"
+
+[[order-extensions]]
+
+  [[order-extensions.group]]
+    id = "io.extension.curl"
+    version = "v1"
+    optional = true
+`}})
+
+				extensionOrder, err := imagehelpers.GetStringLabel(savedImage, extensionOrderLabel)
+				require.NoError(t, err)
+				assert.JSONEq(t, //language=json
+					`[{"group":[{"id":"io.extension.curl","version":"v1","optional":true}]}]`, extensionOrder)
+
+				extensionLayers, err := imagehelpers.GetStringLabel(savedImage, extensionLayersLabel)
+				require.NoError(t, err)
+				assert.JSONEq(t, //language=json
+					`{
+  "io.extension.curl": {
+    "v1": {
+      "api": "0.3",
+      "layerDiffID": "sha256:5bf8899667b8d1e6b124f663faca32903b470831e5e4e992644ac5c839ab3462",
+      "homepage": "extension.curl.com"
+    }
+  }
+}`, extensionLayers)
+
+				var metadata BuilderImageMetadata
+				require.NoError(t, imagehelpers.GetLabel(savedImage, buildpackMetadataLabel, &metadata))
+				assert.Equal(t, []DescriptiveBuildpackInfo{{
+					BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "io.extension.curl", Version: "v1"},
+					Homepage:      "extension.curl.com",
+				}}, metadata.Extensions)
+			})
+
+			it("errors when the lifecycle does not support image extensions", func() {
+				clusterLifecycle.Status.ResolvedClusterLifecycle.APIs.Platform.Supported = []string{"0.4", "0.9"}
+
+				_, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+				require.EqualError(t, err, "image extensions require platform api 0.10 or higher, lifecycle supports: 0.3, 0.4, 0.9")
+			})
+
+			it("errors with an unsupported extension api", func() {
+				fetcher.AddExtension(t, "io.extension.curl", "v1", []buildpackLayer{{
+					v1Layer: extensionLayer,
+					BuildpackInfo: DescriptiveBuildpackInfo{
+						BuildpackInfo: corev1alpha1.BuildpackInfo{
+							Id:      "io.extension.curl",
+							Version: "v1",
+						},
+					},
+					BuildpackLayerInfo: BuildpackLayerInfo{
+						API:         "0.9",
+						LayerDiffID: extensionLayer.diffID,
+					},
+				}})
+
+				_, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+				require.EqualError(t, err, "validating extension io.extension.curl@v1: unsupported buildpack api: 0.9, expecting: 0.2, 0.3")
+			})
+		})
+
 		when("validating buildpacks", func() {
 			it("errors with unsupported stack", func() {
 				addBuildpack(t, "io.buildpack.unsupported.stack", "v4", "buildpack.4.com", "0.2",
@@ -886,6 +1079,76 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 				_, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
 				require.NoError(t, err)
 			})
+
+			when("the stack has no id", func() {
+				addTargetBuildpack := func(targets []corev1alpha1.BuildpackTarget) {
+					fetcher.AddBuildpack(t, "io.buildpack.targets", "v1", []buildpackLayer{{
+						v1Layer: buildpack1Layer,
+						BuildpackInfo: DescriptiveBuildpackInfo{
+							BuildpackInfo: corev1alpha1.BuildpackInfo{
+								Id:      "io.buildpack.targets",
+								Version: "v1",
+							},
+						},
+						BuildpackLayerInfo: BuildpackLayerInfo{
+							API:         "0.3",
+							LayerDiffID: buildpack1Layer.diffID,
+							Stacks:      []corev1alpha1.BuildpackStack{{ID: "io.buildpacks.stacks.unsupported"}},
+							Targets:     targets,
+						},
+					}})
+
+					clusterBuilderSpec.Order = []buildapi.BuilderOrderEntry{{
+						Group: []buildapi.BuilderBuildpackRef{{
+							BuildpackRef: corev1alpha1.BuildpackRef{
+								BuildpackInfo: corev1alpha1.BuildpackInfo{
+									Id:      "io.buildpack.targets",
+									Version: "v1",
+								},
+							},
+						}},
+					}}
+				}
+
+				it.Before(func() {
+					stack.Status.Id = ""
+					stack.Status.Target = &corev1alpha1.BuildpackTarget{
+						OS:      "linux",
+						Arch:    "amd64",
+						Distros: []corev1alpha1.BuildpackDistro{{Name: "ubuntu", Version: "22.04"}},
+					}
+				})
+
+				it("validates buildpacks by their targets instead of their stacks", func() {
+					addTargetBuildpack([]corev1alpha1.BuildpackTarget{
+						{OS: "windows"},
+						{OS: "linux", Distros: []corev1alpha1.BuildpackDistro{{Name: "ubuntu", Version: "22.04"}}},
+					})
+
+					builderRecord, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+					require.NoError(t, err)
+					assert.Equal(t, "", builderRecord.Stack.ID)
+				})
+
+				it("matches every distro of the run image target", func() {
+					stack.Status.Target.Distros = []corev1alpha1.BuildpackDistro{{Name: "ubuntu", Version: "22.04"}, {Name: "debian", Version: "12"}}
+					addTargetBuildpack([]corev1alpha1.BuildpackTarget{
+						{OS: "linux", Distros: []corev1alpha1.BuildpackDistro{{Name: "debian", Version: "12"}}},
+					})
+
+					_, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+					require.NoError(t, err)
+				})
+
+				it("errors with an unsupported target", func() {
+					addTargetBuildpack([]corev1alpha1.BuildpackTarget{
+						{OS: "linux", Distros: []corev1alpha1.BuildpackDistro{{Name: "ubuntu", Version: "20.04"}}},
+					})
+
+					_, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+					require.EqualError(t, err, "validating buildpack io.buildpack.targets@v1: target linux ubuntu@22.04 is not supported")
+				})
+			})
 		})
 
 		when("validating platform api", func() {
@@ -906,6 +1169,135 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the builder has platforms", func() {
+			var (
+				platformBuildImages     = map[string]v1.Image{}
+				platformRunImages       = map[string]v1.Image{}
+				platformLifecycleImages = map[string]v1.Image{}
+			)
+
+			platformImage := func(layers int, arch string) v1.Image {
+				image, err := random.Image(1, int64(layers))
+				require.NoError(t, err)
+
+				config, err := image.ConfigFile()
+				require.NoError(t, err)
+				config.OS = "linux"
+				config.Architecture = arch
+				image, err = mutate.ConfigFile(image, config)
+				require.NoError(t, err)
+				return image
+			}
+
+			const (
+				buildImageIndex     = "paketo-buildpacks/build@sha256:4c3c9b2b4ce5a1df5e2a8b5cd6bf0a1a3d8ec5c0cd2a8e1d74f6f8d5c3b2a190"
+				runImageIndex       = "paketo-buildpacks/run@sha256:7f1e7b6e5c8a1b2d3c4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4"
+				lifecycleImageIndex = "buildpacksio/lifecycle@sha256:0a9b8c7d6e5f40312a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091"
+			)
+
+			it.Before(func() {
+				clusterBuilderSpec.Platforms = []string{"linux/amd64", "linux/arm64"}
+
+				stack.Status.BuildImage.IndexImage = buildImageIndex
+				stack.Status.RunImage.IndexImage = runImageIndex
+				clusterLifecycle.Status.Image.IndexImage = lifecycleImageIndex
+
+				for _, arch := range []string{"amd64", "arm64"} {
+					platform := "linux/" + arch
+					platformBuildImages[platform] = platformImage(buildImageLayers, arch)
+					platformRunImages[platform] = platformImage(5, arch)
+					platformLifecycleImages[platform] = platformImage(lifecycleImageLayers, arch)
+
+					registryClient.AddPlatformImage(buildImageIndex, platform, platformBuildImages[platform], stackKeychain)
+					registryClient.AddPlatformImage(runImageIndex, platform, platformRunImages[platform], stackKeychain)
+					registryClient.AddPlatformImage(lifecycleImageIndex, platform, platformLifecycleImages[platform], lifecycleKeychain)
+				}
+				registryClient.AddSaveKeychain(relocatedRunImageTag, builderKeychain)
+			})
+
+			it("creates a builder image for every platform and pushes the builder and run image indexes", func() {
+				builderRecord, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+				require.NoError(t, err)
+
+				assert.Len(t, builderRecord.Buildpacks, 4)
+				assert.Equal(t, "linux", builderRecord.OS)
+				assert.Empty(t, registryClient.SavedImages())
+
+				builderIndex := registryClient.SavedIndexes()[builderTag]
+				require.NotNil(t, builderIndex)
+				assert.Equal(t, fmt.Sprintf("%s@%s", builderTag, indexDigest(builderIndex)), builderRecord.Image)
+
+				runImageIndex := registryClient.SavedIndexes()[relocatedRunImageTag]
+				require.NotNil(t, runImageIndex)
+				assert.Equal(t, corev1alpha1.BuildStack{RunImage: fmt.Sprintf("%s@%s", relocatedRunImageTag, indexDigest(runImageIndex)), ID: stackID}, builderRecord.Stack)
+
+				builderManifest, err := builderIndex.IndexManifest()
+				require.NoError(t, err)
+				require.Len(t, builderManifest.Manifests, 2)
+
+				runImageManifest, err := runImageIndex.IndexManifest()
+				require.NoError(t, err)
+				require.Len(t, runImageManifest.Manifests, 2)
+
+				require.Len(t, builderRecord.PlatformImages, 2)
+				for i, platform := range []string{"linux/amd64", "linux/arm64"} {
+					runImageDigest := digest(platformRunImages[platform])
+					assert.Equal(t, buildapi.BuilderPlatformImage{
+						Platform: platform,
+						Image:    fmt.Sprintf("%s@%s", builderTag, builderManifest.Manifests[i].Digest),
+						RunImage: fmt.Sprintf("%s@%s", relocatedRunImageTag, runImageDigest),
+					}, builderRecord.PlatformImages[i])
+
+					assert.Equal(t, platform, builderManifest.Manifests[i].Platform.String())
+					assert.Equal(t, platform, runImageManifest.Manifests[i].Platform.String())
+					assert.Equal(t, runImageDigest, runImageManifest.Manifests[i].Digest.String())
+
+					platformBuilder, err := builderIndex.Image(builderManifest.Manifests[i].Digest)
+					require.NoError(t, err)
+
+					metadata := BuilderImageMetadata{}
+					require.NoError(t, imagehelpers.GetLabel(platformBuilder, buildpackMetadataLabel, &metadata))
+					assert.Equal(t, fmt.Sprintf("%s@%s", relocatedRunImageTag, runImageDigest), metadata.Stack.RunImage.Image)
+
+					layers, err := platformBuilder.Layers()
+					require.NoError(t, err)
+					buildImageLayers, err := platformBuildImages[platform].Layers()
+					require.NoError(t, err)
+					assert.Equal(t, buildImageLayers[0], layers[0])
+				}
+			})
+
+			it("errors when an image does not support a platform", func() {
+				clusterBuilderSpec.Platforms = []string{"linux/amd64", "linux/ppc64le"}
+				registryClient.AddImage(buildImageIndex, platformBuildImages["linux/amd64"], stackKeychain)
+
+				_, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+				require.EqualError(t, err, "image "+buildImageIndex+" does not support platform linux/ppc64le")
+			})
+
+			it("fetches the platform images from the resolved image indexes instead of the tags", func() {
+				registryClient.AddPlatformImage(buildImageTag, "linux/arm64", platformImage(buildImageLayers, "arm64"), stackKeychain)
+
+				builderRecord, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
+				require.NoError(t, err)
+
+				builderIndex := registryClient.SavedIndexes()[builderTag]
+				require.NotNil(t, builderIndex)
+				builderManifest, err := builderIndex.IndexManifest()
+				require.NoError(t, err)
+				require.Len(t, builderManifest.Manifests, 2)
+				require.Len(t, builderRecord.PlatformImages, 2)
+
+				platformBuilder, err := builderIndex.Image(builderManifest.Manifests[1].Digest)
+				require.NoError(t, err)
+				layers, err := platformBuilder.Layers()
+				require.NoError(t, err)
+				buildImageLayers, err := platformBuildImages["linux/arm64"].Layers()
+				require.NoError(t, err)
+				assert.Equal(t, buildImageLayers[0], layers[0])
+			})
+		})
+
 		when("signing a builder image", func() {
 			it("does not populate the signature paths when no secrets were present", func() {
 				builderRecord, err := subject.CreateBuilder(ctx, builderKeychain, stackKeychain, lifecycleKeychain, fetcher, stack, clusterLifecycle, clusterBuilderSpec, []*corev1.Secret{}, builderTag)
@@ -1051,3 +1443,8 @@ func digest(image v1.Image) string {
 	d, _ := image.Digest()
 	return d.String()
 }
+
+func indexDigest(index v1.ImageIndex) string {
+	d, _ := index.Digest()
+	return d.String()
+}
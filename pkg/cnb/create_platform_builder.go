@@ -0,0 +1,170 @@
+package cnb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	corev1 "k8s.io/api/core/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// createPlatformsBuilder creates a builder image for every platform of the spec and pushes the builder and its run
+// image as image indexes. The stack and lifecycle images are fetched for each platform from the image indexes they
+// were resolved from.
+func (r *RemoteBuilderCreator) createPlatformsBuilder(
+	ctx context.Context,
+	builderKeychain authn.Keychain,
+	stackKeychain authn.Keychain,
+	lifecycleKeychain authn.Keychain,
+	fetcher RemoteBuildpackFetcher,
+	clusterStack *buildapi.ClusterStack,
+	clusterLifecycle *buildapi.ClusterLifecycle,
+	spec buildapi.BuilderSpec,
+	serviceAccountSecrets []*corev1.Secret,
+	resolvedBuilderRef string,
+) (buildapi.BuilderRecord, error) {
+	var (
+		builderIndex   = mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+		runImageIndex  = mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+		platformImages = make([]buildapi.BuilderPlatformImage, 0, len(spec.Platforms))
+		builderBldr    *builderBlder
+	)
+
+	for _, platform := range spec.Platforms {
+		buildImage, err := r.fetchPlatformImage(stackKeychain, pinnedImage(clusterStack.Status.BuildImage.IndexImage, clusterStack.Status.BuildImage.LatestImage), platform)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+		runImage, err := r.fetchPlatformImage(stackKeychain, pinnedImage(clusterStack.Status.RunImage.IndexImage, clusterStack.Status.RunImage.LatestImage), platform)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+		lifecycleImage, err := r.fetchPlatformImage(lifecycleKeychain, pinnedImage(clusterLifecycle.Status.Image.IndexImage, clusterLifecycle.Status.Image.LatestImage), platform)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+
+		runImageDigest, err := runImage.Digest()
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+		relocatedRunImage := fmt.Sprintf("%s-run-image@%s", resolvedBuilderRef, runImageDigest)
+
+		var writeableImage ggcrv1.Image
+		builderBldr, writeableImage, err = r.builderImage(ctx, fetcher.ForPlatform(platform), clusterStack, clusterLifecycle, spec, buildImage, lifecycleImage, relocatedRunImage)
+		if err != nil {
+			return buildapi.BuilderRecord{}, fmt.Errorf("creating builder for platform %s: %w", platform, err)
+		}
+
+		builderDigest, err := writeableImage.Digest()
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+
+		builderIndex, err = appendPlatformImage(builderIndex, writeableImage)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+		runImageIndex, err = appendPlatformImage(runImageIndex, runImage)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+
+		platformImages = append(platformImages, buildapi.BuilderPlatformImage{
+			Platform: platform,
+			Image:    fmt.Sprintf("%s@%s", resolvedBuilderRef, builderDigest),
+			RunImage: relocatedRunImage,
+		})
+	}
+
+	relocatedRunImage, err := r.RegistryClient.SaveIndex(builderKeychain, fmt.Sprintf("%s-run-image", resolvedBuilderRef), runImageIndex)
+	if err != nil {
+		return buildapi.BuilderRecord{}, err
+	}
+
+	identifier, err := r.RegistryClient.SaveIndex(builderKeychain, resolvedBuilderRef, builderIndex)
+	if err != nil {
+		return buildapi.BuilderRecord{}, err
+	}
+
+	signaturePaths := make([]buildapi.CosignSignature, 0)
+	if len(serviceAccountSecrets) > 0 {
+		signaturePaths, err = r.ImageSigner.SignBuilder(ctx, identifier, serviceAccountSecrets, builderKeychain)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+	}
+
+	return buildapi.BuilderRecord{
+		Image: identifier,
+		Stack: corev1alpha1.BuildStack{
+			RunImage: relocatedRunImage,
+			ID:       clusterStack.Status.Id,
+		},
+		Lifecycle: buildapi.ResolvedClusterLifecycle{
+			Version: clusterLifecycle.Status.ResolvedClusterLifecycle.Version,
+			API:     clusterLifecycle.Status.ResolvedClusterLifecycle.API,
+			APIs:    clusterLifecycle.Status.ResolvedClusterLifecycle.APIs,
+		},
+		Buildpacks:              buildpackMetadata(builderBldr.buildpacks()),
+		Order:                   builderBldr.order,
+		Extensions:              extensionMetadata(builderBldr.extensions()),
+		OrderExtensions:         builderBldr.orderExtensions,
+		ObservedStackGeneration: clusterStack.Status.ObservedGeneration,
+		ObservedStoreGeneration: fetcher.ClusterStoreObservedGeneration(),
+		OS:                      builderBldr.os,
+		SignaturePaths:          signaturePaths,
+		PlatformImages:          platformImages,
+	}, nil
+}
+
+// pinnedImage is the image index an image was resolved from, or the resolved image itself if it is not an index
+func pinnedImage(indexImage, latestImage string) string {
+	if indexImage != "" {
+		return indexImage
+	}
+	return latestImage
+}
+
+// fetchPlatformImage fetches the image of the platform and errors if an image that is not an index was built for
+// a different platform
+func (r *RemoteBuilderCreator) fetchPlatformImage(keychain authn.Keychain, repoName, platform string) (ggcrv1.Image, error) {
+	image, _, err := r.RegistryClient.FetchPlatform(keychain, repoName, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := ggcrv1.ParsePlatform(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := image.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if !platformMatches(want.OS, want.Architecture, want.Variant, config.OS, config.Architecture, config.Variant) {
+		return nil, fmt.Errorf("image %s does not support platform %s", repoName, platform)
+	}
+	return image, nil
+}
+
+func appendPlatformImage(index ggcrv1.ImageIndex, image ggcrv1.Image) (ggcrv1.ImageIndex, error) {
+	config, err := image.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return mutate.AppendManifests(index, mutate.IndexAddendum{
+		Add:        image,
+		Descriptor: ggcrv1.Descriptor{Platform: config.Platform()},
+	}), nil
+}
@@ -0,0 +1,145 @@
+package cnb
+
+import (
+	"fmt"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// ExtensionResolver will attempt to resolve an image extension reference to
+// an image extension from either the Extensions or ClusterExtensions
+type ExtensionResolver interface {
+	resolveExtension(ref v1alpha2.BuilderBuildpackRef) (K8sRemoteBuildpack, error)
+}
+
+type extensionResolver struct {
+	extensions        []*v1alpha2.Extension
+	clusterExtensions []*v1alpha2.ClusterExtension
+}
+
+func NewExtensionResolver(extensions []*v1alpha2.Extension, clusterExtensions []*v1alpha2.ClusterExtension) ExtensionResolver {
+	return &extensionResolver{
+		extensions:        extensions,
+		clusterExtensions: clusterExtensions,
+	}
+}
+
+func (r *extensionResolver) resolveExtension(ref v1alpha2.BuilderBuildpackRef) (K8sRemoteBuildpack, error) {
+	var matchingExtensions []K8sRemoteBuildpack
+	switch {
+	case ref.Kind == v1alpha2.ExtensionKind:
+		ext := findExtension(ref.ObjectReference, r.extensions)
+		if ext == nil {
+			return K8sRemoteBuildpack{}, fmt.Errorf("extension not found: %v", ref.Name)
+		}
+
+		matchingExtensions = resolveFromExtension(ref.Id, []*v1alpha2.Extension{ext})
+	case ref.Kind == v1alpha2.ClusterExtensionKind:
+		cext := findClusterExtension(ref.ObjectReference, r.clusterExtensions)
+		if cext == nil {
+			return K8sRemoteBuildpack{}, fmt.Errorf("cluster extension not found: %v", ref.Name)
+		}
+
+		matchingExtensions = resolveFromClusterExtension(ref.Id, []*v1alpha2.ClusterExtension{cext})
+	case ref.Kind != "":
+		return K8sRemoteBuildpack{}, fmt.Errorf("kind must be either %v or %v", v1alpha2.ExtensionKind, v1alpha2.ClusterExtensionKind)
+	case ref.Id != "":
+		matchingExtensions = append(resolveFromExtension(ref.Id, r.extensions), resolveFromClusterExtension(ref.Id, r.clusterExtensions)...)
+	default:
+		return K8sRemoteBuildpack{}, fmt.Errorf("invalid extension reference")
+	}
+
+	if len(matchingExtensions) == 0 {
+		return K8sRemoteBuildpack{}, errors.Errorf("could not find extension with id '%s'", ref.Id)
+	}
+
+	if ref.Id == "" && !singleExtension(matchingExtensions) {
+		return K8sRemoteBuildpack{}, errors.Errorf("id is required for %s '%s' with multiple extensions", ref.Kind, ref.Name)
+	}
+
+	if ref.Version == "" {
+		return highestVersion(matchingExtensions)
+	}
+
+	for _, result := range matchingExtensions {
+		if result.Buildpack.Version == ref.Version {
+			return result, nil
+		}
+	}
+
+	return K8sRemoteBuildpack{}, errors.Errorf("could not find extension with id '%s' and version '%s'", ref.Id, ref.Version)
+}
+
+// resolveFromExtension returns the extensions matching id or all of them if id is empty
+func resolveFromExtension(id string, extensions []*v1alpha2.Extension) []K8sRemoteBuildpack {
+	var matchingExtensions []K8sRemoteBuildpack
+	for _, ext := range extensions {
+		for _, status := range ext.Status.Extensions {
+			if id == "" || status.Id == id {
+				matchingExtensions = append(matchingExtensions, K8sRemoteBuildpack{
+					Buildpack: status,
+					SecretRef: registry.SecretRef{
+						ServiceAccount: ext.Spec.ServiceAccountName,
+						Namespace:      ext.Namespace,
+					},
+					source: v1.ObjectReference{Name: ext.Name, Namespace: ext.Namespace, Kind: ext.Kind},
+				})
+			}
+		}
+	}
+	return matchingExtensions
+}
+
+// resolveFromClusterExtension returns the extensions matching id or all of them if id is empty
+func resolveFromClusterExtension(id string, clusterExtensions []*v1alpha2.ClusterExtension) []K8sRemoteBuildpack {
+	var matchingExtensions []K8sRemoteBuildpack
+	for _, cext := range clusterExtensions {
+		for _, status := range cext.Status.Extensions {
+			if id == "" || status.Id == id {
+				secretRef := registry.SecretRef{}
+
+				if cext.Spec.ServiceAccountRef != nil {
+					secretRef = registry.SecretRef{
+						ServiceAccount: cext.Spec.ServiceAccountRef.Name,
+						Namespace:      cext.Spec.ServiceAccountRef.Namespace,
+					}
+				}
+				matchingExtensions = append(matchingExtensions, K8sRemoteBuildpack{
+					Buildpack: status,
+					SecretRef: secretRef,
+					source:    v1.ObjectReference{Name: cext.Name, Namespace: cext.Namespace, Kind: cext.Kind},
+				})
+			}
+		}
+	}
+	return matchingExtensions
+}
+
+func singleExtension(extensions []K8sRemoteBuildpack) bool {
+	ids := map[string]struct{}{}
+	for _, ext := range extensions {
+		ids[ext.Buildpack.Id] = struct{}{}
+	}
+	return len(ids) == 1
+}
+
+func findExtension(ref v1.ObjectReference, extensions []*v1alpha2.Extension) *v1alpha2.Extension {
+	for _, ext := range extensions {
+		if ext.Name == ref.Name {
+			return ext
+		}
+	}
+	return nil
+}
+
+func findClusterExtension(ref v1.ObjectReference, clusterExtensions []*v1alpha2.ClusterExtension) *v1alpha2.ClusterExtension {
+	for _, cext := range clusterExtensions {
+		if cext.Name == ref.Name {
+			return cext
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package cnb
+
+import (
+	"testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExtensionResolver(t *testing.T) {
+	spec.Run(t, "TestExtensionResolver", testExtensionResolver)
+}
+
+func testExtensionResolver(t *testing.T, when spec.G, it spec.S) {
+	var (
+		testNamespace = "some-namespace"
+
+		curlExtension = corev1alpha1.BuildpackStatus{
+			BuildpackInfo: corev1alpha1.BuildpackInfo{
+				Id:      "io.extension.curl",
+				Version: "1.0.0",
+			},
+			DiffId: "sha256:1bf8899667b8d1e6b124f663faca32903b470831e5e4e992644ac5c839ab3462",
+			Digest: "sha256:d345d1b12ae6b3f7cfc617f7adaebe06c32ce60b1aa30bb80fb622b65523de8f",
+			Size:   50,
+			StoreImage: corev1alpha1.ImageSource{
+				Image: "some.registry.io/curl-extension",
+			},
+			Homepage: "extension.curl.com",
+			API:      "0.9",
+		}
+
+		newerCurlExtension = corev1alpha1.BuildpackStatus{
+			BuildpackInfo: corev1alpha1.BuildpackInfo{
+				Id:      "io.extension.curl",
+				Version: "2.0.0",
+			},
+			DiffId: "sha256:2bf8899667b8d1e6b124f663faca32903b470831e5e4e992644ac5c839ab3462",
+			Digest: "sha256:7c1213a54d20137a7479e72150c058268a6604b98c011b4fc11ca45927923d7b",
+			Size:   40,
+			StoreImage: corev1alpha1.ImageSource{
+				Image: "some.registry.io/cluster-curl-extension",
+			},
+			Homepage: "extension.curl.com",
+			API:      "0.9",
+		}
+
+		gitExtension = corev1alpha1.BuildpackStatus{
+			BuildpackInfo: corev1alpha1.BuildpackInfo{
+				Id:      "io.extension.git",
+				Version: "1.0.0",
+			},
+			DiffId: "sha256:3bf8899667b8d1e6b124f663faca32903b470831e5e4e992644ac5c839ab3462",
+			Digest: "sha256:07db84e57fdd7101104c2469984217696fdfe51591cb1edee2928514135920d6",
+			Size:   30,
+			StoreImage: corev1alpha1.ImageSource{
+				Image: "some.registry.io/cluster-curl-extension",
+			},
+			API: "0.9",
+		}
+
+		extensions = []*buildapi.Extension{
+			{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1alpha2", Kind: "Extension"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "curl",
+					Namespace: testNamespace,
+				},
+				Spec: buildapi.ExtensionSpec{
+					ServiceAccountName: "some-service-account",
+				},
+				Status: buildapi.ExtensionStatus{
+					Extensions: []corev1alpha1.BuildpackStatus{curlExtension},
+				},
+			},
+		}
+
+		clusterExtensions = []*buildapi.ClusterExtension{
+			{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1alpha2", Kind: "ClusterExtension"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-extensions",
+				},
+				Spec: buildapi.ClusterExtensionSpec{
+					ServiceAccountRef: &corev1.ObjectReference{
+						Name:      "some-cluster-service-account",
+						Namespace: "some-other-namespace",
+					},
+				},
+				Status: buildapi.ClusterExtensionStatus{
+					Extensions: []corev1alpha1.BuildpackStatus{newerCurlExtension, gitExtension},
+				},
+			},
+		}
+
+		resolver = NewExtensionResolver(extensions, clusterExtensions)
+	)
+
+	when("using id", func() {
+		it("finds the highest version across extensions and cluster extensions", func() {
+			extension, err := resolver.resolveExtension(makeRef("io.extension.curl", ""))
+			assert.Nil(t, err)
+			assert.Equal(t, newerCurlExtension, extension.Buildpack)
+			assert.Equal(t, registry.SecretRef{
+				ServiceAccount: "some-cluster-service-account",
+				Namespace:      "some-other-namespace",
+			}, extension.SecretRef)
+		})
+
+		it("finds it using id and version", func() {
+			extension, err := resolver.resolveExtension(makeRef("io.extension.curl", "1.0.0"))
+			assert.Nil(t, err)
+			assert.Equal(t, curlExtension, extension.Buildpack)
+			assert.Equal(t, registry.SecretRef{
+				ServiceAccount: "some-service-account",
+				Namespace:      testNamespace,
+			}, extension.SecretRef)
+		})
+
+		it("fails on unknown version", func() {
+			_, err := resolver.resolveExtension(makeRef("io.extension.curl", "3.0.0"))
+			assert.EqualError(t, err, "could not find extension with id 'io.extension.curl' and version '3.0.0'")
+		})
+
+		it("fails on unknown id", func() {
+			_, err := resolver.resolveExtension(makeRef("io.extension.unknown", ""))
+			assert.EqualError(t, err, "could not find extension with id 'io.extension.unknown'")
+		})
+	})
+
+	when("using object ref", func() {
+		it("finds the extension resource", func() {
+			extension, err := resolver.resolveExtension(makeObjectRef("curl", "Extension", "", ""))
+			assert.Nil(t, err)
+			assert.Equal(t, curlExtension, extension.Buildpack)
+		})
+
+		it("finds the cluster extension resource with an id", func() {
+			extension, err := resolver.resolveExtension(makeObjectRef("cluster-extensions", "ClusterExtension", "io.extension.git", ""))
+			assert.Nil(t, err)
+			assert.Equal(t, gitExtension, extension.Buildpack)
+		})
+
+		it("requires an id for resources with multiple extensions", func() {
+			_, err := resolver.resolveExtension(makeObjectRef("cluster-extensions", "ClusterExtension", "", ""))
+			assert.EqualError(t, err, "id is required for ClusterExtension 'cluster-extensions' with multiple extensions")
+		})
+
+		it("fails on missing resource", func() {
+			_, err := resolver.resolveExtension(makeObjectRef("missing", "Extension", "", ""))
+			assert.EqualError(t, err, "extension not found: missing")
+		})
+
+		it("fails on invalid kind", func() {
+			_, err := resolver.resolveExtension(makeObjectRef("curl", "Buildpack", "", ""))
+			assert.EqualError(t, err, "kind must be either Extension or ClusterExtension")
+		})
+	})
+}
@@ -101,6 +101,7 @@ func makeObjectRef(name, kind, id, version string) buildapi.BuilderBuildpackRef
 
 type fakeFetcher struct {
 	buildpacks         map[string][]buildpackLayer
+	extensions         map[string][]buildpackLayer
 	observedGeneration int64
 }
 
@@ -116,6 +117,22 @@ func (f *fakeFetcher) ResolveAndFetch(_ context.Context, buildpack buildapi.Buil
 	}, nil
 }
 
+func (f *fakeFetcher) ResolveAndFetchExtension(_ context.Context, extension buildapi.BuilderBuildpackRef) (RemoteBuildpackInfo, error) {
+	layers, ok := f.extensions[fmt.Sprintf("%s@%s", extension.Id, extension.Version)]
+	if !ok {
+		return RemoteBuildpackInfo{}, errors.New("extension not found")
+	}
+
+	return RemoteBuildpackInfo{
+		BuildpackInfo: buildpackInfoInLayers(layers, extension.Id, extension.Version),
+		Layers:        layers,
+	}, nil
+}
+
+func (f *fakeFetcher) ForPlatform(platform string) RemoteBuildpackFetcher {
+	return f
+}
+
 func (f *fakeFetcher) ClusterStoreObservedGeneration() int64 {
 	return f.observedGeneration
 }
@@ -128,7 +145,16 @@ func (f *fakeFetcher) resolve(ref buildapi.BuilderBuildpackRef) (K8sRemoteBuildp
 	panic("Not implemented For Tests")
 }
 
+func (f *fakeFetcher) resolveExtension(ref buildapi.BuilderBuildpackRef) (K8sRemoteBuildpack, error) {
+	panic("Not implemented For Tests")
+}
+
 func (f *fakeFetcher) AddBuildpack(t *testing.T, id, version string, layers []buildpackLayer) {
 	t.Helper()
 	f.buildpacks[fmt.Sprintf("%s@%s", id, version)] = layers
 }
+
+func (f *fakeFetcher) AddExtension(t *testing.T, id, version string, layers []buildpackLayer) {
+	t.Helper()
+	f.extensions[fmt.Sprintf("%s@%s", id, version)] = layers
+}
@@ -0,0 +1,49 @@
+package cnb
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RejectRunImageExtensions errors if the image extensions generated a run.Dockerfile during detection. Only the build
+// image is extended, the run image Dockerfiles would otherwise be silently ignored.
+func RejectRunImageExtensions(generatedDir string) error {
+	var extensions []string
+	err := filepath.WalkDir(generatedDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(generatedDir, path)
+		if err != nil {
+			return err
+		}
+
+		// the lifecycle writes <generated>/run/<extension>/Dockerfile or <generated>/<extension>/run.Dockerfile
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		switch {
+		case len(parts) == 3 && parts[0] == "run" && parts[2] == "Dockerfile":
+			extensions = append(extensions, parts[1])
+		case len(parts) == 2 && parts[1] == "run.Dockerfile":
+			extensions = append(extensions, parts[0])
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "reading generated extension output")
+	}
+
+	if len(extensions) > 0 {
+		sort.Strings(extensions)
+		return errors.Errorf("image extensions %s generated a run.Dockerfile, only build image extensions are supported", strings.Join(extensions, ", "))
+	}
+	return nil
+}
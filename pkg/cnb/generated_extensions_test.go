@@ -0,0 +1,50 @@
+package cnb_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/cnb"
+)
+
+func TestRejectRunImageExtensions(t *testing.T) {
+	spec.Run(t, "RejectRunImageExtensions", testRejectRunImageExtensions)
+}
+
+func testRejectRunImageExtensions(t *testing.T, when spec.G, it spec.S) {
+	var generatedDir string
+
+	it.Before(func() {
+		generatedDir = t.TempDir()
+	})
+
+	writeDockerfile := func(path ...string) {
+		file := filepath.Join(append([]string{generatedDir}, path...)...)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte("FROM some/image"), 0644))
+	}
+
+	it("allows build image extensions", func() {
+		writeDockerfile("build", "some-extension", "Dockerfile")
+		writeDockerfile("other-extension", "build.Dockerfile")
+
+		require.NoError(t, cnb.RejectRunImageExtensions(generatedDir))
+	})
+
+	it("allows no generated output", func() {
+		require.NoError(t, cnb.RejectRunImageExtensions(filepath.Join(generatedDir, "missing")))
+	})
+
+	it("rejects extensions that generated a run.Dockerfile", func() {
+		writeDockerfile("run", "some-extension", "Dockerfile")
+		writeDockerfile("other-extension", "run.Dockerfile")
+		writeDockerfile("build", "build-extension", "Dockerfile")
+
+		require.EqualError(t, cnb.RejectRunImageExtensions(generatedDir),
+			"image extensions other-extension, some-extension generated a run.Dockerfile, only build image extensions are supported")
+	})
+}
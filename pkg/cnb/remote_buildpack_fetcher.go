@@ -4,7 +4,10 @@ import (
 	"context"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -16,24 +19,37 @@ import (
 type RemoteBuildpackFetcher interface {
 	BuildpackResolver
 	ResolveAndFetch(context.Context, buildapi.BuilderBuildpackRef) (RemoteBuildpackInfo, error)
+	ResolveAndFetchExtension(context.Context, buildapi.BuilderBuildpackRef) (RemoteBuildpackInfo, error)
+	// ForPlatform returns a fetcher of the buildpack and extension layers of the platform, such as linux/arm64
+	ForPlatform(platform string) RemoteBuildpackFetcher
 }
 
 type remoteBuildpackFetcher struct {
 	BuildpackResolver
+	ExtensionResolver
 	keychainFactory registry.KeychainFactory
+	platform        string
 }
 
 func NewRemoteBuildpackFetcher(
 	factory registry.KeychainFactory,
 	clusterStore *buildapi.ClusterStore,
 	buildpacks []*buildapi.Buildpack, clusterBuildpacks []*buildapi.ClusterBuildpack,
+	extensions []*buildapi.Extension, clusterExtensions []*buildapi.ClusterExtension,
 ) RemoteBuildpackFetcher {
 	return &remoteBuildpackFetcher{
 		BuildpackResolver: NewBuildpackResolver(clusterStore, buildpacks, clusterBuildpacks),
+		ExtensionResolver: NewExtensionResolver(extensions, clusterExtensions),
 		keychainFactory:   dockercreds.NewCachedKeychainFactory(factory),
 	}
 }
 
+func (s *remoteBuildpackFetcher) ForPlatform(platform string) RemoteBuildpackFetcher {
+	fetcher := *s
+	fetcher.platform = platform
+	return &fetcher
+}
+
 func (s *remoteBuildpackFetcher) ResolveAndFetch(ctx context.Context, ref buildapi.BuilderBuildpackRef) (RemoteBuildpackInfo, error) {
 	remote, err := s.resolve(ref)
 	if err != nil {
@@ -50,7 +66,7 @@ func (s *remoteBuildpackFetcher) fetch(ctx context.Context, remoteBuildpack K8sR
 		return RemoteBuildpackInfo{}, err
 	}
 
-	layer, err := layerForBuildpack(keychain, buildpack)
+	layer, diffID, err := s.layerForBuildpack(keychain, buildpack, buildpackLayersLabel)
 	if err != nil {
 		return RemoteBuildpackInfo{}, err
 	}
@@ -74,16 +90,60 @@ func (s *remoteBuildpackFetcher) fetch(ctx context.Context, remoteBuildpack K8sR
 			v1Layer:       layer,
 			BuildpackInfo: info,
 			BuildpackLayerInfo: BuildpackLayerInfo{
-				LayerDiffID: buildpack.DiffId,
+				LayerDiffID: diffID,
 				Order:       buildpack.Order,
 				API:         buildpack.API,
 				Stacks:      buildpack.Stacks,
+				Targets:     buildpack.Targets,
 				Homepage:    buildpack.Homepage,
 			},
 		}),
 	}, nil
 }
 
+func (s *remoteBuildpackFetcher) ResolveAndFetchExtension(ctx context.Context, ref buildapi.BuilderBuildpackRef) (RemoteBuildpackInfo, error) {
+	remote, err := s.resolveExtension(ref)
+	if err != nil {
+		return RemoteBuildpackInfo{}, err
+	}
+
+	return s.fetchExtension(ctx, remote)
+}
+
+func (s *remoteBuildpackFetcher) fetchExtension(ctx context.Context, remoteExtension K8sRemoteBuildpack) (RemoteBuildpackInfo, error) {
+	extension := remoteExtension.Buildpack
+	keychain, err := s.keychainFactory.KeychainForSecretRef(ctx, remoteExtension.SecretRef)
+	if err != nil {
+		return RemoteBuildpackInfo{}, err
+	}
+
+	layer, diffID, err := s.layerForBuildpack(keychain, extension, extensionLayersLabel)
+	if err != nil {
+		return RemoteBuildpackInfo{}, err
+	}
+
+	info := DescriptiveBuildpackInfo{
+		BuildpackInfo: corev1alpha1.BuildpackInfo{
+			Id:      extension.Id,
+			Version: extension.Version,
+		},
+		Homepage: extension.Homepage,
+	}
+
+	return RemoteBuildpackInfo{
+		BuildpackInfo: info,
+		Layers: []buildpackLayer{{
+			v1Layer:       layer,
+			BuildpackInfo: info,
+			BuildpackLayerInfo: BuildpackLayerInfo{
+				LayerDiffID: diffID,
+				API:         extension.API,
+				Homepage:    extension.Homepage,
+			},
+		}},
+	}, nil
+}
+
 // TODO: ensure there are no cycles in the buildpack graph
 func (s *remoteBuildpackFetcher) layersForOrder(ctx context.Context, order corev1alpha1.Order) ([]buildpackLayer, error) {
 	var buildpackLayers []buildpackLayer
@@ -112,6 +172,53 @@ func (s *remoteBuildpackFetcher) layersForOrder(ctx context.Context, order corev
 	return buildpackLayers, nil
 }
 
+// layerForBuildpack returns the layer of the buildpack and its diff id. The layers of a platform are read from the
+// image index the buildpack was resolved from, as the resolved layer is only the layer of a single platform. A store
+// image that is not an index has the same layer for every platform.
+func (s *remoteBuildpackFetcher) layerForBuildpack(keychain authn.Keychain, buildpack corev1alpha1.BuildpackStatus, layersLabel string) (v1.Layer, string, error) {
+	if s.platform == "" || buildpack.StoreIndexImage == "" {
+		layer, err := layerForBuildpack(keychain, buildpack)
+		return layer, buildpack.DiffId, err
+	}
+
+	platform, err := v1.ParsePlatform(s.platform)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ref, err := name.ParseReference(buildpack.StoreIndexImage)
+	if err != nil {
+		return nil, "", err
+	}
+
+	image, err := remote.Image(ref, remote.WithAuthFromKeychain(keychain), remote.WithPlatform(*platform))
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "fetching %s for platform %s", buildpack.StoreIndexImage, s.platform)
+	}
+
+	layerMetadata := BuildpackLayerMetadata{}
+	err = imagehelpers.GetLabel(image, layersLabel, &layerMetadata)
+	if err != nil {
+		return nil, "", err
+	}
+
+	metadata, ok := layerMetadata[buildpack.Id][buildpack.Version]
+	if !ok {
+		return nil, "", errors.Errorf("%s not found in %s for platform %s", buildpack.BuildpackInfo, buildpack.StoreIndexImage, s.platform)
+	}
+
+	diffID, err := v1.NewHash(metadata.LayerDiffID)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to parse layer diffId for %s", buildpack.BuildpackInfo)
+	}
+
+	layer, err := image.LayerByDiffID(diffID)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to get layer %s", buildpack.BuildpackInfo)
+	}
+	return layer, metadata.LayerDiffID, nil
+}
+
 func layerForBuildpack(keychain authn.Keychain, buildpack corev1alpha1.BuildpackStatus) (v1.Layer, error) {
 	return imagehelpers.NewLazyMountableLayer(imagehelpers.LazyMountableLayerArgs{
 		Digest:   buildpack.Digest,
@@ -2,10 +2,18 @@ package cnb
 
 import (
 	"context"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
 
@@ -379,6 +387,120 @@ func testRemoteBuildpackFetcher(t *testing.T, when spec.G, it spec.S) {
 				},
 			}, info)
 		})
+
+		when("fetching for a platform", func() {
+			var (
+				server = httptest.NewServer(ggcrregistry.New())
+				host   = strings.TrimPrefix(server.URL, "http://")
+			)
+
+			it.After(func() {
+				server.Close()
+			})
+
+			buildpackageImage := func(arch string) v1.Image {
+				image, err := random.Image(10, 1)
+				require.NoError(t, err)
+
+				layers, err := image.Layers()
+				require.NoError(t, err)
+
+				image, err = imagehelpers.SetLabels(image, map[string]interface{}{
+					buildpackLayersLabel: BuildpackLayerMetadata{
+						engineBuildpack.Id: {
+							engineBuildpack.Version: BuildpackLayerInfo{
+								API:         engineBuildpack.API,
+								LayerDiffID: diffID(t, layers[0]),
+							},
+						},
+					},
+				})
+				require.NoError(t, err)
+
+				config, err := image.ConfigFile()
+				require.NoError(t, err)
+				config.OS = "linux"
+				config.Architecture = arch
+				image, err = mutate.ConfigFile(image, config)
+				require.NoError(t, err)
+				return image
+			}
+
+			// pushIndex pushes the index to the build package tag and returns the digest it was pushed as
+			pushIndex := func(index v1.ImageIndex) string {
+				ref, err := name.ParseReference(host + "/build-package")
+				require.NoError(t, err)
+				require.NoError(t, remote.WriteIndex(ref, index))
+
+				digest, err := index.Digest()
+				require.NoError(t, err)
+				return ref.Context().Name() + "@" + digest.String()
+			}
+
+			it("returns the layer of the platform from the store image index", func() {
+				arm64Image := buildpackageImage("arm64")
+				index := mutate.AppendManifests(empty.Index,
+					mutate.IndexAddendum{Add: buildpackageImage("amd64"), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+					mutate.IndexAddendum{Add: arm64Image, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+				)
+				buildpack := engineBuildpack
+				buildpack.StoreImage.Image = host + "/build-package"
+				buildpack.StoreIndexImage = pushIndex(index)
+
+				info, err := fetcher.ForPlatform("linux/arm64").(*remoteBuildpackFetcher).fetch(ctx, K8sRemoteBuildpack{
+					Buildpack: buildpack,
+					SecretRef: secretRef,
+				})
+				require.NoError(t, err)
+
+				layers, err := arm64Image.Layers()
+				require.NoError(t, err)
+				require.Len(t, info.Layers, 1)
+				require.Equal(t, diffID(t, layers[0]), info.Layers[0].BuildpackLayerInfo.LayerDiffID)
+				require.Equal(t, diffID(t, layers[0]), diffID(t, info.Layers[0].v1Layer))
+			})
+
+			it("fetches the layer of the platform from the index the store image was resolved from", func() {
+				arm64Image := buildpackageImage("arm64")
+				resolvedIndex := pushIndex(mutate.AppendManifests(empty.Index,
+					mutate.IndexAddendum{Add: buildpackageImage("amd64"), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+					mutate.IndexAddendum{Add: arm64Image, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+				))
+				pushIndex(mutate.AppendManifests(empty.Index,
+					mutate.IndexAddendum{Add: buildpackageImage("arm64"), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+				))
+
+				buildpack := engineBuildpack
+				buildpack.StoreImage.Image = host + "/build-package"
+				buildpack.StoreIndexImage = resolvedIndex
+
+				info, err := fetcher.ForPlatform("linux/arm64").(*remoteBuildpackFetcher).fetch(ctx, K8sRemoteBuildpack{
+					Buildpack: buildpack,
+					SecretRef: secretRef,
+				})
+				require.NoError(t, err)
+
+				layers, err := arm64Image.Layers()
+				require.NoError(t, err)
+				require.Len(t, info.Layers, 1)
+				require.Equal(t, diffID(t, layers[0]), diffID(t, info.Layers[0].v1Layer))
+			})
+
+			it("errors when the buildpack is not in the store image of the platform", func() {
+				buildpack := v9Buildpack
+				buildpack.StoreImage.Image = host + "/build-package"
+				buildpack.StoreIndexImage = pushIndex(mutate.AppendManifests(empty.Index,
+					mutate.IndexAddendum{Add: buildpackageImage("amd64"), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+					mutate.IndexAddendum{Add: buildpackageImage("arm64"), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+				))
+
+				_, err := fetcher.ForPlatform("linux/arm64").(*remoteBuildpackFetcher).fetch(ctx, K8sRemoteBuildpack{
+					Buildpack: buildpack,
+					SecretRef: secretRef,
+				})
+				require.EqualError(t, err, "io.buildpack.multi@9.0.0 not found in "+buildpack.StoreIndexImage+" for platform linux/arm64")
+			})
+		})
 	})
 }
 
@@ -14,7 +14,7 @@ type RemoteLifecycleReader struct {
 }
 
 func (r *RemoteLifecycleReader) Read(keychain authn.Keychain, clusterLifecycleSpec buildapi.ClusterLifecycleSpec) (buildapi.ResolvedClusterLifecycle, error) {
-	lifecycleImg, imageIdentifier, err := r.RegistryClient.Fetch(keychain, clusterLifecycleSpec.Image)
+	lifecycleImg, imageIdentifier, indexIdentifier, err := r.RegistryClient.FetchIndexed(keychain, clusterLifecycleSpec.Image)
 	if err != nil {
 		return buildapi.ResolvedClusterLifecycle{}, err
 	}
@@ -35,6 +35,7 @@ func (r *RemoteLifecycleReader) Read(keychain authn.Keychain, clusterLifecycleSp
 		Image: buildapi.ClusterLifecycleStatusImage{
 			LatestImage: imageIdentifier,
 			Image:       clusterLifecycleSpec.Image,
+			IndexImage:  indexIdentifier,
 		},
 		Version: deprecatedLifecycleMD.Info.Version,
 		API: buildapi.LifecycleAPI{
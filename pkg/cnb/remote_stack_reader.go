@@ -9,12 +9,15 @@ import (
 	"github.com/pkg/errors"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
 )
 
 const (
-	MixinsLabel = "io.buildpacks.stack.mixins"
-	StackLabel  = "io.buildpacks.stack.id"
+	MixinsLabel        = "io.buildpacks.stack.mixins"
+	StackLabel         = "io.buildpacks.stack.id"
+	DistroNameLabel    = "io.buildpacks.base.distro.name"
+	DistroVersionLabel = "io.buildpacks.base.distro.version"
 
 	cnbUserId  = "CNB_USER_ID"
 	cnbGroupId = "CNB_GROUP_ID"
@@ -25,17 +28,31 @@ type RemoteStackReader struct {
 }
 
 func (r *RemoteStackReader) Read(keychain authn.Keychain, clusterStackSpec buildapi.ClusterStackSpec) (buildapi.ResolvedClusterStack, error) {
-	buildImage, buildIdentifier, err := r.RegistryClient.Fetch(keychain, clusterStackSpec.BuildImage.Image)
+	buildImage, buildIdentifier, buildIndex, err := r.RegistryClient.FetchIndexed(keychain, clusterStackSpec.BuildImage.Image)
 	if err != nil {
 		return buildapi.ResolvedClusterStack{}, err
 	}
 
-	runImage, runIdentifier, err := r.RegistryClient.Fetch(keychain, clusterStackSpec.RunImage.Image)
+	runImage, runIdentifier, runIndex, err := r.RegistryClient.FetchIndexed(keychain, clusterStackSpec.RunImage.Image)
 	if err != nil {
 		return buildapi.ResolvedClusterStack{}, err
 	}
 
-	err = validateStackId(clusterStackSpec.Id, buildImage, runImage)
+	buildTarget, err := readTarget(buildImage)
+	if err != nil {
+		return buildapi.ResolvedClusterStack{}, err
+	}
+
+	runTarget, err := readTarget(runImage)
+	if err != nil {
+		return buildapi.ResolvedClusterStack{}, err
+	}
+
+	if clusterStackSpec.Id != "" {
+		err = validateStackId(clusterStackSpec.Id, buildImage, runImage)
+	} else {
+		err = validateTargets(buildTarget, runTarget)
+	}
 	if err != nil {
 		return buildapi.ResolvedClusterStack{}, err
 	}
@@ -67,14 +84,17 @@ func (r *RemoteStackReader) Read(keychain authn.Keychain, clusterStackSpec build
 		BuildImage: buildapi.ClusterStackStatusImage{
 			LatestImage: buildIdentifier,
 			Image:       clusterStackSpec.BuildImage.Image,
+			IndexImage:  buildIndex,
 		},
 		RunImage: buildapi.ClusterStackStatusImage{
 			LatestImage: runIdentifier,
 			Image:       clusterStackSpec.RunImage.Image,
+			IndexImage:  runIndex,
 		},
 		Mixins:  mixins,
 		UserID:  userId,
 		GroupID: groupId,
+		Target:  runTarget,
 	}, err
 }
 
@@ -96,6 +116,44 @@ func validateStackId(stackId string, buildImage ggcrv1.Image, runImage ggcrv1.Im
 	return nil
 }
 
+// readTarget reads the os, arch and distribution of a base image, nil if the image config has no os
+func readTarget(image ggcrv1.Image) (*corev1alpha1.BuildpackTarget, error) {
+	config, err := image.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.OS == "" {
+		return nil, nil
+	}
+
+	target := &corev1alpha1.BuildpackTarget{
+		OS:          config.OS,
+		Arch:        config.Architecture,
+		ArchVariant: config.Variant,
+	}
+
+	if distro := config.Config.Labels[DistroNameLabel]; distro != "" {
+		target.Distros = []corev1alpha1.BuildpackDistro{{
+			Name:    distro,
+			Version: config.Config.Labels[DistroVersionLabel],
+		}}
+	}
+	return target, nil
+}
+
+// validateTargets validates that the build and run images of a stack without an id are for the same os and arch
+func validateTargets(buildTarget, runTarget *corev1alpha1.BuildpackTarget) error {
+	if buildTarget == nil || runTarget == nil {
+		return nil
+	}
+
+	if buildTarget.OS != runTarget.OS || buildTarget.Arch != runTarget.Arch {
+		return errors.Errorf("invalid stack images. build image target: %s/%s, run image target: %s/%s", buildTarget.OS, buildTarget.Arch, runTarget.OS, runTarget.Arch)
+	}
+	return nil
+}
+
 func readMixins(image ggcrv1.Image) ([]string, error) {
 	var mixins []string
 	hasLabel, err := imagehelpers.HasLabel(image, MixinsLabel)
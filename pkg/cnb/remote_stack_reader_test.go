@@ -6,12 +6,14 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/cnb"
 	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
 	"github.com/pivotal/kpack/pkg/registry/registryfakes"
@@ -79,6 +81,75 @@ func testRemoteStackReader(t *testing.T, when spec.G, it spec.S) {
 
 		})
 
+		it("records the image indexes the stack images were resolved from", func() {
+			fakeClient.AddImage(runTag, runImage(t, stackId, nil), expectedKeychain)
+			fakeClient.AddImage(buildTag, buildImage(t, stackId, nil), expectedKeychain)
+			fakeClient.AddIndex(runTag, "gcr.io/image/run@sha256:a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1")
+			fakeClient.AddIndex(buildTag, "gcr.io/image/build@sha256:b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2")
+
+			resolvedStack, err := remoteStackReader.Read(expectedKeychain, buildapi.ClusterStackSpec{
+				Id: stackId,
+				BuildImage: buildapi.ClusterStackSpecImage{
+					Image: buildTag,
+				},
+				RunImage: buildapi.ClusterStackSpecImage{
+					Image: runTag,
+				},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, "gcr.io/image/build@sha256:b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2", resolvedStack.BuildImage.IndexImage)
+			assert.Equal(t, "gcr.io/image/run@sha256:a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1", resolvedStack.RunImage.IndexImage)
+		})
+
+		when("the stack has no id", func() {
+			it("resolves the target of the run image", func() {
+				runImage := targetImage(t, runImage(t, "", nil), "arm64", map[string]string{
+					"io.buildpacks.base.distro.name":    "ubuntu",
+					"io.buildpacks.base.distro.version": "22.04",
+				})
+				buildImage := targetImage(t, buildImage(t, "", nil), "arm64", nil)
+
+				fakeClient.AddImage(runTag, runImage, expectedKeychain)
+				fakeClient.AddImage(buildTag, buildImage, expectedKeychain)
+
+				resolvedStack, err := remoteStackReader.Read(expectedKeychain, buildapi.ClusterStackSpec{
+					BuildImage: buildapi.ClusterStackSpecImage{
+						Image: buildTag,
+					},
+					RunImage: buildapi.ClusterStackSpecImage{
+						Image: runTag,
+					},
+				})
+				require.NoError(t, err)
+
+				assert.Equal(t, "", resolvedStack.Id)
+				assert.Equal(t, &corev1alpha1.BuildpackTarget{
+					OS:      "linux",
+					Arch:    "arm64",
+					Distros: []corev1alpha1.BuildpackDistro{{Name: "ubuntu", Version: "22.04"}},
+				}, resolvedStack.Target)
+			})
+
+			it("returns error if the build and run image arch do not match", func() {
+				runImage := targetImage(t, runImage(t, "", nil), "arm64", nil)
+				buildImage := targetImage(t, buildImage(t, "", nil), "amd64", nil)
+
+				fakeClient.AddImage(runTag, runImage, expectedKeychain)
+				fakeClient.AddImage(buildTag, buildImage, expectedKeychain)
+
+				_, err := remoteStackReader.Read(expectedKeychain, buildapi.ClusterStackSpec{
+					BuildImage: buildapi.ClusterStackSpecImage{
+						Image: buildTag,
+					},
+					RunImage: buildapi.ClusterStackSpecImage{
+						Image: runTag,
+					},
+				})
+				require.EqualError(t, err, "invalid stack images. build image target: linux/amd64, run image target: linux/arm64")
+			})
+		})
+
 		when("invalid", func() {
 			it("returns error if stack id does not match run image", func() {
 				runImage := runImage(t, "something.else", nil)
@@ -233,3 +304,17 @@ func buildImage(t *testing.T, stackId string, mixins []string) v1.Image {
 
 	return runImage
 }
+
+func targetImage(t *testing.T, image v1.Image, arch string, labels map[string]string) v1.Image {
+	config, err := image.ConfigFile()
+	require.NoError(t, err)
+
+	config.OS = "linux"
+	config.Architecture = arch
+	image, err = mutate.ConfigFile(image, config)
+	require.NoError(t, err)
+
+	image, err = imagehelpers.SetStringLabels(image, labels)
+	require.NoError(t, err)
+	return image
+}
@@ -17,13 +17,22 @@ type RemoteBuildpackReader struct {
 }
 
 func (r *RemoteBuildpackReader) Read(keychain authn.Keychain, storeImages []corev1alpha1.ImageSource) ([]corev1alpha1.BuildpackStatus, error) {
+	return r.read(keychain, storeImages, buildpackLayersLabel)
+}
+
+// ReadExtensions reads the image extensions packaged in the extension images
+func (r *RemoteBuildpackReader) ReadExtensions(keychain authn.Keychain, extensionImages []corev1alpha1.ImageSource) ([]corev1alpha1.BuildpackStatus, error) {
+	return r.read(keychain, extensionImages, extensionLayersLabel)
+}
+
+func (r *RemoteBuildpackReader) read(keychain authn.Keychain, storeImages []corev1alpha1.ImageSource, layersLabel string) ([]corev1alpha1.BuildpackStatus, error) {
 	var g errgroup.Group
 
 	c := make(chan corev1alpha1.BuildpackStatus)
 	for _, storeImage := range storeImages {
 		storeImageCopy := storeImage
 		g.Go(func() error {
-			image, _, err := r.RegistryClient.Fetch(keychain, storeImageCopy.Image)
+			image, _, indexIdentifier, err := r.RegistryClient.FetchIndexed(keychain, storeImageCopy.Image)
 			if err != nil {
 				return err
 			}
@@ -39,7 +48,7 @@ func (r *RemoteBuildpackReader) Read(keychain authn.Keychain, storeImages []core
 			}
 
 			layerMetadata := BuildpackLayerMetadata{}
-			err = imagehelpers.GetLabel(image, buildpackLayersLabel, &layerMetadata)
+			err = imagehelpers.GetLabel(image, layersLabel, &layerMetadata)
 			if err != nil {
 				return err
 			}
@@ -78,17 +87,19 @@ func (r *RemoteBuildpackReader) Read(keychain authn.Keychain, storeImages []core
 					}
 
 					c <- corev1alpha1.BuildpackStatus{
-						BuildpackInfo: info,
-						Buildpackage:  packageInfo,
-						StoreImage:    storeImageCopy,
-						Digest:        digest.String(),
-						DiffId:        metadata.LayerDiffID,
-						Size:          size,
+						BuildpackInfo:   info,
+						Buildpackage:    packageInfo,
+						StoreImage:      storeImageCopy,
+						StoreIndexImage: indexIdentifier,
+						Digest:          digest.String(),
+						DiffId:          metadata.LayerDiffID,
+						Size:            size,
 
 						Order:    metadata.Order,
 						Homepage: metadata.Homepage,
 						API:      metadata.API,
 						Stacks:   metadata.Stacks,
+						Targets:  metadata.Targets,
 					}
 				}
 			}
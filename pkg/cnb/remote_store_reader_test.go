@@ -455,5 +455,76 @@ func testRemoteStoreReader(t *testing.T, when spec.G, it spec.S) {
 				require.Equal(t, expectedBuildpackOrder, subsequentOrder)
 			}
 		})
+
+		it("returns extensions from extension images", func() {
+			extensionImage, err := random.Image(0, 0)
+			require.NoError(t, err)
+
+			extensionImage, err = mutate.AppendLayers(extensionImage,
+				fakeLayer{
+					digest: "sha256:6aa3691a73805f608e5fce69fb6bc89aec8362f58a6b4be2682515e9cfa3cc1a",
+					diffID: "sha256:1fe2cf74b742ec16c76b9e996c247c78aa41905fe86b744db998094b4bcaf38a",
+					size:   40,
+				},
+			)
+			require.NoError(t, err)
+
+			extensionImage, err = imagehelpers.SetStringLabels(extensionImage, map[string]string{
+				"io.buildpacks.extension.layers": //language=json
+				`{
+  "org.extension.curl": {
+    "0.0.1": {
+      "layerDiffID": "sha256:1fe2cf74b742ec16c76b9e996c247c78aa41905fe86b744db998094b4bcaf38a",
+      "api": "0.9",
+      "homepage": "extension.curl.com"
+    }
+  }
+}
+`,
+				"io.buildpacks.buildpackage.metadata": //language=json
+				`{
+  "id": "org.extension.curl",
+  "version": "0.0.1"
+}`,
+			})
+			require.NoError(t, err)
+
+			fakeClient.AddImage("extension/curl", extensionImage, expectedKeychain)
+
+			extensions, err := remoteStoreReader.ReadExtensions(expectedKeychain, []corev1alpha1.ImageSource{
+				{
+					Image: "extension/curl",
+				},
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, []corev1alpha1.BuildpackStatus{
+				{
+					BuildpackInfo: corev1alpha1.BuildpackInfo{
+						Id:      "org.extension.curl",
+						Version: "0.0.1",
+					},
+					Buildpackage: corev1alpha1.BuildpackageInfo{
+						Id:      "org.extension.curl",
+						Version: "0.0.1",
+					},
+					StoreImage: corev1alpha1.ImageSource{
+						Image: "extension/curl",
+					},
+					API:      "0.9",
+					Homepage: "extension.curl.com",
+					DiffId:   "sha256:1fe2cf74b742ec16c76b9e996c247c78aa41905fe86b744db998094b4bcaf38a",
+					Digest:   "sha256:6aa3691a73805f608e5fce69fb6bc89aec8362f58a6b4be2682515e9cfa3cc1a",
+					Size:     40,
+				},
+			}, extensions)
+
+			_, err = remoteStoreReader.ReadExtensions(expectedKeychain, []corev1alpha1.ImageSource{
+				{
+					Image: buildpackageA,
+				},
+			})
+			require.Error(t, err)
+		})
 	})
 }
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+import (
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
 
 type Config struct {
 	SystemNamespace      string `json:"systemNamespace"`
@@ -10,6 +14,19 @@ type Config struct {
 	MaximumPlatformApiVersion string `json:"maximumPlatformApiVersion"`
 	SshTrustUnknownHosts      bool   `json:"sshTrustUnknownHosts"`
 	ScalingFactor             int    `json:"scalingFactor"`
+
+	SourcePollingFrequency time.Duration `json:"sourcePollingFrequency"`
+	SourceWebhookPort      int           `json:"sourceWebhookPort"`
+	SourceUploadPort       int           `json:"sourceUploadPort"`
+	SourceUploadRepository string        `json:"sourceUploadRepository"`
+
+	// the tls files are paths on the controller and are left out of the config in slsa attestations
+	SourceUploadTLSCert string `json:"-"`
+	SourceUploadTLSKey  string `json:"-"`
+
+	MaxConcurrentBuilds             int `json:"maxConcurrentBuilds"`
+	MaxConcurrentBuildsPerNamespace int `json:"maxConcurrentBuildsPerNamespace"`
+	MaxConcurrentBuildsPerBuilder   int `json:"maxConcurrentBuildsPerBuilder"`
 }
 
 type FeatureFlags struct {
@@ -0,0 +1,83 @@
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	cosignremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// ErrUnverifiedImage is returned when an image does not have a signature from the trusted key
+var ErrUnverifiedImage = errors.New("image is not signed by a trusted key")
+
+// SourceVerifier verifies registry source images are signed with a cosign public key
+type SourceVerifier struct {
+	verifier signature.Verifier
+	identity string
+}
+
+func NewSourceVerifier(publicKeyPath string) (*SourceVerifier, error) {
+	publicKeyPEM, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading cosign public key")
+	}
+
+	publicKey, err := cryptoutils.UnmarshalPEMToPublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing cosign public key")
+	}
+
+	verifier, err := signature.LoadVerifier(publicKey, crypto.SHA256)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading cosign public key")
+	}
+
+	der, err := cryptoutils.MarshalPublicKeyToDER(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SourceVerifier{
+		verifier: verifier,
+		identity: fmt.Sprintf("cosign public key sha256:%x", sha256.Sum256(der)),
+	}, nil
+}
+
+// Verify returns the identity of the trusted key when the image has a valid signature from it
+func (v *SourceVerifier) Verify(keychain authn.Keychain, image string) (string, error) {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	_, _, err = cosign.VerifyImageSignatures(context.Background(), ref, &cosign.CheckOpts{
+		RegistryClientOpts: []cosignremote.Option{cosignremote.WithRemoteOptions(remote.WithAuthFromKeychain(keychain))},
+		SigVerifier:        v.verifier,
+		ClaimVerifier:      cosign.SimpleClaimVerifier,
+		IgnoreTlog:         true,
+		IgnoreSCT:          true,
+	})
+	if err != nil {
+		var (
+			verificationFailure *cosign.VerificationFailure
+			noMatching          *cosign.ErrNoMatchingSignatures
+			noSignatures        *cosign.ErrNoSignaturesFound
+		)
+		if errors.As(err, &verificationFailure) || errors.As(err, &noMatching) || errors.As(err, &noSignatures) {
+			return "", errors.Wrapf(ErrUnverifiedImage, "%s: %s", image, err)
+		}
+		return "", errors.Wrapf(err, "verifying signature of %s", image)
+	}
+
+	return v.identity, nil
+}
@@ -0,0 +1,91 @@
+package cosign
+
+import (
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/v2/cmd/cosign/cli/sign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/secret"
+)
+
+func TestSourceVerifier(t *testing.T) {
+	spec.Run(t, "testSourceVerifier", testSourceVerifier)
+}
+
+func testSourceVerifier(t *testing.T, when spec.G, it spec.S) {
+	var (
+		ro             = &options.RootOptions{Timeout: options.DefaultTimeout}
+		secretLocation string
+		imageName      string
+		imageDigest    string
+		stopRegistry   func()
+		imageCleanup   func()
+	)
+
+	it.Before(func() {
+		var repo string
+		repo, stopRegistry = fakeRegistry(t)
+		imageName = path.Join(repo, "some-source-image")
+
+		hash, cleanup := pushRandomImage(t, imageName)
+		imageDigest, imageCleanup = hash.String(), cleanup
+
+		secretLocation = t.TempDir()
+		keypair(t, secretLocation, "signing-secret", "")
+		keypair(t, secretLocation, "other-secret", "")
+	})
+
+	it.After(func() {
+		imageCleanup()
+		stopRegistry()
+	})
+
+	signImage := func() {
+		t.Helper()
+		signer := NewImageSigner(func(ro *options.RootOptions, ko options.KeyOpts, signOpts options.SignOptions, imgs []string) error {
+			if filepath.Base(filepath.Dir(ko.KeyRef)) != "signing-secret" {
+				return nil
+			}
+			return sign.SignCmd(ro, ko, signOpts, imgs)
+		}, fetchSignatureFunc)
+
+		require.NoError(t, signer.Sign(ro, createReportToml(t, imageName, imageDigest), secretLocation, nil, nil, nil))
+	}
+
+	it("returns the key identity for images signed by the trusted key", func() {
+		signImage()
+
+		verifier, err := NewSourceVerifier(filepath.Join(secretLocation, "signing-secret", secret.CosignSecretPublicKey))
+		require.NoError(t, err)
+
+		identity, err := verifier.Verify(authn.DefaultKeychain, imageName+"@"+imageDigest)
+		require.NoError(t, err)
+		assert.Regexp(t, "^cosign public key sha256:[0-9a-f]{64}$", identity)
+	})
+
+	it("rejects images signed by another key", func() {
+		signImage()
+
+		verifier, err := NewSourceVerifier(filepath.Join(secretLocation, "other-secret", secret.CosignSecretPublicKey))
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(authn.DefaultKeychain, imageName+"@"+imageDigest)
+		require.True(t, errors.Is(err, ErrUnverifiedImage), "unexpected error: %v", err)
+	})
+
+	it("rejects unsigned images", func() {
+		verifier, err := NewSourceVerifier(filepath.Join(secretLocation, "signing-secret", secret.CosignSecretPublicKey))
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(authn.DefaultKeychain, imageName+"@"+imageDigest)
+		require.True(t, errors.Is(err, ErrUnverifiedImage), "unexpected error: %v", err)
+	})
+}
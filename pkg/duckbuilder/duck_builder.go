@@ -30,6 +30,7 @@ func (b *DuckBuilder) GetKind() string {
 
 type DuckBuilderSpec struct {
 	ImagePullSecrets []v1.LocalObjectReference
+	Rollout          *buildapi.BuilderRollout
 }
 
 func (b *DuckBuilder) Ready() bool {
@@ -69,3 +70,22 @@ func (b *DuckBuilder) ConditionReadyMessage() string {
 
 	return condition.Message
 }
+
+// Platforms are the platforms the builder image was created for, empty if the builder is not an image index
+func (b *DuckBuilder) Platforms() []string {
+	platforms := make([]string, 0, len(b.Status.PlatformImages))
+	for _, platformImage := range b.Status.PlatformImages {
+		platforms = append(platforms, platformImage.Platform)
+	}
+	return platforms
+}
+
+// RolloutPending is true if the image is not a canary and the rollout of the latest image has not been promoted
+func (b *DuckBuilder) RolloutPending(image *buildapi.Image) bool {
+	rollout := b.Status.Rollout
+	if b.Spec.Rollout == nil || rollout == nil || rollout.Image != b.Status.LatestImage {
+		return false
+	}
+
+	return rollout.Phase != buildapi.RolloutPhasePromoted && !b.Spec.Rollout.IsCanary(image)
+}
@@ -138,4 +138,39 @@ func testDuckBuilder(t *testing.T, when spec.G, it spec.S) {
 		require.Equal(t, "some/run@sha256:12345678", duckBuilder.RunImage())
 	})
 
+	when("RolloutPending", func() {
+		var rolloutBuilder *DuckBuilder
+		canaryImage := &buildapi.Image{ObjectMeta: metav1.ObjectMeta{Name: "canary", Labels: map[string]string{"tier": "canary"}}}
+		otherImage := &buildapi.Image{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+
+		it.Before(func() {
+			copied := *duckBuilder
+			rolloutBuilder = &copied
+			rolloutBuilder.Spec.Rollout = &buildapi.BuilderRollout{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "canary"}},
+			}
+			rolloutBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+				Image: "some/builder@sha256:12345678",
+				Phase: buildapi.RolloutPhaseCanary,
+			}
+		})
+
+		it("is pending for images that are not canaries until the rollout is promoted", func() {
+			require.True(t, rolloutBuilder.RolloutPending(otherImage))
+			require.False(t, rolloutBuilder.RolloutPending(canaryImage))
+
+			rolloutBuilder.Status.Rollout.Phase = buildapi.RolloutPhasePromoted
+			require.False(t, rolloutBuilder.RolloutPending(otherImage))
+		})
+
+		it("is not pending for a rollout of a previous image", func() {
+			rolloutBuilder.Status.Rollout.Image = "some/builder@sha256:previous"
+			require.False(t, rolloutBuilder.RolloutPending(otherImage))
+		})
+
+		it("is not pending without a rollout", func() {
+			require.False(t, duckBuilder.RolloutPending(otherImage))
+		})
+	})
+
 }
@@ -68,7 +68,10 @@ func convertBuilder(builder *buildapi.Builder) *DuckBuilder {
 	return &DuckBuilder{
 		TypeMeta:   builder.TypeMeta,
 		ObjectMeta: builder.ObjectMeta,
-		Status:     builder.Status,
+		Spec: DuckBuilderSpec{
+			Rollout: builder.Spec.Rollout,
+		},
+		Status: builder.Status,
 	}
 }
 
@@ -80,6 +83,9 @@ func convertClusterBuilder(builder *buildapi.ClusterBuilder) *DuckBuilder {
 	return &DuckBuilder{
 		TypeMeta:   builder.TypeMeta,
 		ObjectMeta: builder.ObjectMeta,
-		Status:     builder.Status,
+		Spec: DuckBuilderSpec{
+			Rollout: builder.Spec.Rollout,
+		},
+		Status: builder.Status,
 	}
 }
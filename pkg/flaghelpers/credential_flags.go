@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type CredentialsFlags []string
@@ -33,7 +34,6 @@ func GetEnvBool(key string, defaultValue bool) bool {
 	return v
 }
 
-
 func GetEnvInt(key string, defaultValue int) int {
 	s := os.Getenv(key)
 	v, err := strconv.Atoi(s)
@@ -42,3 +42,12 @@ func GetEnvInt(key string, defaultValue int) int {
 	}
 	return v
 }
+
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	s := os.Getenv(key)
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// changedPaths returns the paths that differ between the trees of two commits. Only commits and trees are
+// fetched from remotes that can filter objects, file contents are never downloaded.
+func (r *remoteGitResolver) changedPaths(auth transport.AuthMethod, url, oldRevision, newRevision string) ([]string, error) {
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, fmt.Errorf("parsing url: %w", err)
+	}
+
+	gitClient, err := client.NewClient(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating client: %w", err)
+	}
+
+	storage := memory.NewStorage()
+	err = uploadPack(gitClient, endpoint, auth, storage, func(capabilities *capability.List) (*packp.UploadPackRequest, error) {
+		req := packp.NewUploadPackRequestFromCapabilities(capabilities)
+		req.Wants = []plumbing.Hash{plumbing.NewHash(oldRevision), plumbing.NewHash(newRevision)}
+		req.Depth = packp.DepthCommits(1)
+		if err := req.Capabilities.Set(capability.Shallow); err != nil {
+			return nil, err
+		}
+
+		if capabilities.Supports(capability.Filter) {
+			req.Filter = packp.FilterBlobNone()
+			if err := req.Capabilities.Set(capability.Filter); err != nil {
+				return nil, err
+			}
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching: %w", err)
+	}
+
+	return diffPaths(storage, oldRevision, newRevision)
+}
+
+func diffPaths(objects storer.EncodedObjectStorer, oldRevision, newRevision string) ([]string, error) {
+	oldTree, err := commitTree(objects, plumbing.NewHash(oldRevision))
+	if err != nil {
+		return nil, err
+	}
+
+	newTree, err := commitTree(objects, plumbing.NewHash(newRevision))
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := object.DiffTree(oldTree, newTree)
+	if err != nil {
+		return nil, fmt.Errorf("diffing trees: %w", err)
+	}
+
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.From.Name != "" {
+			paths = append(paths, change.From.Name)
+		}
+		if change.To.Name != "" && change.To.Name != change.From.Name {
+			paths = append(paths, change.To.Name)
+		}
+	}
+	return paths, nil
+}
+
+func commitTree(objects storer.EncodedObjectStorer, hash plumbing.Hash) (*object.Tree, error) {
+	// If the object is an annotated tag, first resolve to the commit it points
+	// to.
+	tag, err := object.GetTag(objects, hash)
+	if err == nil {
+		hash = tag.Target
+	}
+
+	commit, err := object.GetCommit(objects, hash)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("tree: %w", err)
+	}
+	return tree, nil
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestDiffPaths(t *testing.T) {
+	spec.Run(t, "testDiffPaths", testDiffPaths)
+}
+
+func testDiffPaths(t *testing.T, when spec.G, it spec.S) {
+	var (
+		repoDir    string
+		repository *gogit.Repository
+	)
+
+	commit := func(files map[string]string) string {
+		t.Helper()
+		worktree, err := repository.Worktree()
+		require.NoError(t, err)
+
+		for name, contents := range files {
+			p := filepath.Join(repoDir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+			require.NoError(t, os.WriteFile(p, []byte(contents), 0644))
+			_, err = worktree.Add(name)
+			require.NoError(t, err)
+		}
+
+		hash, err := worktree.Commit("some-commit", &gogit.CommitOptions{
+			Author: &object.Signature{Name: "some-author", Email: "author@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash.String()
+	}
+
+	it.Before(func() {
+		repoDir = t.TempDir()
+
+		var err error
+		repository, err = gogit.PlainInit(repoDir, false)
+		require.NoError(t, err)
+	})
+
+	it("returns the paths changed between two commits", func() {
+		first := commit(map[string]string{"services/api/main.go": "package main", "README.md": "readme"})
+		second := commit(map[string]string{"services/web/index.html": "<html/>", "README.md": "new readme"})
+
+		paths, err := diffPaths(repository.Storer, first, second)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"services/web/index.html", "README.md"}, paths)
+	})
+
+	when("path filters", func() {
+		it("is relevant when a path is included and not excluded", func() {
+			filter := newPathFilter(corev1alpha1.SourceConfig{
+				IncludePaths: []string{"services/api/", "libs/"},
+				ExcludePaths: []string{"*.md"},
+			})
+
+			assert.True(t, filter.Relevant([]string{"README.md", "services/api/main.go"}))
+			assert.True(t, filter.Relevant([]string{"libs/shared/util.go"}))
+			assert.False(t, filter.Relevant([]string{"services/web/index.html", "services/api/README.md"}))
+			assert.False(t, filter.Relevant(nil))
+		})
+
+		it("includes everything when only excludes are provided", func() {
+			filter := newPathFilter(corev1alpha1.SourceConfig{
+				ExcludePaths: []string{"docs/"},
+			})
+
+			assert.True(t, filter.Relevant([]string{"docs/index.md", "main.go"}))
+			assert.False(t, filter.Relevant([]string{"docs/index.md"}))
+		})
+	})
+}
@@ -3,8 +3,10 @@ package git
 import (
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"log"
+	"net/http"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	gogit "github.com/go-git/go-git/v5"
@@ -19,6 +21,11 @@ type Fetcher struct {
 	Logger               *log.Logger
 	Keychain             GitKeychain
 	InitializeSubmodules bool
+	LFS                  bool
+	// SparseCheckoutPaths limits the directories checked out, all files are checked out when empty
+	SparseCheckoutPaths []string
+	// Verifier requires the commit to be signed by a trusted key when provided
+	Verifier *CommitVerifier
 }
 
 func init() {
@@ -61,15 +68,33 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 		return errors.Wrap(err, "resolving source config")
 	}
 
-	err = remote.Fetch(&gogit.FetchOptions{
-		RefSpecs: []config.RefSpec{config.RefSpec(resolvedSourceConfig.Git.Revision + ":" + resolvedSourceConfig.Git.Revision)},
-		Auth:     auth,
-		Depth:    1,
-	})
-	if err != nil && err != transport.ErrAuthenticationRequired {
-		return errors.Wrapf(err, "unable to fetch references for repository")
-	} else if err == transport.ErrAuthenticationRequired {
-		return errors.Wrapf(err, "invalid credentials for repository")
+	//resolvedSourceConfig.Git.Revision is the hash of the commit
+	hash := plumbing.NewHash(resolvedSourceConfig.Git.Revision)
+
+	// a sparse checkout only fetches the blobs of its directories if the remote supports filtering them
+	sparseDirs := sparseCheckoutDirectories(f.SparseCheckoutPaths)
+	fetched := false
+	if len(sparseDirs) > 0 {
+		f.Logger.Printf("Using sparse checkout of %q", sparseDirs)
+		fetched, err = fetchSparse(repository.Storer, gitURL, auth, hash, sparseDirs)
+		if err == transport.ErrAuthenticationRequired {
+			return errors.Wrapf(err, "invalid credentials for repository")
+		} else if err != nil {
+			return errors.Wrapf(err, "unable to fetch objects for repository")
+		}
+	}
+
+	if !fetched {
+		err = remote.Fetch(&gogit.FetchOptions{
+			RefSpecs: []config.RefSpec{config.RefSpec(resolvedSourceConfig.Git.Revision + ":" + resolvedSourceConfig.Git.Revision)},
+			Auth:     auth,
+			Depth:    1,
+		})
+		if err != nil && err != transport.ErrAuthenticationRequired {
+			return errors.Wrapf(err, "unable to fetch references for repository")
+		} else if err == transport.ErrAuthenticationRequired {
+			return errors.Wrapf(err, "invalid credentials for repository")
+		}
 	}
 
 	worktree, err := repository.Worktree()
@@ -77,10 +102,21 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 		return errors.Wrapf(err, "getting worktree for repository")
 	}
 
-	//resolvedSourceConfig.Git.Revision is the hash of the commit
-	hash := plumbing.NewHash(resolvedSourceConfig.Git.Revision)
+	var signer string
+	if f.Verifier != nil {
+		commit, err := repository.CommitObject(hash)
+		if err != nil {
+			return errors.Wrapf(err, "getting commit %s", hash)
+		}
 
-	err = worktree.Checkout(&gogit.CheckoutOptions{Hash: hash})
+		signer, err = f.Verifier.Verify(commit)
+		if err != nil {
+			return err
+		}
+		f.Logger.Printf("Verified commit %s is signed by %s", hash, signer)
+	}
+
+	err = worktree.Checkout(&gogit.CheckoutOptions{Hash: hash, SparseCheckoutDirectories: sparseDirs})
 	if err != nil {
 		return errors.Wrapf(err, "checking out revision")
 	}
@@ -104,6 +140,18 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 		}
 	}
 
+	if f.LFS {
+		lfs := &lfsFetcher{
+			Logger:   f.Logger,
+			Keychain: f.Keychain,
+			Client:   http.DefaultClient,
+			Dirs:     sparseDirs,
+		}
+		if err := lfs.Fetch(repository, dir, gitURL); err != nil {
+			return errors.Wrapf(err, "fetching lfs objects")
+		}
+	}
+
 	projectMetadataFile, err := os.Create(path.Join(metadataDir, "project-metadata.toml"))
 	if err != nil {
 		return errors.Wrapf(err, "invalid metadata destination '%s/project-metadata.toml' for git repository: %s", metadataDir, gitURL)
@@ -114,8 +162,10 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 		Source: Source{
 			Type: "git",
 			Metadata: Metadata{
-				Repository: gitURL,
-				Revision:   gitRevision,
+				Repository:     gitURL,
+				Revision:       gitRevision,
+				SparseCheckout: sparseDirs,
+				Signer:         signer,
 			},
 			Version: Version{
 				Commit: hash.String(),
@@ -130,6 +180,20 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 	return nil
 }
 
+// sparseCheckoutDirectories converts paths into directory prefixes of the repository root.
+// Returns nil if any path is the repository root.
+func sparseCheckoutDirectories(paths []string) []string {
+	var dirs []string
+	for _, p := range paths {
+		p = strings.Trim(path.Clean("/"+strings.TrimSpace(p)), "/")
+		if p == "" {
+			return nil
+		}
+		dirs = append(dirs, p+"/")
+	}
+	return dirs
+}
+
 type Project struct {
 	Source Source `toml:"source"`
 }
@@ -141,8 +205,10 @@ type Source struct {
 }
 
 type Metadata struct {
-	Repository string `toml:"repository"`
-	Revision   string `toml:"revision"`
+	Repository     string   `toml:"repository"`
+	Revision       string   `toml:"revision"`
+	SparseCheckout []string `toml:"sparse-checkout,omitempty"`
+	Signer         string   `toml:"signer,omitempty"`
 }
 
 type Version struct {
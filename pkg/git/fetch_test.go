@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"log"
 	"os"
+	"os/exec"
 	"path"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/BurntSushi/toml"
 	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
@@ -129,6 +133,80 @@ func testGitCheckout(t *testing.T, when spec.G, it spec.S) {
 			require.ErrorContains(t, err, "unable to fetch references for repository")
 		})
 
+		it("checks out only the sparse checkout paths", func() {
+			fetcher.SparseCheckoutPaths = []string{"/go/", "vendor"}
+			err := fetcher.Fetch(testDir, "https://github.com/git-fixtures/basic", "master", metadataDir)
+			require.NoError(t, err)
+
+			require.FileExists(t, path.Join(testDir, "go", "example.go"))
+			require.FileExists(t, path.Join(testDir, "vendor", "foo.go"))
+			require.NoFileExists(t, path.Join(testDir, "json", "short.json"))
+			require.NoFileExists(t, path.Join(testDir, "CHANGELOG"))
+
+			var projectMetadata Project
+			_, err = toml.DecodeFile(path.Join(metadataDir, "project-metadata.toml"), &projectMetadata)
+			require.NoError(t, err)
+			require.Equal(t, []string{"go/", "vendor/"}, projectMetadata.Source.Metadata.SparseCheckout)
+		})
+
+		// localRemote creates a repository with an app and an other directory and returns its url, commit, and the
+		// blob of the file in the other directory
+		localRemote := func(allowFilter bool) (string, string, plumbing.Hash) {
+			remoteDir, err := os.MkdirTemp("", "test-git-remote")
+			require.NoError(t, err)
+			t.Cleanup(func() { os.RemoveAll(remoteDir) })
+
+			gitCommand := func(args ...string) string {
+				cmd := exec.Command("git", append([]string{"-C", remoteDir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+				output, err := cmd.CombinedOutput()
+				require.NoError(t, err, string(output))
+				return strings.TrimSpace(string(output))
+			}
+			gitCommand("init", "-q")
+			gitCommand("config", "uploadpack.allowAnySHA1InWant", "true")
+			gitCommand("config", "uploadpack.allowFilter", strconv.FormatBool(allowFilter))
+			require.NoError(t, os.MkdirAll(path.Join(remoteDir, "app"), 0755))
+			require.NoError(t, os.MkdirAll(path.Join(remoteDir, "other"), 0755))
+			require.NoError(t, os.WriteFile(path.Join(remoteDir, "app", "main.go"), []byte("package main"), 0644))
+			require.NoError(t, os.WriteFile(path.Join(remoteDir, "other", "large.bin"), []byte("not needed"), 0644))
+			gitCommand("add", ".")
+			gitCommand("commit", "-q", "-m", "initial")
+
+			return "file://" + remoteDir, gitCommand("rev-parse", "HEAD"), plumbing.NewHash(gitCommand("rev-parse", "HEAD:other/large.bin"))
+		}
+
+		it("only fetches the blobs of the sparse checkout paths from a remote that filters objects", func() {
+			gitURL, commit, otherBlob := localRemote(true)
+
+			fetcher.SparseCheckoutPaths = []string{"app"}
+			err := fetcher.Fetch(testDir, gitURL, commit, metadataDir)
+			require.NoError(t, err)
+
+			require.FileExists(t, path.Join(testDir, "app", "main.go"))
+			require.NoFileExists(t, path.Join(testDir, "other", "large.bin"))
+
+			repository, err := gogit.PlainOpen(testDir)
+			require.NoError(t, err)
+			_, err = repository.BlobObject(otherBlob)
+			require.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+		})
+
+		it("fetches every blob for a sparse checkout from a remote that does not filter objects", func() {
+			gitURL, commit, otherBlob := localRemote(false)
+
+			fetcher.SparseCheckoutPaths = []string{"app"}
+			err := fetcher.Fetch(testDir, gitURL, commit, metadataDir)
+			require.NoError(t, err)
+
+			require.FileExists(t, path.Join(testDir, "app", "main.go"))
+			require.NoFileExists(t, path.Join(testDir, "other", "large.bin"))
+
+			repository, err := gogit.PlainOpen(testDir)
+			require.NoError(t, err)
+			_, err = repository.BlobObject(otherBlob)
+			require.NoError(t, err)
+		})
+
 		it("initializes submodules", func() {
 			fetcher.InitializeSubmodules = true
 			err := fetcher.Fetch(testDir, "https://github.com/git-fixtures/submodule", "master", metadataDir)
@@ -141,6 +219,13 @@ func testGitCheckout(t *testing.T, when spec.G, it spec.S) {
 	})
 }
 
+func TestSparseCheckoutDirectories(t *testing.T) {
+	require.Equal(t, []string{"go/", "some/path/"}, sparseCheckoutDirectories([]string{"go", "/some/path/"}))
+	require.Nil(t, sparseCheckoutDirectories([]string{"go", ""}))
+	require.Nil(t, sparseCheckoutDirectories([]string{"/"}))
+	require.Nil(t, sparseCheckoutDirectories(nil))
+}
+
 func isExecutableByAny(mode os.FileMode) bool {
 	return mode&0111 != 0
 }
@@ -0,0 +1,491 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	giturls "github.com/chainguard-dev/git-urls"
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/plumbing/format/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	lfsMediaType      = "application/vnd.git-lfs+json"
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	lfsMaxPointerSize = 1024
+	lfsConfigFile     = ".lfsconfig"
+	// lfsBatchSize is the most objects the lfs servers accept in a batch request
+	lfsBatchSize = 100
+)
+
+type lfsPointer struct {
+	path string
+	mode os.FileMode
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type lfsBatchRequest struct {
+	Operation string        `json:"operation"`
+	Transfers []string      `json:"transfers"`
+	Objects   []*lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	Oid     string `json:"oid"`
+	Size    int64  `json:"size"`
+	Actions struct {
+		Download *struct {
+			Href   string            `json:"href"`
+			Header map[string]string `json:"header"`
+		} `json:"download"`
+	} `json:"actions"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// lfsSSHAuthenticateResponse is the output of git-lfs-authenticate, the endpoint and headers of the lfs requests
+// for a repository cloned over ssh
+type lfsSSHAuthenticateResponse struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+// lfsCredentials authenticate the requests to the lfs endpoint with the keychain auth or the headers from
+// git-lfs-authenticate
+type lfsCredentials struct {
+	auth   transport.AuthMethod
+	header map[string]string
+}
+
+func (c lfsCredentials) set(req *http.Request) {
+	if httpAuth, ok := c.auth.(interface{ SetAuth(r *http.Request) }); ok {
+		httpAuth.SetAuth(req)
+	}
+	for k, v := range c.header {
+		req.Header.Set(k, v)
+	}
+}
+
+// lfsFetcher replaces Git LFS pointer files in a checked out repository with their content
+// using the basic transfer adapter of the LFS batch API.
+type lfsFetcher struct {
+	Logger   *log.Logger
+	Keychain GitKeychain
+	Client   *http.Client
+	// Dirs limits the pointers replaced to the directories of a sparse checkout
+	Dirs []string
+}
+
+func (l *lfsFetcher) Fetch(repository *gogit.Repository, dir, gitURL string) error {
+	pointers, err := lfsPointers(repository, l.Dirs)
+	if err != nil {
+		return errors.Wrap(err, "finding lfs pointers")
+	}
+
+	if len(pointers) == 0 {
+		return nil
+	}
+
+	endpoint, creds, err := l.resolve(dir, gitURL)
+	if err != nil {
+		return err
+	}
+
+	l.Logger.Printf("Downloading %d Git LFS objects from %q...", len(pointers), endpoint)
+
+	objects, err := l.batch(endpoint, creds, pointers)
+	if err != nil {
+		return err
+	}
+
+	byOid := map[string][]*lfsPointer{}
+	for _, p := range pointers {
+		byOid[p.Oid] = append(byOid[p.Oid], p)
+	}
+
+	for _, obj := range objects {
+		if obj.Error != nil {
+			return errors.Errorf("lfs object %s: %s", obj.Oid, obj.Error.Message)
+		}
+		if obj.Actions.Download == nil {
+			return errors.Errorf("lfs object %s: no download action", obj.Oid)
+		}
+
+		for _, p := range byOid[obj.Oid] {
+			err := l.download(endpoint, creds, obj.Actions.Download.Href, obj.Actions.Download.Header, filepath.Join(dir, p.path), p)
+			if err != nil {
+				return errors.Wrapf(err, "downloading lfs object for %q", p.path)
+			}
+		}
+		delete(byOid, obj.Oid)
+	}
+
+	if len(byOid) > 0 {
+		return errors.Errorf("%d lfs objects missing from batch response", len(byOid))
+	}
+
+	return nil
+}
+
+// resolve returns the lfs endpoint and its credentials. Repositories cloned over ssh get the endpoint and credentials
+// from git-lfs-authenticate, unless the endpoint is set in .lfsconfig.
+func (l *lfsFetcher) resolve(dir, gitURL string) (string, lfsCredentials, error) {
+	endpoint, err := lfsConfigURL(dir)
+	if err != nil {
+		return "", lfsCredentials{}, err
+	}
+
+	if endpoint == "" {
+		parsed, err := giturls.Parse(gitURL)
+		if err != nil {
+			return "", lfsCredentials{}, errors.Wrapf(err, "parsing git url %q", gitURL)
+		}
+
+		if parsed.Scheme == "ssh" {
+			auth, err := l.Keychain.Resolve(gitURL)
+			if err != nil {
+				return "", lfsCredentials{}, err
+			}
+
+			if sshAuth, ok := auth.(gitssh.AuthMethod); ok {
+				return lfsSSHAuthenticate(parsed, sshAuth)
+			}
+		}
+
+		endpoint, err = lfsEndpoint(gitURL)
+		if err != nil {
+			return "", lfsCredentials{}, err
+		}
+	}
+
+	auth, err := l.Keychain.Resolve(endpoint)
+	if err != nil {
+		return "", lfsCredentials{}, err
+	}
+	return endpoint, lfsCredentials{auth: auth}, nil
+}
+
+// batch requests the download actions of the unique pointers, lfsBatchSize objects at a time
+func (l *lfsFetcher) batch(endpoint string, creds lfsCredentials, pointers []*lfsPointer) ([]lfsBatchObject, error) {
+	uniquePointers := make([]*lfsPointer, 0, len(pointers))
+	seen := map[string]struct{}{}
+	for _, p := range pointers {
+		if _, ok := seen[p.Oid]; ok {
+			continue
+		}
+		seen[p.Oid] = struct{}{}
+		uniquePointers = append(uniquePointers, p)
+	}
+
+	var objects []lfsBatchObject
+	for start := 0; start < len(uniquePointers); start += lfsBatchSize {
+		end := start + lfsBatchSize
+		if end > len(uniquePointers) {
+			end = len(uniquePointers)
+		}
+
+		response, err := l.batchRequest(endpoint, creds, uniquePointers[start:end])
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, response.Objects...)
+	}
+	return objects, nil
+}
+
+func (l *lfsFetcher) batchRequest(endpoint string, creds lfsCredentials, pointers []*lfsPointer) (*lfsBatchResponse, error) {
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   pointers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	creds.set(req)
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "lfs batch request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, errors.Wrapf(transport.ErrAuthenticationRequired, "lfs batch request")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("lfs batch request: unexpected status %d", resp.StatusCode)
+	}
+
+	var response lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err, "decoding lfs batch response")
+	}
+	return &response, nil
+}
+
+func (l *lfsFetcher) download(endpoint string, creds lfsCredentials, href string, header map[string]string, dest string, pointer *lfsPointer) error {
+	req, err := http.NewRequest(http.MethodGet, href, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(header) == 0 && sameHost(endpoint, href) {
+		creds.set(req)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".lfs-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if size != pointer.Size || hex.EncodeToString(hash.Sum(nil)) != pointer.Oid {
+		return errors.New("content does not match lfs pointer")
+	}
+
+	if err := os.Chmod(tmp.Name(), pointer.mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+// lfsPointers returns the files in the HEAD commit that are Git LFS pointers. Only the blobs of files in the sparse
+// checkout directories are read, as the other blobs are not fetched by a sparse checkout.
+func lfsPointers(repository *gogit.Repository, dirs []string) ([]*lfsPointer, error) {
+	head, err := repository.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	var pointers []*lfsPointer
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			return pointers, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if !entry.Mode.IsFile() || (len(dirs) > 0 && !inDirectories(name, dirs)) {
+			continue
+		}
+
+		blob, err := object.GetBlob(repository.Storer, entry.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if blob.Size > lfsMaxPointerSize {
+			continue
+		}
+
+		contents, err := blobContents(blob)
+		if err != nil {
+			return nil, err
+		}
+
+		pointer, ok := parseLFSPointer(contents)
+		if !ok {
+			continue
+		}
+
+		mode, err := entry.Mode.ToOSFileMode()
+		if err != nil {
+			return nil, err
+		}
+
+		pointer.path = name
+		pointer.mode = mode
+		pointers = append(pointers, pointer)
+	}
+}
+
+func blobContents(blob *object.Blob) (string, error) {
+	reader, err := blob.Reader()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	contents, err := io.ReadAll(reader)
+	return string(contents), err
+}
+
+func parseLFSPointer(contents string) (*lfsPointer, bool) {
+	if !strings.HasPrefix(contents, lfsPointerVersion+"\n") {
+		return nil, false
+	}
+
+	pointer := &lfsPointer{Size: -1}
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), " ")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "oid":
+			pointer.Oid = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			pointer.Size = size
+		}
+	}
+
+	if len(pointer.Oid) != sha256.Size*2 || pointer.Size < 0 {
+		return nil, false
+	}
+	return pointer, true
+}
+
+// lfsConfigURL returns the lfs.url from the repository .lfsconfig, it is empty if the url is not set
+func lfsConfigURL(dir string) (string, error) {
+	file, err := os.Open(filepath.Join(dir, lfsConfigFile))
+	if err != nil {
+		return "", nil
+	}
+	defer file.Close()
+
+	cfg := gitconfig.New()
+	if err := gitconfig.NewDecoder(file).Decode(cfg); err != nil {
+		return "", errors.Wrapf(err, "parsing %s", lfsConfigFile)
+	}
+	return strings.TrimSuffix(cfg.Section("lfs").Option("url"), "/"), nil
+}
+
+// lfsEndpoint derives the default endpoint <repository>.git/info/lfs from the git url.
+func lfsEndpoint(gitURL string) (string, error) {
+	parsed, err := giturls.Parse(gitURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing git url %q", gitURL)
+	}
+
+	scheme, host := parsed.Scheme, parsed.Host
+	if scheme != "http" && scheme != "https" {
+		scheme, host = "https", parsed.Hostname()
+	}
+
+	path := strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	return fmt.Sprintf("%s://%s/%s.git/info/lfs", scheme, host, path), nil
+}
+
+// lfsSSHAuthenticate runs git-lfs-authenticate on the ssh remote for the lfs endpoint and the headers that
+// authenticate its requests
+func lfsSSHAuthenticate(gitURL *url.URL, auth gitssh.AuthMethod) (string, lfsCredentials, error) {
+	config, err := auth.ClientConfig()
+	if err != nil {
+		return "", lfsCredentials{}, err
+	}
+	if gitURL.User != nil && gitURL.User.Username() != "" {
+		config.User = gitURL.User.Username()
+	}
+
+	port := gitURL.Port()
+	if port == "" {
+		port = "22"
+	}
+
+	client, err := ssh.Dial("tcp", net.JoinHostPort(gitURL.Hostname(), port), config)
+	if err != nil {
+		return "", lfsCredentials{}, errors.Wrapf(err, "connecting to %s for git-lfs-authenticate", gitURL.Host)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", lfsCredentials{}, err
+	}
+	defer session.Close()
+
+	output, err := session.Output(fmt.Sprintf("git-lfs-authenticate %s download", strings.TrimPrefix(gitURL.Path, "/")))
+	if err != nil {
+		return "", lfsCredentials{}, errors.Wrap(err, "running git-lfs-authenticate")
+	}
+
+	var response lfsSSHAuthenticateResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return "", lfsCredentials{}, errors.Wrap(err, "decoding git-lfs-authenticate response")
+	}
+	if response.Href == "" {
+		return "", lfsCredentials{}, errors.New("git-lfs-authenticate did not return an lfs endpoint")
+	}
+
+	return strings.TrimSuffix(response.Href, "/"), lfsCredentials{header: response.Header}, nil
+}
+
+func sameHost(a, b string) bool {
+	urlA, errA := url.Parse(a)
+	urlB, errB := url.Parse(b)
+	return errA == nil && errB == nil && urlA.Host == urlB.Host
+}
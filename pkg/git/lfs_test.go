@@ -0,0 +1,408 @@
+package git
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestLFSFetcher(t *testing.T) {
+	spec.Run(t, "testLFSFetcher", testLFSFetcher)
+}
+
+func testLFSFetcher(t *testing.T, when spec.G, it spec.S) {
+	const largeContent = "some large binary content"
+
+	var (
+		repoDir       string
+		repository    *gogit.Repository
+		objects       map[string]string
+		batchRequests []lfsBatchRequest
+		authHeaders   []string
+		server        *httptest.Server
+		output        *bytes.Buffer
+		fetcher       *lfsFetcher
+	)
+
+	oidOf := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+
+	pointerFor := func(content string) string {
+		return fmt.Sprintf("%s\noid sha256:%s\nsize %d\n", lfsPointerVersion, oidOf(content), len(content))
+	}
+
+	commit := func(files map[string]string) {
+		t.Helper()
+		worktree, err := repository.Worktree()
+		require.NoError(t, err)
+
+		for name, contents := range files {
+			p := filepath.Join(repoDir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+			require.NoError(t, os.WriteFile(p, []byte(contents), 0644))
+			_, err = worktree.Add(name)
+			require.NoError(t, err)
+		}
+
+		_, err = worktree.Commit("some-commit", &gogit.CommitOptions{
+			Author: &object.Signature{Name: "some-author", Email: "author@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+
+	it.Before(func() {
+		repoDir = t.TempDir()
+		objects = map[string]string{oidOf(largeContent): largeContent}
+		batchRequests = nil
+		authHeaders = nil
+		output = &bytes.Buffer{}
+
+		var err error
+		repository, err = gogit.PlainInit(repoDir, false)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/some-org/some-repo.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+			authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+			require.Equal(t, lfsMediaType, r.Header.Get("Content-Type"))
+
+			var batchRequest lfsBatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&batchRequest))
+			batchRequests = append(batchRequests, batchRequest)
+
+			var response []map[string]interface{}
+			for _, obj := range batchRequest.Objects {
+				response = append(response, map[string]interface{}{
+					"oid":  obj.Oid,
+					"size": obj.Size,
+					"actions": map[string]interface{}{
+						"download": map[string]interface{}{
+							"href":   server.URL + "/objects/" + obj.Oid,
+							"header": map[string]string{"X-Some-Header": "some-value"},
+						},
+					},
+				})
+			}
+
+			w.Header().Set("Content-Type", lfsMediaType)
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"objects": response}))
+		})
+		mux.HandleFunc("/objects/", func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "some-value", r.Header.Get("X-Some-Header"))
+
+			content, ok := objects[filepath.Base(r.URL.Path)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(content))
+		})
+		server = httptest.NewServer(mux)
+
+		fetcher = &lfsFetcher{
+			Logger:   log.New(output, "", 0),
+			Keychain: lfsKeychain{auth: &githttp.BasicAuth{Username: "some-user", Password: "some-password"}},
+			Client:   server.Client(),
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	it("replaces pointer files with their content", func() {
+		commit(map[string]string{
+			"assets/large.bin": pointerFor(largeContent),
+			"copy.bin":         pointerFor(largeContent),
+			"README.md":        "readme",
+		})
+
+		err := fetcher.Fetch(repository, repoDir, server.URL+"/some-org/some-repo")
+		require.NoError(t, err)
+
+		contents, err := os.ReadFile(filepath.Join(repoDir, "assets", "large.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, largeContent, string(contents))
+
+		contents, err = os.ReadFile(filepath.Join(repoDir, "copy.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, largeContent, string(contents))
+
+		contents, err = os.ReadFile(filepath.Join(repoDir, "README.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "readme", string(contents))
+
+		require.Len(t, batchRequests, 1)
+		assert.Equal(t, "download", batchRequests[0].Operation)
+		require.Len(t, batchRequests[0].Objects, 1)
+		assert.Equal(t, oidOf(largeContent), batchRequests[0].Objects[0].Oid)
+		assert.Equal(t, int64(len(largeContent)), batchRequests[0].Objects[0].Size)
+
+		assert.Equal(t, []string{"Basic c29tZS11c2VyOnNvbWUtcGFzc3dvcmQ="}, authHeaders)
+		assert.Contains(t, output.String(), "Downloading 2 Git LFS objects")
+	})
+
+	it("uses the lfs url from .lfsconfig", func() {
+		commit(map[string]string{
+			"large.bin":  pointerFor(largeContent),
+			".lfsconfig": fmt.Sprintf("[lfs]\n\turl = %s/some-org/some-repo.git/info/lfs\n", server.URL),
+		})
+
+		err := fetcher.Fetch(repository, repoDir, "git@github.com:other-org/other-repo.git")
+		require.NoError(t, err)
+
+		contents, err := os.ReadFile(filepath.Join(repoDir, "large.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, largeContent, string(contents))
+	})
+
+	it("only replaces pointers in the sparse checkout directories", func() {
+		commit(map[string]string{
+			"app/large.bin":   pointerFor(largeContent),
+			"other/large.bin": pointerFor("other content"),
+		})
+		fetcher.Dirs = []string{"app/"}
+
+		err := fetcher.Fetch(repository, repoDir, server.URL+"/some-org/some-repo")
+		require.NoError(t, err)
+
+		require.Len(t, batchRequests, 1)
+		require.Len(t, batchRequests[0].Objects, 1)
+		assert.Equal(t, oidOf(largeContent), batchRequests[0].Objects[0].Oid)
+	})
+
+	it("only reads the blobs of the sparse checkout directories of a filtered fetch", func() {
+		remoteDir := t.TempDir()
+		gitCommand := func(args ...string) string {
+			output, err := exec.Command("git", append([]string{"-C", remoteDir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...).CombinedOutput()
+			require.NoError(t, err, string(output))
+			return strings.TrimSpace(string(output))
+		}
+		gitCommand("init", "-q")
+		gitCommand("config", "uploadpack.allowAnySHA1InWant", "true")
+		gitCommand("config", "uploadpack.allowFilter", "true")
+		require.NoError(t, os.MkdirAll(filepath.Join(remoteDir, "app"), 0755))
+		require.NoError(t, os.MkdirAll(filepath.Join(remoteDir, "other"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(remoteDir, "app", "large.bin"), []byte(pointerFor(largeContent)), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(remoteDir, "other", "large.bin"), []byte(pointerFor("other content")), 0644))
+		gitCommand("add", ".")
+		gitCommand("commit", "-q", "-m", "initial")
+		hash := plumbing.NewHash(gitCommand("rev-parse", "HEAD"))
+
+		dirs := []string{"app/"}
+		fetched, err := fetchSparse(repository.Storer, "file://"+remoteDir, nil, hash, dirs)
+		require.NoError(t, err)
+		require.True(t, fetched)
+		_, err = repository.BlobObject(plumbing.NewHash(gitCommand("rev-parse", "HEAD:other/large.bin")))
+		require.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+
+		worktree, err := repository.Worktree()
+		require.NoError(t, err)
+		require.NoError(t, worktree.Checkout(&gogit.CheckoutOptions{Hash: hash, SparseCheckoutDirectories: dirs}))
+
+		fetcher.Dirs = dirs
+		err = fetcher.Fetch(repository, repoDir, server.URL+"/some-org/some-repo")
+		require.NoError(t, err)
+
+		contents, err := os.ReadFile(filepath.Join(repoDir, "app", "large.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, largeContent, string(contents))
+
+		require.Len(t, batchRequests, 1)
+		require.Len(t, batchRequests[0].Objects, 1)
+		assert.Equal(t, oidOf(largeContent), batchRequests[0].Objects[0].Oid)
+	})
+
+	it("does not contact the lfs server without pointer files", func() {
+		commit(map[string]string{"README.md": "readme"})
+
+		err := fetcher.Fetch(repository, repoDir, server.URL+"/some-org/some-repo")
+		require.NoError(t, err)
+		assert.Empty(t, batchRequests)
+	})
+
+	it("errors when the content does not match the pointer", func() {
+		commit(map[string]string{"large.bin": pointerFor(largeContent)})
+		objects[oidOf(largeContent)] = "something else"
+
+		err := fetcher.Fetch(repository, repoDir, server.URL+"/some-org/some-repo")
+		require.ErrorContains(t, err, "content does not match lfs pointer")
+	})
+
+	it("requests at most 100 objects in a batch", func() {
+		files := map[string]string{}
+		for i := 0; i < 150; i++ {
+			content := fmt.Sprintf("large content %d", i)
+			objects[oidOf(content)] = content
+			files[fmt.Sprintf("large-%d.bin", i)] = pointerFor(content)
+		}
+		commit(files)
+
+		err := fetcher.Fetch(repository, repoDir, server.URL+"/some-org/some-repo")
+		require.NoError(t, err)
+
+		require.Len(t, batchRequests, 2)
+		assert.Len(t, batchRequests[0].Objects, 100)
+		assert.Len(t, batchRequests[1].Objects, 50)
+
+		contents, err := os.ReadFile(filepath.Join(repoDir, "large-149.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, "large content 149", string(contents))
+	})
+
+	when("the repository is cloned over ssh", func() {
+		var (
+			sshAddr     string
+			sshCommands []string
+		)
+
+		it.Before(func() {
+			sshCommands = nil
+			sshAddr = lfsSSHServer(t, func(command string) string {
+				sshCommands = append(sshCommands, command)
+				return fmt.Sprintf(`{"href": %q, "header": {"Authorization": "RemoteAuth some-token"}}`, server.URL+"/some-org/some-repo.git/info/lfs")
+			})
+
+			keys, err := gitssh.NewPublicKeys("git", lfsSSHPrivateKey(t), "")
+			require.NoError(t, err)
+			keys.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+			fetcher.Keychain = lfsKeychain{auth: keys}
+		})
+
+		it("authenticates with git-lfs-authenticate", func() {
+			commit(map[string]string{"large.bin": pointerFor(largeContent)})
+
+			err := fetcher.Fetch(repository, repoDir, fmt.Sprintf("ssh://git@%s/some-org/some-repo.git", sshAddr))
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"git-lfs-authenticate some-org/some-repo.git download"}, sshCommands)
+			assert.Equal(t, []string{"RemoteAuth some-token"}, authHeaders)
+
+			contents, err := os.ReadFile(filepath.Join(repoDir, "large.bin"))
+			require.NoError(t, err)
+			assert.Equal(t, largeContent, string(contents))
+		})
+	})
+
+	when("lfsEndpoint", func() {
+		it("derives the endpoint from the git url", func() {
+			for gitURL, expected := range map[string]string{
+				"https://github.com/some-org/some-repo":      "https://github.com/some-org/some-repo.git/info/lfs",
+				"https://github.com/some-org/some-repo.git":  "https://github.com/some-org/some-repo.git/info/lfs",
+				"git@github.com:some-org/some-repo.git":      "https://github.com/some-org/some-repo.git/info/lfs",
+				"ssh://git@github.com:22/some-org/some-repo": "https://github.com/some-org/some-repo.git/info/lfs",
+			} {
+				endpoint, err := lfsEndpoint(gitURL)
+				require.NoError(t, err)
+				assert.Equal(t, expected, endpoint, gitURL)
+			}
+		})
+	})
+}
+
+// lfsSSHServer serves ssh exec requests with the output of the handler and returns the address of the server
+func lfsSSHServer(t *testing.T, handler func(command string) string) string {
+	t.Helper()
+
+	hostKey, err := ssh.ParsePrivateKey(lfsSSHPrivateKey(t))
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				_, channels, requests, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(requests)
+
+				for newChannel := range channels {
+					channel, channelRequests, err := newChannel.Accept()
+					if err != nil {
+						return
+					}
+
+					for req := range channelRequests {
+						if req.Type != "exec" {
+							_ = req.Reply(false, nil)
+							continue
+						}
+
+						var payload struct{ Command string }
+						_ = ssh.Unmarshal(req.Payload, &payload)
+						_ = req.Reply(true, nil)
+
+						_, _ = channel.Write([]byte(handler(payload.Command)))
+						_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+						_ = channel.Close()
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func lfsSSHPrivateKey(t *testing.T) []byte {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	require.NoError(t, err)
+	return pem.EncodeToMemory(block)
+}
+
+type lfsKeychain struct {
+	auth transport.AuthMethod
+}
+
+func (k lfsKeychain) Resolve(string) (transport.AuthMethod, error) {
+	return k.auth, nil
+}
@@ -0,0 +1,38 @@
+package git
+
+import (
+	ignore "github.com/sabhiram/go-gitignore"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// pathFilter matches repository paths against the gitignore style include and exclude patterns of a source
+type pathFilter struct {
+	include *ignore.GitIgnore
+	exclude *ignore.GitIgnore
+}
+
+func newPathFilter(sourceConfig corev1alpha1.SourceConfig) *pathFilter {
+	filter := &pathFilter{}
+	if len(sourceConfig.IncludePaths) > 0 {
+		filter.include = ignore.CompileIgnoreLines(sourceConfig.IncludePaths...)
+	}
+	if len(sourceConfig.ExcludePaths) > 0 {
+		filter.exclude = ignore.CompileIgnoreLines(sourceConfig.ExcludePaths...)
+	}
+	return filter
+}
+
+// Relevant returns true if any of the paths is included and not excluded
+func (f *pathFilter) Relevant(paths []string) bool {
+	for _, p := range paths {
+		if f.include != nil && !f.include.MatchesPath(p) {
+			continue
+		}
+		if f.exclude != nil && f.exclude.MatchesPath(p) {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -35,15 +35,7 @@ func (r *remoteGitResolver) ResolveByListingRemote(auth transport.AuthMethod, so
 		Auth: auth,
 	})
 	if err != nil {
-		return corev1alpha1.ResolvedSourceConfig{
-			Git: &corev1alpha1.ResolvedGitSource{
-				URL:                  sourceConfig.Git.URL,
-				Revision:             sourceConfig.Git.Revision,
-				Type:                 corev1alpha1.Unknown,
-				SubPath:              sourceConfig.SubPath,
-				InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
-			},
-		}, nil
+		return unknownResolvedSource(sourceConfig), nil
 	}
 
 	for _, ref := range refs {
@@ -55,11 +47,46 @@ func (r *remoteGitResolver) ResolveByListingRemote(auth transport.AuthMethod, so
 					Type:                 sourceType(ref),
 					SubPath:              sourceConfig.SubPath,
 					InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
+					LFS:                  sourceConfig.Git.LFS,
+					SparseCheckout:       sourceConfig.Git.SparseCheckout,
+					SparseCheckoutPaths:  sourceConfig.Git.SparseCheckoutPaths,
+					Verification:         sourceConfig.Git.Verification,
 				},
 			}, nil
 		}
 	}
 
+	if selector, ok := ParseRevisionSelector(sourceConfig.Git.Revision); ok {
+		tagRefs := map[string]*plumbing.Reference{}
+		var tags []string
+		for _, ref := range refs {
+			if ref.Name().IsTag() {
+				tagRefs[ref.Name().Short()] = ref
+				tags = append(tags, ref.Name().Short())
+			}
+		}
+
+		tag, found := selector.Select(tags)
+		if !found {
+			return noMatchingTagResolvedSource(sourceConfig), nil
+		}
+
+		return corev1alpha1.ResolvedSourceConfig{
+			Git: &corev1alpha1.ResolvedGitSource{
+				URL:                  sourceConfig.Git.URL,
+				Revision:             tagRefs[tag].Hash().String(),
+				Tag:                  tag,
+				Type:                 corev1alpha1.Tag,
+				SubPath:              sourceConfig.SubPath,
+				InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
+				LFS:                  sourceConfig.Git.LFS,
+				SparseCheckout:       sourceConfig.Git.SparseCheckout,
+				SparseCheckoutPaths:  sourceConfig.Git.SparseCheckoutPaths,
+				Verification:         sourceConfig.Git.Verification,
+			},
+		}, nil
+	}
+
 	return corev1alpha1.ResolvedSourceConfig{
 		Git: &corev1alpha1.ResolvedGitSource{
 			URL:                  sourceConfig.Git.URL,
@@ -67,10 +94,47 @@ func (r *remoteGitResolver) ResolveByListingRemote(auth transport.AuthMethod, so
 			Type:                 corev1alpha1.Commit,
 			SubPath:              sourceConfig.SubPath,
 			InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
+			LFS:                  sourceConfig.Git.LFS,
+			SparseCheckout:       sourceConfig.Git.SparseCheckout,
+			SparseCheckoutPaths:  sourceConfig.Git.SparseCheckoutPaths,
+			Verification:         sourceConfig.Git.Verification,
 		},
 	}, nil
 }
 
+func unknownResolvedSource(sourceConfig corev1alpha1.SourceConfig) corev1alpha1.ResolvedSourceConfig {
+	return corev1alpha1.ResolvedSourceConfig{
+		Git: &corev1alpha1.ResolvedGitSource{
+			URL:                  sourceConfig.Git.URL,
+			Revision:             sourceConfig.Git.Revision,
+			Type:                 corev1alpha1.Unknown,
+			SubPath:              sourceConfig.SubPath,
+			InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
+			LFS:                  sourceConfig.Git.LFS,
+			SparseCheckout:       sourceConfig.Git.SparseCheckout,
+			SparseCheckoutPaths:  sourceConfig.Git.SparseCheckoutPaths,
+			Verification:         sourceConfig.Git.Verification,
+		},
+	}
+}
+
+// noMatchingTagResolvedSource keeps polling a revision selector that does not match a tag yet. It has no revision, so no
+// build is created until a matching tag is pushed.
+func noMatchingTagResolvedSource(sourceConfig corev1alpha1.SourceConfig) corev1alpha1.ResolvedSourceConfig {
+	return corev1alpha1.ResolvedSourceConfig{
+		Git: &corev1alpha1.ResolvedGitSource{
+			URL:                  sourceConfig.Git.URL,
+			Type:                 corev1alpha1.Tag,
+			SubPath:              sourceConfig.SubPath,
+			InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
+			LFS:                  sourceConfig.Git.LFS,
+			SparseCheckout:       sourceConfig.Git.SparseCheckout,
+			SparseCheckoutPaths:  sourceConfig.Git.SparseCheckoutPaths,
+			Verification:         sourceConfig.Git.Verification,
+		},
+	}
+}
+
 func sourceType(reference *plumbing.Reference) corev1alpha1.GitSourceKind {
 	switch {
 	case reference.Name().IsBranch():
@@ -20,6 +20,8 @@ const commitSHARegex = `^[a-f0-9]{40}$`
 
 var commitSHAValidator = regexp.MustCompile(commitSHARegex)
 
+var errNoMatchingTag = errors.New("no tags match the revision selector")
+
 func (r *remoteGitResolver) ResolveByCloning(auth transport.AuthMethod, sourceConfig corev1alpha1.SourceConfig) (corev1alpha1.ResolvedSourceConfig, error) {
 	emptyResult := corev1alpha1.ResolvedSourceConfig{
 		Git: &corev1alpha1.ResolvedGitSource{
@@ -28,6 +30,10 @@ func (r *remoteGitResolver) ResolveByCloning(auth transport.AuthMethod, sourceCo
 			Type:                 corev1alpha1.Unknown,
 			SubPath:              sourceConfig.SubPath,
 			InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
+			LFS:                  sourceConfig.Git.LFS,
+			SparseCheckout:       sourceConfig.Git.SparseCheckout,
+			SparseCheckoutPaths:  sourceConfig.Git.SparseCheckoutPaths,
+			Verification:         sourceConfig.Git.Verification,
 		},
 	}
 	// git init --bare
@@ -44,15 +50,27 @@ func (r *remoteGitResolver) ResolveByCloning(auth transport.AuthMethod, sourceCo
 		return emptyResult, fmt.Errorf("creating remote: %w", err)
 	}
 
+	revision := sourceConfig.Git.Revision
+	var selectedTag string
+	if selector, ok := ParseRevisionSelector(revision); ok {
+		selectedTag, err = selectRemoteTag(repository, auth, selector)
+		if errors.Is(err, errNoMatchingTag) {
+			return noMatchingTagResolvedSource(sourceConfig), nil
+		} else if err != nil {
+			return emptyResult, fmt.Errorf("revision \"%s\": %w", revision, err)
+		}
+		revision = "refs/tags/" + selectedTag
+	}
+
 	input := fetchResolverInput{
 		repository: repository,
 		auth:       auth,
-		revision:   sourceConfig.Git.Revision,
+		revision:   revision,
 	}
 	var output *fetchResolverOutput
 	errs := []error{}
 
-	for _, resolver := range relevantFetchResolversFor(sourceConfig.Git.Revision) {
+	for _, resolver := range relevantFetchResolversFor(revision) {
 		output, err = resolver(input)
 		if err != nil {
 			errs = append(errs, err)
@@ -77,10 +95,42 @@ func (r *remoteGitResolver) ResolveByCloning(auth transport.AuthMethod, sourceCo
 			Type:                 output.kind,
 			SubPath:              sourceConfig.SubPath,
 			InitializeSubmodules: sourceConfig.Git.InitializeSubmodules,
+			LFS:                  sourceConfig.Git.LFS,
+			SparseCheckout:       sourceConfig.Git.SparseCheckout,
+			SparseCheckoutPaths:  sourceConfig.Git.SparseCheckoutPaths,
+			Verification:         sourceConfig.Git.Verification,
+			Tag:                  selectedTag,
 		},
 	}, nil
 }
 
+func selectRemoteTag(repository *gogit.Repository, auth transport.AuthMethod, selector *RevisionSelector) (string, error) {
+	remote, err := repository.Remote(defaultRemote)
+	if err != nil {
+		return "", fmt.Errorf("remote: %w", err)
+	}
+
+	refs, err := remote.List(&gogit.ListOptions{
+		Auth: auth,
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing references: %w", err)
+	}
+
+	var tags []string
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags = append(tags, ref.Name().Short())
+		}
+	}
+
+	tag, found := selector.Select(tags)
+	if !found {
+		return "", errNoMatchingTag
+	}
+	return tag, nil
+}
+
 func hashOfSubpath(subPath string, hash plumbing.Hash, repository *gogit.Repository) string {
 	path := strings.Trim(subPath, "/")
 	if path == "" {
@@ -118,6 +118,61 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("source is a revision selector", func() {
+			it("returns the highest matching tag with resolved commit", func() {
+				tagsUrl := "https://github.com/git-fixtures/tags.git"
+
+				gitResolver := remoteGitResolver{featureFlags}
+
+				resolvedGitSource, err := gitResolver.Resolve(anonymousAuth, corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      tagsUrl,
+						Revision: "commit-*",
+					},
+					SubPath: "/tree",
+				})
+				require.NoError(t, err)
+				expected := corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      tagsUrl,
+						Revision: tagCommit,
+						Tag:      tag,
+						Type:     corev1alpha1.Tag,
+						SubPath:  "/tree",
+					},
+				}
+
+				if featureFlags.GitResolverUseShallowClone {
+					expected.Git.Tree = tagSubPathTree
+				}
+
+				assert.Equal(t, expected, resolvedGitSource)
+			})
+
+			it("returns a pollable source without a revision when no tag matches", func() {
+				tagsUrl := "https://github.com/git-fixtures/tags.git"
+
+				gitResolver := remoteGitResolver{featureFlags}
+
+				resolvedGitSource, err := gitResolver.Resolve(anonymousAuth, corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      tagsUrl,
+						Revision: "v2.*",
+					},
+					SubPath: "/tree",
+				})
+				require.NoError(t, err)
+				assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:     tagsUrl,
+						Type:    corev1alpha1.Tag,
+						SubPath: "/tree",
+					},
+				}, resolvedGitSource)
+				assert.True(t, resolvedGitSource.Git.IsPollable())
+			})
+		})
+
 		when("authentication fails", func() {
 			it("returns an unknown type", func() {
 				gitResolver := remoteGitResolver{featureFlags}
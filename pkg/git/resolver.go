@@ -3,7 +3,9 @@ package git
 import (
 	"context"
 
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	k8sclient "k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -31,7 +33,43 @@ func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceR
 		return corev1alpha1.ResolvedSourceConfig{}, err
 	}
 
-	return r.remoteGitResolver.Resolve(auth, sourceResolver.Spec.Source)
+	resolved, err := r.remoteGitResolver.Resolve(auth, sourceResolver.Spec.Source)
+	if err != nil {
+		return resolved, err
+	}
+
+	if sourceResolver.Spec.Source.HasPathFilters() {
+		r.retainRevisionIfNoRelevantChanges(ctx, auth, sourceResolver, resolved.Git)
+	}
+
+	return resolved, nil
+}
+
+// retainRevisionIfNoRelevantChanges keeps the previously resolved revision when none of the paths changed by the
+// new commit match the include and exclude paths of the source. The previous revision is always the last relevant
+// commit, so changes are compared against the revision that would have been built. The new revision is used if the
+// changed paths cannot be determined.
+func (r *Resolver) retainRevisionIfNoRelevantChanges(ctx context.Context, auth transport.AuthMethod, sourceResolver *buildapi.SourceResolver, resolved *corev1alpha1.ResolvedGitSource) {
+	previous := sourceResolver.Status.Source.Git
+	if previous == nil || sourceResolver.Status.ObservedGeneration != sourceResolver.Generation {
+		return
+	}
+
+	if resolved.IsUnknown() || previous.IsUnknown() || previous.URL != resolved.URL || previous.Revision == resolved.Revision {
+		return
+	}
+
+	paths, err := r.remoteGitResolver.changedPaths(auth, resolved.URL, previous.Revision, resolved.Revision)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Unable to determine the paths changed between %s and %s of %s, using the new revision: %v", previous.Revision, resolved.Revision, resolved.URL, err)
+		return
+	}
+
+	if !newPathFilter(sourceResolver.Spec.Source).Relevant(paths) {
+		resolved.Revision = previous.Revision
+		resolved.Tag = previous.Tag
+		resolved.Tree = previous.Tree
+	}
 }
 
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
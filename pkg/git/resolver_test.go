@@ -0,0 +1,161 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestResolver(t *testing.T) {
+	spec.Run(t, "testResolver", testResolver)
+}
+
+func testResolver(t *testing.T, when spec.G, it spec.S) {
+	var (
+		remoteDir string
+		remoteURL string
+		logs      *observer.ObservedLogs
+		ctx       context.Context
+		resolver  *Resolver
+	)
+
+	gitCommand := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", remoteDir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+
+	commit := func(files map[string]string) string {
+		t.Helper()
+		for name, contents := range files {
+			p := filepath.Join(remoteDir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+			require.NoError(t, os.WriteFile(p, []byte(contents), 0644))
+		}
+		gitCommand("add", ".")
+		gitCommand("commit", "-q", "-m", "some-commit")
+		return gitCommand("rev-parse", "HEAD")
+	}
+
+	sourceResolver := func(previousRevision string, include, exclude []string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Git:          &corev1alpha1.Git{URL: remoteURL, Revision: "main"},
+					IncludePaths: include,
+					ExcludePaths: exclude,
+				},
+			},
+			Status: buildapi.SourceResolverStatus{
+				Status: corev1alpha1.Status{ObservedGeneration: 1},
+				Source: corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      remoteURL,
+						Revision: previousRevision,
+						Tree:     "previous-tree",
+						Type:     corev1alpha1.Branch,
+					},
+				},
+			},
+		}
+	}
+
+	resolvedRevision := func(revision string) *corev1alpha1.ResolvedGitSource {
+		return &corev1alpha1.ResolvedGitSource{
+			URL:      remoteURL,
+			Revision: revision,
+			Tree:     "new-tree",
+			Type:     corev1alpha1.Branch,
+		}
+	}
+
+	it.Before(func() {
+		remoteDir = t.TempDir()
+		remoteURL = "file://" + remoteDir
+		gitCommand("init", "-q")
+		gitCommand("config", "uploadpack.allowAnySHA1InWant", "true")
+		gitCommand("config", "uploadpack.allowFilter", "true")
+
+		var core zapcore.Core
+		core, logs = observer.New(zap.WarnLevel)
+		ctx = logging.WithLogger(context.Background(), zap.New(core).Sugar())
+
+		resolver = &Resolver{}
+	})
+
+	when("#retainRevisionIfNoRelevantChanges", func() {
+		var first string
+
+		it.Before(func() {
+			first = commit(map[string]string{"services/api/main.go": "package main", "README.md": "readme"})
+		})
+
+		it("retains the previous revision when no included path changed", func() {
+			second := commit(map[string]string{"services/web/index.html": "<html/>"})
+
+			resolved := resolvedRevision(second)
+			resolver.retainRevisionIfNoRelevantChanges(ctx, nil, sourceResolver(first, []string{"services/api/"}, nil), resolved)
+
+			assert.Equal(t, first, resolved.Revision)
+			assert.Equal(t, "previous-tree", resolved.Tree)
+		})
+
+		it("uses the new revision when an included path changed", func() {
+			second := commit(map[string]string{"services/api/main.go": "package main // changed"})
+
+			resolved := resolvedRevision(second)
+			resolver.retainRevisionIfNoRelevantChanges(ctx, nil, sourceResolver(first, []string{"services/api/"}, nil), resolved)
+
+			assert.Equal(t, second, resolved.Revision)
+			assert.Equal(t, "new-tree", resolved.Tree)
+		})
+
+		it("retains the previous revision when only excluded paths changed", func() {
+			second := commit(map[string]string{"README.md": "new readme"})
+
+			resolved := resolvedRevision(second)
+			resolver.retainRevisionIfNoRelevantChanges(ctx, nil, sourceResolver(first, nil, []string{"*.md"}), resolved)
+
+			assert.Equal(t, first, resolved.Revision)
+		})
+
+		it("uses the new revision when a path that is not excluded changed", func() {
+			second := commit(map[string]string{"README.md": "new readme", "main.go": "package main"})
+
+			resolved := resolvedRevision(second)
+			resolver.retainRevisionIfNoRelevantChanges(ctx, nil, sourceResolver(first, nil, []string{"*.md"}), resolved)
+
+			assert.Equal(t, second, resolved.Revision)
+		})
+
+		it("uses the new revision and logs when the changed paths cannot be determined", func() {
+			second := commit(map[string]string{"README.md": "new readme"})
+			missing := "0123456789012345678901234567890123456789"
+
+			resolved := resolvedRevision(second)
+			resolver.retainRevisionIfNoRelevantChanges(ctx, nil, sourceResolver(missing, nil, []string{"*.md"}), resolved)
+
+			assert.Equal(t, second, resolved.Revision)
+			require.Equal(t, 1, logs.Len())
+			assert.Contains(t, logs.All()[0].Message, "Unable to determine the paths changed between "+missing+" and "+second)
+		})
+	})
+}
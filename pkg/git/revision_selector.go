@@ -0,0 +1,94 @@
+package git
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	globCharacters       = "*?["
+	constraintCharacters = "<>=~^!|,"
+)
+
+// RevisionSelector selects the highest tag matching a glob (v2.*) or a semver constraint (>=1.4 <2)
+type RevisionSelector struct {
+	glob       string
+	constraint *semver.Constraints
+}
+
+// ParseRevisionSelector returns a RevisionSelector if the revision is a glob or semver constraint
+// rather than an exact branch, tag or commit.
+func ParseRevisionSelector(revision string) (*RevisionSelector, bool) {
+	revision = strings.TrimSpace(revision)
+	if revision == "" {
+		return nil, false
+	}
+
+	if strings.ContainsAny(revision, globCharacters) {
+		if _, err := path.Match(revision, ""); err != nil {
+			return nil, false
+		}
+		return &RevisionSelector{glob: revision}, true
+	}
+
+	if !strings.ContainsAny(revision, constraintCharacters) && !strings.Contains(revision, " - ") {
+		return nil, false
+	}
+
+	constraint, err := semver.NewConstraint(revision)
+	if err != nil {
+		return nil, false
+	}
+	return &RevisionSelector{constraint: constraint}, true
+}
+
+func (s *RevisionSelector) Matches(tag string) bool {
+	if s.constraint != nil {
+		version, err := semver.NewVersion(tag)
+		return err == nil && s.constraint.Check(version)
+	}
+
+	matched, err := path.Match(s.glob, tag)
+	return err == nil && matched
+}
+
+// Select returns the highest matching tag. Tags are ordered by semantic version when possible
+// and lexically otherwise.
+func (s *RevisionSelector) Select(tags []string) (string, bool) {
+	var matching []string
+	for _, tag := range tags {
+		if s.Matches(tag) {
+			matching = append(matching, tag)
+		}
+	}
+
+	if len(matching) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return tagLess(matching[i], matching[j])
+	})
+	return matching[len(matching)-1], true
+}
+
+func tagLess(a, b string) bool {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	switch {
+	case errA == nil && errB == nil:
+		if va.Equal(vb) {
+			return a < b
+		}
+		return va.LessThan(vb)
+	case errA == nil:
+		return false
+	case errB == nil:
+		return true
+	default:
+		return a < b
+	}
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisionSelector(t *testing.T) {
+	spec.Run(t, "testRevisionSelector", testRevisionSelector)
+}
+
+func testRevisionSelector(t *testing.T, when spec.G, it spec.S) {
+	tags := []string{"v1.3.0", "v1.4.0", "v1.10.2", "v2.0.0", "v2.1.0-rc.1", "v2.0.1", "latest", "release-a", "release-b"}
+
+	when("ParseRevisionSelector", func() {
+		it("does not treat exact revisions as selectors", func() {
+			for _, revision := range []string{"main", "v1.0.0", "refs/heads/main", "6ecf0ef2c2dffb796033e5a02219af86ec6584e5", ""} {
+				_, ok := ParseRevisionSelector(revision)
+				assert.False(t, ok, revision)
+			}
+		})
+
+		it("parses globs and semver constraints", func() {
+			for _, revision := range []string{"v2.*", "release-?", ">=1.4 <2", "~1.4", "^1", "1.2 - 1.4", ">=1.0, <2.0"} {
+				_, ok := ParseRevisionSelector(revision)
+				assert.True(t, ok, revision)
+			}
+		})
+
+		it("rejects invalid selectors", func() {
+			_, ok := ParseRevisionSelector("v2.[")
+			assert.False(t, ok)
+
+			_, ok = ParseRevisionSelector(">=not-a-version")
+			assert.False(t, ok)
+		})
+	})
+
+	when("Select", func() {
+		it("selects the highest tag matching a semver constraint", func() {
+			selector, ok := ParseRevisionSelector(">=1.4 <2")
+			require.True(t, ok)
+
+			tag, found := selector.Select(tags)
+			require.True(t, found)
+			assert.Equal(t, "v1.10.2", tag)
+		})
+
+		it("ignores prereleases unless the constraint includes them", func() {
+			selector, ok := ParseRevisionSelector("^2")
+			require.True(t, ok)
+
+			tag, found := selector.Select(tags)
+			require.True(t, found)
+			assert.Equal(t, "v2.0.1", tag)
+		})
+
+		it("selects the highest tag matching a glob by semantic version", func() {
+			selector, ok := ParseRevisionSelector("v1.*")
+			require.True(t, ok)
+
+			tag, found := selector.Select(tags)
+			require.True(t, found)
+			assert.Equal(t, "v1.10.2", tag)
+		})
+
+		it("orders non semver tags lexically", func() {
+			selector, ok := ParseRevisionSelector("release-*")
+			require.True(t, ok)
+
+			tag, found := selector.Select(tags)
+			require.True(t, found)
+			assert.Equal(t, "release-b", tag)
+		})
+
+		it("returns false when no tags match", func() {
+			selector, ok := ParseRevisionSelector("v3.*")
+			require.True(t, ok)
+
+			_, found := selector.Select(tags)
+			assert.False(t, found)
+		})
+	})
+}
@@ -0,0 +1,161 @@
+package git
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/sideband"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/pkg/errors"
+)
+
+// fetchSparse fetches the commit and its trees without any blobs, and then only the blobs of the sparse checkout
+// directories. It returns false without fetching anything if the remote cannot filter the objects it sends.
+func fetchSparse(storer storage.Storer, gitURL string, auth transport.AuthMethod, hash plumbing.Hash, dirs []string) (bool, error) {
+	endpoint, err := transport.NewEndpoint(gitURL)
+	if err != nil {
+		return false, err
+	}
+
+	gitClient, err := client.NewClient(endpoint)
+	if err != nil {
+		return false, err
+	}
+
+	supported := false
+	err = uploadPack(gitClient, endpoint, auth, storer, func(capabilities *capability.List) (*packp.UploadPackRequest, error) {
+		if !capabilities.Supports(capability.Filter) ||
+			!(capabilities.Supports(capability.AllowReachableSHA1InWant) || capabilities.Supports(capability.AllowTipSHA1InWant)) {
+			return nil, nil
+		}
+		supported = true
+
+		req := packp.NewUploadPackRequestFromCapabilities(capabilities)
+		req.Wants = []plumbing.Hash{hash}
+		req.Depth = packp.DepthCommits(1)
+		req.Filter = packp.FilterBlobNone()
+		for _, c := range []capability.Capability{capability.Shallow, capability.Filter} {
+			if err := req.Capabilities.Set(c); err != nil {
+				return nil, err
+			}
+		}
+		return req, nil
+	})
+	if err != nil || !supported {
+		return false, err
+	}
+
+	if err := storer.SetShallow([]plumbing.Hash{hash}); err != nil {
+		return false, err
+	}
+
+	blobs, err := sparseBlobs(storer, hash, dirs)
+	if err != nil {
+		return false, err
+	}
+	if len(blobs) == 0 {
+		return true, nil
+	}
+
+	return true, uploadPack(gitClient, endpoint, auth, storer, func(capabilities *capability.List) (*packp.UploadPackRequest, error) {
+		req := packp.NewUploadPackRequestFromCapabilities(capabilities)
+		req.Wants = blobs
+		return req, nil
+	})
+}
+
+// uploadPack stores the objects of the request built from the capabilities of the remote, no request is made if the
+// request is nil
+func uploadPack(gitClient transport.Transport, endpoint *transport.Endpoint, auth transport.AuthMethod, storer storage.Storer, request func(*capability.List) (*packp.UploadPackRequest, error)) (err error) {
+	session, err := gitClient.NewUploadPackSession(endpoint, auth)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := session.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	advertised, err := session.AdvertisedReferences()
+	if err != nil {
+		return err
+	}
+
+	req, err := request(advertised.Capabilities)
+	if err != nil || req == nil {
+		return err
+	}
+
+	if advertised.Capabilities.Supports(capability.NoProgress) {
+		if err := req.Capabilities.Set(capability.NoProgress); err != nil {
+			return err
+		}
+	}
+
+	response, err := session.UploadPack(context.Background(), req)
+	if err != nil {
+		return errors.Wrap(err, "fetching objects")
+	}
+	defer response.Close()
+
+	var reader io.Reader = response
+	switch {
+	case req.Capabilities.Supports(capability.Sideband64k):
+		reader = sideband.NewDemuxer(sideband.Sideband64k, response)
+	case req.Capabilities.Supports(capability.Sideband):
+		reader = sideband.NewDemuxer(sideband.Sideband, response)
+	}
+
+	return packfile.UpdateObjectStorage(storer, reader)
+}
+
+// sparseBlobs returns the blobs of the commit tree in the directories
+func sparseBlobs(storer storage.Storer, hash plumbing.Hash, dirs []string) ([]plumbing.Hash, error) {
+	commit, err := object.GetCommit(storer, hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting commit %s", hash)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting tree of commit %s", hash)
+	}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	seen := map[plumbing.Hash]bool{}
+	var blobs []plumbing.Hash
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			return blobs, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "walking tree of commit %s", hash)
+		}
+
+		if !entry.Mode.IsFile() || seen[entry.Hash] || !inDirectories(name, dirs) {
+			continue
+		}
+		seen[entry.Hash] = true
+		blobs = append(blobs, entry.Hash)
+	}
+}
+
+func inDirectories(name string, dirs []string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+	return false
+}
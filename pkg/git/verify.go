@@ -0,0 +1,255 @@
+package git
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	GPGKeysFile = "gpg-keys"
+	SSHKeysFile = "ssh-keys"
+
+	sshSignatureArmorStart = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureArmorEnd   = "-----END SSH SIGNATURE-----"
+	sshSignatureMagic      = "SSHSIG"
+	sshSignatureNamespace  = "git"
+)
+
+// ErrUnverifiedCommit is returned when a commit is not signed by a trusted key
+var ErrUnverifiedCommit = errors.New("commit is not signed by a trusted key")
+
+type trustedSSHKey struct {
+	key     ssh.PublicKey
+	comment string
+}
+
+// CommitVerifier verifies commits are signed with a GPG or SSH key from a set of trusted keys
+type CommitVerifier struct {
+	gpgKeyRing string
+	sshKeys    []trustedSSHKey
+}
+
+// NewCommitVerifier reads the trusted ASCII armored GPG keys and authorized_keys formatted SSH keys from dir
+func NewCommitVerifier(dir string) (*CommitVerifier, error) {
+	gpgKeyRing, err := readKeysFile(filepath.Join(dir, GPGKeysFile))
+	if err != nil {
+		return nil, err
+	}
+
+	sshKeysFile, err := readKeysFile(filepath.Join(dir, SSHKeysFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var sshKeys []trustedSSHKey
+	for rest := []byte(sshKeysFile); len(bytes.TrimSpace(rest)) > 0; {
+		var (
+			key     ssh.PublicKey
+			comment string
+		)
+		key, comment, _, rest, err = ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", SSHKeysFile)
+		}
+		sshKeys = append(sshKeys, trustedSSHKey{key: key, comment: comment})
+	}
+
+	if strings.TrimSpace(gpgKeyRing) == "" && len(sshKeys) == 0 {
+		return nil, errors.Errorf("no trusted %s or %s found in %s", GPGKeysFile, SSHKeysFile, dir)
+	}
+
+	return &CommitVerifier{
+		gpgKeyRing: gpgKeyRing,
+		sshKeys:    sshKeys,
+	}, nil
+}
+
+// Verify returns the identity of the trusted key that signed the commit
+func (v *CommitVerifier) Verify(commit *object.Commit) (string, error) {
+	if commit.PGPSignature == "" {
+		return "", errors.Wrapf(ErrUnverifiedCommit, "commit %s is not signed", commit.Hash)
+	}
+
+	if strings.HasPrefix(commit.PGPSignature, sshSignatureArmorStart) {
+		return v.verifySSH(commit)
+	}
+	return v.verifyGPG(commit)
+}
+
+func (v *CommitVerifier) verifyGPG(commit *object.Commit) (string, error) {
+	if strings.TrimSpace(v.gpgKeyRing) == "" {
+		return "", errors.Wrapf(ErrUnverifiedCommit, "commit %s has a gpg signature and no gpg keys are trusted", commit.Hash)
+	}
+
+	entity, err := commit.Verify(v.gpgKeyRing)
+	if err != nil {
+		return "", errors.Wrapf(ErrUnverifiedCommit, "commit %s: %s", commit.Hash, err)
+	}
+
+	identity := ""
+	if primary := entity.PrimaryIdentity(); primary != nil {
+		identity = primary.Name + " "
+	}
+	return fmt.Sprintf("%s(gpg %X)", identity, entity.PrimaryKey.Fingerprint), nil
+}
+
+func (v *CommitVerifier) verifySSH(commit *object.Commit) (string, error) {
+	signature, err := parseSSHSignature(commit.PGPSignature)
+	if err != nil {
+		return "", errors.Wrapf(ErrUnverifiedCommit, "commit %s: %s", commit.Hash, err)
+	}
+
+	message, err := encodeWithoutSignature(commit)
+	if err != nil {
+		return "", err
+	}
+
+	for _, trusted := range v.sshKeys {
+		if !bytes.Equal(trusted.key.Marshal(), signature.publicKey.Marshal()) {
+			continue
+		}
+
+		if err := signature.verify(message); err != nil {
+			return "", errors.Wrapf(ErrUnverifiedCommit, "commit %s: %s", commit.Hash, err)
+		}
+
+		identity := ""
+		if trusted.comment != "" {
+			identity = trusted.comment + " "
+		}
+		return fmt.Sprintf("%s(ssh %s)", identity, ssh.FingerprintSHA256(trusted.key)), nil
+	}
+
+	return "", errors.Wrapf(ErrUnverifiedCommit, "commit %s is signed by untrusted ssh key %s", commit.Hash, ssh.FingerprintSHA256(signature.publicKey))
+}
+
+// sshSignature is an ssh signature in the format of https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+type sshSignature struct {
+	publicKey     ssh.PublicKey
+	namespace     string
+	hashAlgorithm string
+	signature     *ssh.Signature
+}
+
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	body := strings.TrimSpace(armored)
+	body = strings.TrimPrefix(body, sshSignatureArmorStart)
+	body = strings.TrimSuffix(body, sshSignatureArmorEnd)
+
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding ssh signature")
+	}
+
+	if !bytes.HasPrefix(blob, []byte(sshSignatureMagic)) {
+		return nil, errors.New("invalid ssh signature")
+	}
+
+	var wire struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err := ssh.Unmarshal(blob[len(sshSignatureMagic):], &wire); err != nil {
+		return nil, errors.Wrap(err, "parsing ssh signature")
+	}
+
+	if wire.Version != 1 {
+		return nil, errors.Errorf("unsupported ssh signature version %d", wire.Version)
+	}
+
+	publicKey, err := ssh.ParsePublicKey(wire.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing ssh signature public key")
+	}
+
+	signature := &ssh.Signature{}
+	if err := ssh.Unmarshal(wire.Signature, signature); err != nil {
+		return nil, errors.Wrap(err, "parsing ssh signature")
+	}
+
+	return &sshSignature{
+		publicKey:     publicKey,
+		namespace:     wire.Namespace,
+		hashAlgorithm: wire.HashAlgorithm,
+		signature:     signature,
+	}, nil
+}
+
+func (s *sshSignature) verify(message []byte) error {
+	if s.namespace != sshSignatureNamespace {
+		return errors.Errorf("ssh signature namespace %q is not %q", s.namespace, sshSignatureNamespace)
+	}
+
+	signedData, err := sshSignedData(s.namespace, s.hashAlgorithm, message)
+	if err != nil {
+		return err
+	}
+
+	return s.publicKey.Verify(signedData, s.signature)
+}
+
+// sshSignedData is the data signed by an ssh signature of the message
+func sshSignedData(namespace, hashAlgorithm string, message []byte) ([]byte, error) {
+	var h hash.Hash
+	switch hashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return nil, errors.Errorf("unsupported ssh signature hash algorithm %q", hashAlgorithm)
+	}
+	h.Write(message)
+
+	return append([]byte(sshSignatureMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Namespace:     namespace,
+		HashAlgorithm: hashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...), nil
+}
+
+func encodeWithoutSignature(commit *object.Commit) ([]byte, error) {
+	encoded := &plumbing.MemoryObject{}
+	if err := commit.EncodeWithoutSignature(encoded); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	reader, err := encoded.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	_, err = buf.ReadFrom(reader)
+	return buf.Bytes(), err
+}
+
+func readKeysFile(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(contents), err
+}
@@ -0,0 +1,200 @@
+package git
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCommitVerifier(t *testing.T) {
+	spec.Run(t, "testCommitVerifier", testCommitVerifier)
+}
+
+func testCommitVerifier(t *testing.T, when spec.G, it spec.S) {
+	var (
+		repoDir    string
+		keysDir    string
+		repository *gogit.Repository
+	)
+
+	commit := func(options *gogit.CommitOptions) *object.Commit {
+		t.Helper()
+		worktree, err := repository.Worktree()
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte(time.Now().String()), 0644))
+		_, err = worktree.Add("README.md")
+		require.NoError(t, err)
+
+		options.Author = &object.Signature{Name: "some-author", Email: "author@example.com", When: time.Now()}
+		hash, err := worktree.Commit("some-commit", options)
+		require.NoError(t, err)
+
+		c, err := repository.CommitObject(hash)
+		require.NoError(t, err)
+		return c
+	}
+
+	newGPGEntity := func(name, email string) *openpgp.Entity {
+		t.Helper()
+		entity, err := openpgp.NewEntity(name, "", email, nil)
+		require.NoError(t, err)
+		return entity
+	}
+
+	armoredPublicKey := func(entity *openpgp.Entity) []byte {
+		t.Helper()
+		buf := &bytes.Buffer{}
+		w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.Serialize(w))
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	newSSHSigner := func() ssh.Signer {
+		t.Helper()
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		signer, err := ssh.NewSignerFromKey(key)
+		require.NoError(t, err)
+		return signer
+	}
+
+	writeKeys := func(file string, contents []byte) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(keysDir, file), contents, 0644))
+	}
+
+	it.Before(func() {
+		repoDir = t.TempDir()
+		keysDir = t.TempDir()
+
+		var err error
+		repository, err = gogit.PlainInit(repoDir, false)
+		require.NoError(t, err)
+	})
+
+	when("gpg signatures", func() {
+		it("verifies commits signed by a trusted key", func() {
+			trusted := newGPGEntity("Some Signer", "some-signer@example.com")
+			writeKeys(GPGKeysFile, armoredPublicKey(trusted))
+
+			verifier, err := NewCommitVerifier(keysDir)
+			require.NoError(t, err)
+
+			signer, err := verifier.Verify(commit(&gogit.CommitOptions{SignKey: trusted}))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("Some Signer <some-signer@example.com> (gpg %X)", trusted.PrimaryKey.Fingerprint), signer)
+		})
+
+		it("rejects commits signed by an untrusted key", func() {
+			writeKeys(GPGKeysFile, armoredPublicKey(newGPGEntity("Some Signer", "some-signer@example.com")))
+
+			verifier, err := NewCommitVerifier(keysDir)
+			require.NoError(t, err)
+
+			_, err = verifier.Verify(commit(&gogit.CommitOptions{SignKey: newGPGEntity("Other Signer", "other-signer@example.com")}))
+			require.True(t, errors.Is(err, ErrUnverifiedCommit))
+		})
+	})
+
+	when("ssh signatures", func() {
+		it("verifies commits signed by a trusted key", func() {
+			trusted := newSSHSigner()
+			writeKeys(SSHKeysFile, append(bytes.TrimSpace(ssh.MarshalAuthorizedKey(trusted.PublicKey())), []byte(" some-signer@example.com\n")...))
+
+			verifier, err := NewCommitVerifier(keysDir)
+			require.NoError(t, err)
+
+			signer, err := verifier.Verify(commit(&gogit.CommitOptions{Signer: sshCommitSigner{trusted}}))
+			require.NoError(t, err)
+			assert.Equal(t, "some-signer@example.com (ssh "+ssh.FingerprintSHA256(trusted.PublicKey())+")", signer)
+		})
+
+		it("rejects commits signed by an untrusted key", func() {
+			writeKeys(SSHKeysFile, ssh.MarshalAuthorizedKey(newSSHSigner().PublicKey()))
+
+			verifier, err := NewCommitVerifier(keysDir)
+			require.NoError(t, err)
+
+			_, err = verifier.Verify(commit(&gogit.CommitOptions{Signer: sshCommitSigner{newSSHSigner()}}))
+			require.True(t, errors.Is(err, ErrUnverifiedCommit))
+			require.ErrorContains(t, err, "is signed by untrusted ssh key")
+		})
+	})
+
+	it("rejects unsigned commits", func() {
+		writeKeys(SSHKeysFile, ssh.MarshalAuthorizedKey(newSSHSigner().PublicKey()))
+
+		verifier, err := NewCommitVerifier(keysDir)
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(commit(&gogit.CommitOptions{}))
+		require.True(t, errors.Is(err, ErrUnverifiedCommit))
+		require.ErrorContains(t, err, "is not signed")
+	})
+
+	it("requires trusted keys", func() {
+		_, err := NewCommitVerifier(keysDir)
+		require.ErrorContains(t, err, "no trusted gpg-keys or ssh-keys found")
+	})
+}
+
+// sshCommitSigner signs commits like git with gpg.format=ssh
+type sshCommitSigner struct {
+	signer ssh.Signer
+}
+
+func (s sshCommitSigner) Sign(message io.Reader) ([]byte, error) {
+	contents, err := io.ReadAll(message)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := sshSignedData(sshSignatureNamespace, "sha512", contents)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.signer.Sign(rand.Reader, signedData)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := append([]byte(sshSignatureMagic), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, s.signer.PublicKey().Marshal(), sshSignatureNamespace, "", "sha512", ssh.Marshal(signature)})...)
+
+	armored := &bytes.Buffer{}
+	armored.WriteString(sshSignatureArmorStart + "\n")
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	for len(encoded) > 70 {
+		armored.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	armored.WriteString(encoded + "\n" + sshSignatureArmorEnd + "\n")
+	return armored.Bytes(), nil
+}
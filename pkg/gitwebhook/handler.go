@@ -0,0 +1,128 @@
+package gitwebhook
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	giturls "github.com/chainguard-dev/git-urls"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/git"
+)
+
+const maxPayloadSize = 25 * 1024 * 1024
+
+// Handler receives push webhooks from git hosts and immediately enqueues the SourceResolvers
+// that track the pushed repository and ref.
+type Handler struct {
+	Secret               []byte
+	SourceResolverLister buildlisters.SourceResolverLister
+	Enqueue              func(obj interface{})
+	Logger               *zap.SugaredLogger
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if len(h.Secret) == 0 {
+		http.Error(w, "webhook secret is not configured", http.StatusUnauthorized)
+		return
+	}
+
+	p := detectProvider(r.Header)
+	if p == nil {
+		http.Error(w, "unsupported webhook provider", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := p.verify(r.Header, body, h.Secret); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if !p.isPush(r.Header) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	event, err := p.parse(body)
+	if err != nil {
+		http.Error(w, "invalid push payload", http.StatusBadRequest)
+		return
+	}
+
+	sourceResolvers, err := h.SourceResolverLister.List(labels.Everything())
+	if err != nil {
+		http.Error(w, "failed to list source resolvers", http.StatusInternalServerError)
+		return
+	}
+
+	urls := normalizedURLs(event.URLs)
+	for _, sr := range sourceResolvers {
+		if matches(sr, urls, event.Refs) {
+			h.Logger.Debugw("enqueuing source resolver from webhook", "provider", p.name(), "namespace", sr.Namespace, "name", sr.Name)
+			h.Enqueue(sr)
+		}
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func matches(sr *buildapi.SourceResolver, urls map[string]struct{}, refs []string) bool {
+	if !sr.IsGit() {
+		return false
+	}
+
+	if _, ok := urls[normalizeURL(sr.Spec.Source.Git.URL)]; !ok {
+		return false
+	}
+
+	revision := sr.Spec.Source.Git.Revision
+	selector, isSelector := git.ParseRevisionSelector(revision)
+	for _, ref := range refs {
+		if ref == revision || ref == branchRefPrefix+revision || ref == tagRefPrefix+revision {
+			return true
+		}
+
+		if isSelector && strings.HasPrefix(ref, tagRefPrefix) && selector.Matches(strings.TrimPrefix(ref, tagRefPrefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizedURLs(rawURLs []string) map[string]struct{} {
+	urls := map[string]struct{}{}
+	for _, rawURL := range rawURLs {
+		if rawURL == "" {
+			continue
+		}
+		urls[normalizeURL(rawURL)] = struct{}{}
+	}
+	return urls
+}
+
+// normalizeURL reduces http(s), ssh and scp-like git urls to a comparable host/path form.
+func normalizeURL(rawURL string) string {
+	parsed, err := giturls.Parse(rawURL)
+	if err != nil {
+		return strings.ToLower(rawURL)
+	}
+
+	path := strings.Trim(parsed.Path, "/")
+	path = strings.TrimSuffix(path, ".git")
+	return strings.ToLower(parsed.Hostname() + "/" + path)
+}
@@ -0,0 +1,236 @@
+package gitwebhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/gitwebhook"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+)
+
+func TestHandler(t *testing.T) {
+	spec.Run(t, "Git Webhook Handler", testHandler)
+}
+
+func testHandler(t *testing.T, when spec.G, it spec.S) {
+	const secret = "some-secret"
+
+	var (
+		enqueued []string
+		handler  *gitwebhook.Handler
+	)
+
+	gitSourceResolver := func(name, url, revision string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      url,
+						Revision: revision,
+					},
+				},
+			},
+		}
+	}
+
+	it.Before(func() {
+		enqueued = nil
+		listers := testhelpers.NewListers([]runtime.Object{
+			gitSourceResolver("https-main", "https://github.com/some-org/some-repo", "main"),
+			gitSourceResolver("ssh-main", "git@github.com:some-org/some-repo.git", "main"),
+			gitSourceResolver("other-branch", "https://github.com/some-org/some-repo", "develop"),
+			gitSourceResolver("other-repo", "https://github.com/some-org/other-repo", "main"),
+			gitSourceResolver("tag", "https://github.com/some-org/some-repo", "v1.0.0"),
+			gitSourceResolver("selector", "https://github.com/some-org/some-repo", ">=2.0.0 <3"),
+			&buildapi.SourceResolver{
+				ObjectMeta: metav1.ObjectMeta{Name: "blob", Namespace: "some-namespace"},
+				Spec: buildapi.SourceResolverSpec{
+					Source: corev1alpha1.SourceConfig{Blob: &corev1alpha1.Blob{URL: "https://github.com/some-org/some-repo"}},
+				},
+			},
+		})
+
+		handler = &gitwebhook.Handler{
+			Secret:               []byte(secret),
+			SourceResolverLister: listers.GetSourceResolverLister(),
+			Enqueue: func(obj interface{}) {
+				enqueued = append(enqueued, obj.(*buildapi.SourceResolver).Name)
+			},
+			Logger: zap.NewNop().Sugar(),
+		}
+	})
+
+	serve := func(headers map[string]string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	githubPayload := `{"ref":"refs/heads/main","repository":{"clone_url":"https://github.com/some-org/some-repo.git","ssh_url":"git@github.com:some-org/some-repo.git","html_url":"https://github.com/some-org/some-repo"}}`
+
+	when("github", func() {
+		it("enqueues source resolvers matching the repository and branch", func() {
+			rec := serve(map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(githubPayload),
+			}, githubPayload)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.ElementsMatch(t, []string{"https-main", "ssh-main"}, enqueued)
+		})
+
+		it("matches tags", func() {
+			payload := strings.Replace(githubPayload, "refs/heads/main", "refs/tags/v1.0.0", 1)
+			rec := serve(map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(payload),
+			}, payload)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.Equal(t, []string{"tag"}, enqueued)
+		})
+
+		it("matches tags selected by a revision selector", func() {
+			payload := strings.Replace(githubPayload, "refs/heads/main", "refs/tags/v2.1.0", 1)
+			rec := serve(map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(payload),
+			}, payload)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.Equal(t, []string{"selector"}, enqueued)
+		})
+
+		it("rejects invalid signatures", func() {
+			rec := serve(map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign("something-else"),
+			}, githubPayload)
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Empty(t, enqueued)
+		})
+
+		it("rejects missing signatures", func() {
+			rec := serve(map[string]string{
+				"X-GitHub-Event": "push",
+			}, githubPayload)
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Empty(t, enqueued)
+		})
+
+		it("ignores non push events", func() {
+			payload := `{"zen":"hello"}`
+			rec := serve(map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": "sha256=" + sign(payload),
+			}, payload)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Empty(t, enqueued)
+		})
+	})
+
+	when("gitea", func() {
+		it("verifies the gitea signature", func() {
+			rec := serve(map[string]string{
+				"X-Gitea-Event":     "push",
+				"X-GitHub-Event":    "push",
+				"X-Gitea-Signature": sign(githubPayload),
+			}, githubPayload)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.ElementsMatch(t, []string{"https-main", "ssh-main"}, enqueued)
+		})
+	})
+
+	when("gitlab", func() {
+		payload := `{"ref":"refs/heads/develop","project":{"git_http_url":"https://github.com/some-org/some-repo.git","git_ssh_url":"git@github.com:some-org/some-repo.git","web_url":"https://github.com/some-org/some-repo"}}`
+
+		it("verifies the secret token", func() {
+			rec := serve(map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": secret,
+			}, payload)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.Equal(t, []string{"other-branch"}, enqueued)
+		})
+
+		it("rejects an invalid token", func() {
+			rec := serve(map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "wrong",
+			}, payload)
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Empty(t, enqueued)
+		})
+	})
+
+	when("bitbucket", func() {
+		it("handles cloud push payloads", func() {
+			payload := `{"push":{"changes":[{"new":{"type":"branch","name":"main"}}]},"repository":{"links":{"html":{"href":"https://github.com/some-org/some-repo"}}}}`
+			rec := serve(map[string]string{
+				"X-Event-Key":     "repo:push",
+				"X-Hub-Signature": "sha256=" + sign(payload),
+			}, payload)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.ElementsMatch(t, []string{"https-main", "ssh-main"}, enqueued)
+		})
+
+		it("handles data center refs changed payloads", func() {
+			payload := `{"changes":[{"ref":{"id":"refs/heads/develop"}}],"repository":{"links":{"clone":[{"href":"ssh://git@github.com/some-org/some-repo.git"}]}}}`
+			rec := serve(map[string]string{
+				"X-Event-Key":     "repo:refs_changed",
+				"X-Hub-Signature": "sha256=" + sign(payload),
+			}, payload)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.Equal(t, []string{"other-branch"}, enqueued)
+		})
+	})
+
+	it("rejects unknown providers", func() {
+		rec := serve(map[string]string{}, githubPayload)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	it("only accepts posts", func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,226 @@
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+var errInvalidSignature = errors.New("invalid webhook signature")
+
+// pushEvent is the provider independent subset of a push payload used to match SourceResolvers.
+type pushEvent struct {
+	URLs []string
+	Refs []string
+}
+
+type provider interface {
+	name() string
+	matches(header http.Header) bool
+	isPush(header http.Header) bool
+	verify(header http.Header, body, secret []byte) error
+	parse(body []byte) (pushEvent, error)
+}
+
+// providers are checked in order. Gitea is first because it also sends GitHub compatible headers.
+var providers = []provider{
+	gitea{},
+	github{},
+	gitlab{},
+	bitbucket{},
+}
+
+func detectProvider(header http.Header) provider {
+	for _, p := range providers {
+		if p.matches(header) {
+			return p
+		}
+	}
+	return nil
+}
+
+type github struct{}
+
+func (github) name() string { return "github" }
+
+func (github) matches(header http.Header) bool { return header.Get("X-GitHub-Event") != "" }
+
+func (github) isPush(header http.Header) bool { return header.Get("X-GitHub-Event") == "push" }
+
+func (github) verify(header http.Header, body, secret []byte) error {
+	return verifyHmac(strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256="), body, secret)
+}
+
+func (github) parse(body []byte) (pushEvent, error) {
+	return parseGithubStylePush(body)
+}
+
+type gitea struct{}
+
+func (gitea) name() string { return "gitea" }
+
+func (gitea) matches(header http.Header) bool { return header.Get("X-Gitea-Event") != "" }
+
+func (gitea) isPush(header http.Header) bool { return header.Get("X-Gitea-Event") == "push" }
+
+func (gitea) verify(header http.Header, body, secret []byte) error {
+	return verifyHmac(header.Get("X-Gitea-Signature"), body, secret)
+}
+
+func (gitea) parse(body []byte) (pushEvent, error) {
+	return parseGithubStylePush(body)
+}
+
+func parseGithubStylePush(body []byte) (pushEvent, error) {
+	var payload struct {
+		Ref        string `json:"ref"`
+		Repository struct {
+			CloneURL string `json:"clone_url"`
+			SshURL   string `json:"ssh_url"`
+			HtmlURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return pushEvent{}, err
+	}
+
+	return pushEvent{
+		URLs: []string{payload.Repository.CloneURL, payload.Repository.SshURL, payload.Repository.HtmlURL},
+		Refs: []string{payload.Ref},
+	}, nil
+}
+
+type gitlab struct{}
+
+func (gitlab) name() string { return "gitlab" }
+
+func (gitlab) matches(header http.Header) bool { return header.Get("X-Gitlab-Event") != "" }
+
+func (gitlab) isPush(header http.Header) bool {
+	event := header.Get("X-Gitlab-Event")
+	return event == "Push Hook" || event == "Tag Push Hook"
+}
+
+// GitLab does not sign payloads, it sends the configured secret token as is.
+func (gitlab) verify(header http.Header, _, secret []byte) error {
+	if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), secret) != 1 {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (gitlab) parse(body []byte) (pushEvent, error) {
+	var payload struct {
+		Ref     string `json:"ref"`
+		Project struct {
+			GitHttpURL string `json:"git_http_url"`
+			GitSshURL  string `json:"git_ssh_url"`
+			WebURL     string `json:"web_url"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return pushEvent{}, err
+	}
+
+	return pushEvent{
+		URLs: []string{payload.Project.GitHttpURL, payload.Project.GitSshURL, payload.Project.WebURL},
+		Refs: []string{payload.Ref},
+	}, nil
+}
+
+// bitbucket handles both Bitbucket Cloud (repo:push) and Bitbucket Data Center (repo:refs_changed) payloads.
+type bitbucket struct{}
+
+func (bitbucket) name() string { return "bitbucket" }
+
+func (bitbucket) matches(header http.Header) bool { return header.Get("X-Event-Key") != "" }
+
+func (bitbucket) isPush(header http.Header) bool {
+	event := header.Get("X-Event-Key")
+	return event == "repo:push" || event == "repo:refs_changed"
+}
+
+func (bitbucket) verify(header http.Header, body, secret []byte) error {
+	return verifyHmac(strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256="), body, secret)
+}
+
+func (bitbucket) parse(body []byte) (pushEvent, error) {
+	var payload struct {
+		Push struct {
+			Changes []struct {
+				New *struct {
+					Type string `json:"type"`
+					Name string `json:"name"`
+				} `json:"new"`
+			} `json:"changes"`
+		} `json:"push"`
+		Changes []struct {
+			Ref struct {
+				ID string `json:"id"`
+			} `json:"ref"`
+		} `json:"changes"`
+		Repository struct {
+			Links struct {
+				Html struct {
+					Href string `json:"href"`
+				} `json:"html"`
+				Clone []struct {
+					Href string `json:"href"`
+				} `json:"clone"`
+			} `json:"links"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return pushEvent{}, err
+	}
+
+	event := pushEvent{}
+	if payload.Repository.Links.Html.Href != "" {
+		event.URLs = append(event.URLs, payload.Repository.Links.Html.Href)
+	}
+	for _, clone := range payload.Repository.Links.Clone {
+		event.URLs = append(event.URLs, clone.Href)
+	}
+
+	for _, change := range payload.Push.Changes {
+		if change.New == nil {
+			continue
+		}
+		switch change.New.Type {
+		case "branch":
+			event.Refs = append(event.Refs, branchRefPrefix+change.New.Name)
+		case "tag":
+			event.Refs = append(event.Refs, tagRefPrefix+change.New.Name)
+		}
+	}
+	for _, change := range payload.Changes {
+		event.Refs = append(event.Refs, change.Ref.ID)
+	}
+
+	return event, nil
+}
+
+func verifyHmac(signature string, body, secret []byte) error {
+	actual, err := hex.DecodeString(signature)
+	if err != nil || len(actual) == 0 {
+		return errInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+	return nil
+}
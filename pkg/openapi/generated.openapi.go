@@ -64,16 +64,22 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Build":                       schema_pkg_apis_build_v1alpha2_Build(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCache":                  schema_pkg_apis_build_v1alpha2_BuildCache(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCacheConfig":            schema_pkg_apis_build_v1alpha2_BuildCacheConfig(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildEnvSource":              schema_pkg_apis_build_v1alpha2_BuildEnvSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildList":                   schema_pkg_apis_build_v1alpha2_BuildList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildPersistentVolumeCache":  schema_pkg_apis_build_v1alpha2_BuildPersistentVolumeCache(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRetryPolicy":            schema_pkg_apis_build_v1alpha2_BuildRetryPolicy(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildSpec":                   schema_pkg_apis_build_v1alpha2_BuildSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildSpecImage":              schema_pkg_apis_build_v1alpha2_BuildSpecImage(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildStack":                  schema_pkg_apis_build_v1alpha2_BuildStack(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildStatus":                 schema_pkg_apis_build_v1alpha2_BuildStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildWindow":                 schema_pkg_apis_build_v1alpha2_BuildWindow(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Builder":                     schema_pkg_apis_build_v1alpha2_Builder(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderBuildpackRef":         schema_pkg_apis_build_v1alpha2_BuilderBuildpackRef(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderList":                 schema_pkg_apis_build_v1alpha2_BuilderList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry":           schema_pkg_apis_build_v1alpha2_BuilderOrderEntry(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderPlatformImage":        schema_pkg_apis_build_v1alpha2_BuilderPlatformImage(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRollout":              schema_pkg_apis_build_v1alpha2_BuilderRollout(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRolloutStatus":        schema_pkg_apis_build_v1alpha2_BuilderRolloutStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderSpec":                 schema_pkg_apis_build_v1alpha2_BuilderSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderStatus":               schema_pkg_apis_build_v1alpha2_BuilderStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Buildpack":                   schema_pkg_apis_build_v1alpha2_Buildpack(ref),
@@ -87,6 +93,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterBuildpackList":        schema_pkg_apis_build_v1alpha2_ClusterBuildpackList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterBuildpackSpec":        schema_pkg_apis_build_v1alpha2_ClusterBuildpackSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterBuildpackStatus":      schema_pkg_apis_build_v1alpha2_ClusterBuildpackStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtension":            schema_pkg_apis_build_v1alpha2_ClusterExtension(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtensionList":        schema_pkg_apis_build_v1alpha2_ClusterExtensionList(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtensionSpec":        schema_pkg_apis_build_v1alpha2_ClusterExtensionSpec(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtensionStatus":      schema_pkg_apis_build_v1alpha2_ClusterExtensionStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycle":            schema_pkg_apis_build_v1alpha2_ClusterLifecycle(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleList":        schema_pkg_apis_build_v1alpha2_ClusterLifecycleList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleSpec":        schema_pkg_apis_build_v1alpha2_ClusterLifecycleSpec(ref),
@@ -105,6 +115,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignAnnotation":            schema_pkg_apis_build_v1alpha2_CosignAnnotation(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig":                schema_pkg_apis_build_v1alpha2_CosignConfig(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignSignature":             schema_pkg_apis_build_v1alpha2_CosignSignature(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Extension":                   schema_pkg_apis_build_v1alpha2_Extension(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ExtensionList":               schema_pkg_apis_build_v1alpha2_ExtensionList(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ExtensionSpec":               schema_pkg_apis_build_v1alpha2_ExtensionSpec(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ExtensionStatus":             schema_pkg_apis_build_v1alpha2_ExtensionStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Image":                       schema_pkg_apis_build_v1alpha2_Image(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuild":                  schema_pkg_apis_build_v1alpha2_ImageBuild(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuilder":                schema_pkg_apis_build_v1alpha2_ImageBuilder(ref),
@@ -115,6 +129,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageStatus":                 schema_pkg_apis_build_v1alpha2_ImageStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LastBuild":                   schema_pkg_apis_build_v1alpha2_LastBuild(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.NamespacedBuilderSpec":       schema_pkg_apis_build_v1alpha2_NamespacedBuilderSpec(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PendingBuild":                schema_pkg_apis_build_v1alpha2_PendingBuild(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PlatformImage":               schema_pkg_apis_build_v1alpha2_PlatformImage(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.RegistryCache":               schema_pkg_apis_build_v1alpha2_RegistryCache(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ResolvedClusterLifecycle":    schema_pkg_apis_build_v1alpha2_ResolvedClusterLifecycle(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ResolvedClusterStack":        schema_pkg_apis_build_v1alpha2_ResolvedClusterStack(ref),
@@ -125,28 +141,35 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Blob":                         schema_pkg_apis_core_v1alpha1_Blob(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildBuilderSpec":             schema_pkg_apis_core_v1alpha1_BuildBuilderSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildStack":                   schema_pkg_apis_core_v1alpha1_BuildStack(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackDistro":              schema_pkg_apis_core_v1alpha1_BuildpackDistro(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackInfo":                schema_pkg_apis_core_v1alpha1_BuildpackInfo(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackMetadata":            schema_pkg_apis_core_v1alpha1_BuildpackMetadata(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackRef":                 schema_pkg_apis_core_v1alpha1_BuildpackRef(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStack":               schema_pkg_apis_core_v1alpha1_BuildpackStack(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStatus":              schema_pkg_apis_core_v1alpha1_BuildpackStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackTarget":              schema_pkg_apis_core_v1alpha1_BuildpackTarget(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackageInfo":             schema_pkg_apis_core_v1alpha1_BuildpackageInfo(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding":                   schema_pkg_apis_core_v1alpha1_CNBBinding(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition":                    schema_pkg_apis_core_v1alpha1_Condition(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ConfigMapSource":              schema_pkg_apis_core_v1alpha1_ConfigMapSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Git":                          schema_pkg_apis_core_v1alpha1_Git(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ImageSource":                  schema_pkg_apis_core_v1alpha1_ImageSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig":                 schema_pkg_apis_core_v1alpha1_NotaryConfig(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotarySecretRef":              schema_pkg_apis_core_v1alpha1_NotarySecretRef(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryV1Config":               schema_pkg_apis_core_v1alpha1_NotaryV1Config(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.OrderEntry":                   schema_pkg_apis_core_v1alpha1_OrderEntry(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.PersistentVolumeClaimSource":  schema_pkg_apis_core_v1alpha1_PersistentVolumeClaimSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry":                     schema_pkg_apis_core_v1alpha1_Registry(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedBlobSource":           schema_pkg_apis_core_v1alpha1_ResolvedBlobSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedGitSource":            schema_pkg_apis_core_v1alpha1_ResolvedGitSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource":       schema_pkg_apis_core_v1alpha1_ResolvedRegistrySource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedSourceConfig":         schema_pkg_apis_core_v1alpha1_ResolvedSourceConfig(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedVolumeSource":         schema_pkg_apis_core_v1alpha1_ResolvedVolumeSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig":                 schema_pkg_apis_core_v1alpha1_SourceConfig(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification":           schema_pkg_apis_core_v1alpha1_SourceVerification(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Status":                       schema_pkg_apis_core_v1alpha1_Status(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.VolatileTime":                 schema_pkg_apis_core_v1alpha1_VolatileTime(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Volume":                       schema_pkg_apis_core_v1alpha1_Volume(ref),
 	}
 }
 
@@ -2010,6 +2033,41 @@ func schema_pkg_apis_build_v1alpha2_BuildCacheConfig(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_BuildEnvSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is Secret or ConfigMap",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"hash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hash is the sha256 digest of the referenced keys of the Secret or ConfigMap, it is empty if it does not exist or is not labeled as a build env source",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_BuildList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2076,6 +2134,35 @@ func schema_pkg_apis_build_v1alpha2_BuildPersistentVolumeCache(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_BuildRetryPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxAttempts is the most builds created for the same changes, including the build that first failed",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"backoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Backoff is how long to wait before the first retry. It doubles after every failed retry. Defaults to 1m.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"maxAttempts"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_BuildSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2190,6 +2277,43 @@ func schema_pkg_apis_build_v1alpha2_BuildSpec(ref common.ReferenceCallback) comm
 							},
 						},
 					},
+					"envFrom": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"envSources": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvSources are the hashes of the Secrets and ConfigMaps referenced by Env and EnvFrom when the build was created",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildEnvSource"),
+									},
+								},
+							},
+						},
+					},
 					"projectDescriptorPath": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -2285,12 +2409,19 @@ func schema_pkg_apis_build_v1alpha2_BuildSpec(ref common.ReferenceCallback) comm
 							Format: "",
 						},
 					},
+					"logLevel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LogLevel is the log level of the lifecycle phases",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCacheConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildSpecImage", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LastBuild", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildBuilderSpec", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCacheConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildEnvSource", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildSpecImage", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LastBuild", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildBuilderSpec", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
@@ -2465,6 +2596,55 @@ func schema_pkg_apis_build_v1alpha2_BuildStatus(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_BuildWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reasons": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Reasons are the build reasons, such as STACK or BUILDPACK, that are only built while the window is open",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule is a cron expression on which the window opens",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is how long the window stays open",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"reasons", "schedule", "duration"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_Builder(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2679,6 +2859,125 @@ func schema_pkg_apis_build_v1alpha2_BuilderOrderEntry(ref common.ReferenceCallba
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_BuilderPlatformImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"platform": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"runImage": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"platform", "image", "runImage"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuilderRollout(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector selects the canary Images that rebuild first when the latest image of the builder changes",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"percentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of the Images built with the builder, chosen by name, that rebuild first",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessThreshold is the percentage of canary Images that must build successfully before the remaining Images rebuild. Defaults to 100.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuilderRolloutStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the latest image of the builder that is being rolled out",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is Canary while canary Images rebuild, Promoted once the remaining Images may rebuild and Halted if too many canary builds failed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"canaryImages": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"succeededImages": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"failedImages": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"skippedImages": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkippedImages are canary Images with a pending build that is deferred by a build window or not allowed by their allowed build reasons. They do not count towards the success threshold.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_BuilderSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2727,6 +3026,25 @@ func schema_pkg_apis_build_v1alpha2_BuilderSpec(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"orderExtensions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "OrderExtensions is the order of the image extensions that generate Dockerfiles to extend the build image",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry"),
+									},
+								},
+							},
+						},
+					},
 					"additionalLabels": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"object"},
@@ -2742,23 +3060,62 @@ func schema_pkg_apis_build_v1alpha2_BuilderSpec(ref common.ReferenceCallback) co
 							},
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry", "k8s.io/api/core/v1.ObjectReference"},
-	}
-}
-
-func schema_pkg_apis_build_v1alpha2_BuilderStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"observedGeneration": {
+					"rollout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Description: "Rollout stages rebuilds of the Images built with the builder when its latest image changes",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRollout"),
+						},
+					},
+					"platforms": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Platforms, such as linux/amd64 and linux/arm64, each get a builder image created from the images of the platform in the stack, lifecycle and buildpacks. The builder is pushed as an image index of the platform images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"buildImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildImage and RunImage reference the stack images directly instead of a ClusterStack, the run image target is used in place of a stack id",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
+					"runImage": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRollout", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage", "k8s.io/api/core/v1.ObjectReference"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuilderStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -2809,6 +3166,32 @@ func schema_pkg_apis_build_v1alpha2_BuilderStatus(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"extensionMetadata": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackMetadata"),
+									},
+								},
+							},
+						},
+					},
+					"orderExtensions": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.OrderEntry"),
+									},
+								},
+							},
+						},
+					},
 					"stack": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
@@ -2858,11 +3241,35 @@ func schema_pkg_apis_build_v1alpha2_BuilderStatus(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"rollout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rollout is the progress of the rollout of the latest image to the Images built with the builder",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRolloutStatus"),
+						},
+					},
+					"platformImages": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderPlatformImage"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignSignature", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ResolvedClusterLifecycle", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildStack", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackMetadata", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.OrderEntry"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderPlatformImage", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRolloutStatus", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignSignature", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ResolvedClusterLifecycle", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildStack", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackMetadata", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.OrderEntry"},
 	}
 }
 
@@ -3187,6 +3594,25 @@ func schema_pkg_apis_build_v1alpha2_ClusterBuilderSpec(ref common.ReferenceCallb
 							},
 						},
 					},
+					"orderExtensions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "OrderExtensions is the order of the image extensions that generate Dockerfiles to extend the build image",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry"),
+									},
+								},
+							},
+						},
+					},
 					"additionalLabels": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"object"},
@@ -3202,6 +3628,45 @@ func schema_pkg_apis_build_v1alpha2_ClusterBuilderSpec(ref common.ReferenceCallb
 							},
 						},
 					},
+					"rollout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rollout stages rebuilds of the Images built with the builder when its latest image changes",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRollout"),
+						},
+					},
+					"platforms": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Platforms, such as linux/amd64 and linux/arm64, each get a builder image created from the images of the platform in the stack, lifecycle and buildpacks. The builder is pushed as an image index of the platform images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"buildImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildImage and RunImage reference the stack images directly instead of a ClusterStack, the run image target is used in place of a stack id",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
+					"runImage": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
 					"serviceAccountRef": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
@@ -3212,7 +3677,7 @@ func schema_pkg_apis_build_v1alpha2_ClusterBuilderSpec(ref common.ReferenceCallb
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry", "k8s.io/api/core/v1.ObjectReference"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRollout", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage", "k8s.io/api/core/v1.ObjectReference"},
 	}
 }
 
@@ -3395,7 +3860,7 @@ func schema_pkg_apis_build_v1alpha2_ClusterBuildpackStatus(ref common.ReferenceC
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterLifecycle(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterExtension(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -3424,13 +3889,13 @@ func schema_pkg_apis_build_v1alpha2_ClusterLifecycle(ref common.ReferenceCallbac
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleSpec"),
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtensionSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatus"),
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtensionStatus"),
 						},
 					},
 				},
@@ -3438,11 +3903,11 @@ func schema_pkg_apis_build_v1alpha2_ClusterLifecycle(ref common.ReferenceCallbac
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtensionSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtensionStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterLifecycleList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterExtensionList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -3475,7 +3940,7 @@ func schema_pkg_apis_build_v1alpha2_ClusterLifecycleList(ref common.ReferenceCal
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycle"),
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtension"),
 									},
 								},
 							},
@@ -3486,11 +3951,11 @@ func schema_pkg_apis_build_v1alpha2_ClusterLifecycleList(ref common.ReferenceCal
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycle", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterExtension", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterLifecycleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterExtensionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -3515,7 +3980,7 @@ func schema_pkg_apis_build_v1alpha2_ClusterLifecycleSpec(ref common.ReferenceCal
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterLifecycleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterExtensionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -3548,64 +4013,33 @@ func schema_pkg_apis_build_v1alpha2_ClusterLifecycleStatus(ref common.ReferenceC
 							},
 						},
 					},
-					"image": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatusImage"),
-						},
-					},
-					"version": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"api": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Deprecated: Use `LifecycleAPIs` instead",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPI"),
+					"extensions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
 						},
-					},
-					"apis": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPIs"),
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStatus"),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatusImage", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPI", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPIs", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
-	}
-}
-
-func schema_pkg_apis_build_v1alpha2_ClusterLifecycleStatusImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"latestImage": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"image": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-				},
-			},
-		},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStatus", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterStack(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterLifecycle(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -3634,13 +4068,13 @@ func schema_pkg_apis_build_v1alpha2_ClusterStack(ref common.ReferenceCallback) c
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpec"),
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatus"),
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatus"),
 						},
 					},
 				},
@@ -3648,11 +4082,11 @@ func schema_pkg_apis_build_v1alpha2_ClusterStack(ref common.ReferenceCallback) c
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterStackList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterLifecycleList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -3685,7 +4119,7 @@ func schema_pkg_apis_build_v1alpha2_ClusterStackList(ref common.ReferenceCallbac
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStack"),
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycle"),
 									},
 								},
 							},
@@ -3696,34 +4130,22 @@ func schema_pkg_apis_build_v1alpha2_ClusterStackList(ref common.ReferenceCallbac
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStack", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycle", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterStackSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterLifecycleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"id": {
+					"image": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"buildImage": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
-						},
-					},
-					"runImage": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
-						},
-					},
 					"serviceAccountRef": {
 						SchemaProps: spec.SchemaProps{
 							Ref: ref("k8s.io/api/core/v1.ObjectReference"),
@@ -3733,29 +4155,258 @@ func schema_pkg_apis_build_v1alpha2_ClusterStackSpec(ref common.ReferenceCallbac
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage", "k8s.io/api/core/v1.ObjectReference"},
+			"k8s.io/api/core/v1.ObjectReference"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ClusterStackSpecImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ClusterLifecycleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"image": {
+					"observedGeneration": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_build_v1alpha2_ClusterStackStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions the latest available observations of a resource's current state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatusImage"),
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"api": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Deprecated: Use `LifecycleAPIs` instead",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPI"),
+						},
+					},
+					"apis": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPIs"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterLifecycleStatusImage", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPI", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LifecycleAPIs", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ClusterLifecycleStatusImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"latestImage": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"indexImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IndexImage is the image index the latest image was resolved from, it is empty if the image is not an index",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ClusterStack(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatus"),
+						},
+					},
+				},
+				Required: []string{"spec", "status"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ClusterStackList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStack"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStack", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ClusterStackSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"buildImage": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
+					"runImage": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
+					"serviceAccountRef": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/api/core/v1.ObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage", "k8s.io/api/core/v1.ObjectReference"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ClusterStackSpecImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ClusterStackStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -3837,11 +4488,17 @@ func schema_pkg_apis_build_v1alpha2_ClusterStackStatus(ref common.ReferenceCallb
 							Format: "int32",
 						},
 					},
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target is the os, arch and distribution of the run image",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackTarget"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatusImage", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatusImage", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackTarget", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
 	}
 }
 
@@ -3863,6 +4520,13 @@ func schema_pkg_apis_build_v1alpha2_ClusterStackStatusImage(ref common.Reference
 							Format: "",
 						},
 					},
+					"indexImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IndexImage is the image index the latest image was resolved from, it is empty if the image is not an index",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -4143,6 +4807,184 @@ func schema_pkg_apis_build_v1alpha2_CosignSignature(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_Extension(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ExtensionSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ExtensionStatus"),
+						},
+					},
+				},
+				Required: []string{"spec", "status"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ExtensionSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ExtensionStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ExtensionList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Extension"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Extension", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ExtensionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ExtensionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions the latest available observations of a resource's current state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"extensions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStatus"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStatus", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_Image(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4250,6 +5092,25 @@ func schema_pkg_apis_build_v1alpha2_ImageBuild(ref common.ReferenceCallback) com
 							},
 						},
 					},
+					"envFrom": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvFrom adds the data of Secrets and ConfigMaps to the build env. The image is rebuilt when the data of a Secret or ConfigMap referenced by Env or EnvFrom changes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
 					"resources": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
@@ -4318,11 +5179,17 @@ func schema_pkg_apis_build_v1alpha2_ImageBuild(ref common.ReferenceCallback) com
 							Format: "",
 						},
 					},
+					"retryPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryPolicy recreates builds that fail for a transient reason",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRetryPolicy"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRetryPolicy", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
@@ -4538,14 +5405,99 @@ func schema_pkg_apis_build_v1alpha2_ImageSpec(ref common.ReferenceCallback) comm
 							Format: "",
 						},
 					},
-					"additionalTags": {
+					"additionalTags": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule is a cron expression, such as \"0 4 * * 1\" or \"@weekly\", on which the image is rebuilt",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"buildWindows": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildWindows defer automatic builds for the listed reasons until a window is open",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildWindow"),
+									},
+								},
+							},
+						},
+					},
+					"upstreamImages": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "UpstreamImages are Images in the same namespace that this image is built from, such as a base image. The image is rebuilt when the latest image of an upstream Image changes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"allowedBuildReasons": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowedBuildReasons are the build reasons, such as COMMIT or STACK, that build the image automatically. Changes for other reasons are reported as a pending build until they are approved with a triggered build. All reasons are allowed if unset.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"platforms": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
 								"x-kubernetes-list-type": "",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Platforms, such as linux/amd64 and linux/arm64, are each built by a separate build on a node of the platform. The tag is pushed as an image index of the platform images once every platform build succeeds.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -4562,7 +5514,7 @@ func schema_pkg_apis_build_v1alpha2_ImageSpec(ref common.ReferenceCallback) comm
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuild", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageCacheConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.ObjectReference"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildWindow", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuild", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageCacheConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.ObjectReference"},
 	}
 }
 
@@ -4641,11 +5593,49 @@ func schema_pkg_apis_build_v1alpha2_ImageStatus(ref common.ReferenceCallback) co
 							Format: "",
 						},
 					},
+					"lastScheduledBuildTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastScheduledBuildTime is when the last build for the schedule was created",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastBuildRequestID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastBuildRequestID is the ID of the last build request a build was created for",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pendingBuild": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PendingBuild is a required build that has been deferred by a build window or is waiting for approval",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PendingBuild"),
+						},
+					},
+					"latestPlatformImages": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "LatestPlatformImages are the platform images of the latest image index",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PlatformImage"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PendingBuild", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PlatformImage", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -4729,6 +5719,25 @@ func schema_pkg_apis_build_v1alpha2_NamespacedBuilderSpec(ref common.ReferenceCa
 							},
 						},
 					},
+					"orderExtensions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "OrderExtensions is the order of the image extensions that generate Dockerfiles to extend the build image",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry"),
+									},
+								},
+							},
+						},
+					},
 					"additionalLabels": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"object"},
@@ -4744,6 +5753,45 @@ func schema_pkg_apis_build_v1alpha2_NamespacedBuilderSpec(ref common.ReferenceCa
 							},
 						},
 					},
+					"rollout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rollout stages rebuilds of the Images built with the builder when its latest image changes",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRollout"),
+						},
+					},
+					"platforms": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Platforms, such as linux/amd64 and linux/arm64, each get a builder image created from the images of the platform in the stack, lifecycle and buildpacks. The builder is pushed as an image index of the platform images.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"buildImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildImage and RunImage reference the stack images directly instead of a ClusterStack, the run image target is used in place of a stack id",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
+					"runImage": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage"),
+						},
+					},
 					"serviceAccountName": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -4760,7 +5808,66 @@ func schema_pkg_apis_build_v1alpha2_NamespacedBuilderSpec(ref common.ReferenceCa
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry", "k8s.io/api/core/v1.ObjectReference"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderOrderEntry", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderRollout", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackSpecImage", "k8s.io/api/core/v1.ObjectReference"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PendingBuild(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"changes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"deferredUntil": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeferredUntil is when the earliest build window for the reasons opens, unset if the build waits for approval",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PlatformImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"platform": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"platform", "image"},
+			},
+		},
 	}
 }
 
@@ -4878,11 +5985,17 @@ func schema_pkg_apis_build_v1alpha2_ResolvedClusterStack(ref common.ReferenceCal
 							Format: "int32",
 						},
 					},
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target is the os, arch and distribution of the run image",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackTarget"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatusImage"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ClusterStackStatusImage", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackTarget"},
 	}
 }
 
@@ -5080,6 +6193,13 @@ func schema_pkg_apis_core_v1alpha1_Blob(ref common.ReferenceCallback) common.Ope
 							Format: "int64",
 						},
 					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is populated by the source resolver and identifies the content of the blob at the url",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
@@ -5157,6 +6277,32 @@ func schema_pkg_apis_core_v1alpha1_BuildStack(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_pkg_apis_core_v1alpha1_BuildpackDistro(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_core_v1alpha1_BuildpackInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5348,6 +6494,13 @@ func schema_pkg_apis_core_v1alpha1_BuildpackStatus(ref common.ReferenceCallback)
 							Format: "",
 						},
 					},
+					"storeIndexImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StoreIndexImage is the image index the store image was resolved from, it is empty if the store image is not an index",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"order": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -5384,12 +6537,81 @@ func schema_pkg_apis_core_v1alpha1_BuildpackStatus(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"targets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackTarget"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"id"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStack", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackageInfo", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ImageSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.OrderEntry"},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackStack", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackTarget", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackageInfo", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ImageSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.OrderEntry"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_BuildpackTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BuildpackTarget is an os, arch and distribution supported by a buildpack. Targets replace stacks as of buildpack api 0.10, empty fields match any value.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"os": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"arch": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"variant": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"distros": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackDistro"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackDistro"},
 	}
 }
 
@@ -5496,19 +6718,46 @@ func schema_pkg_apis_core_v1alpha1_Condition(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
-					"message": {
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A human readable message indicating details about the transition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.VolatileTime"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ConfigMapSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"key": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A human readable message indicating details about the transition.",
-							Type:        []string{"string"},
-							Format:      "",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
 				},
-				Required: []string{"type", "status"},
+				Required: []string{"name", "key"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.VolatileTime"},
 	}
 }
 
@@ -5538,10 +6787,51 @@ func schema_pkg_apis_core_v1alpha1_Git(ref common.ReferenceCallback) common.Open
 							Format: "",
 						},
 					},
+					"lfs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LFS downloads the content of Git LFS tracked files when fetching the source",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"sparseCheckout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SparseCheckout only checks out the SubPath and SparseCheckoutPaths directories of the repository",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"sparseCheckoutPaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"verification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verification requires the commit to carry a GPG or SSH signature from a trusted key",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification"),
+						},
+					},
 				},
 				Required: []string{"url", "revision"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification"},
 	}
 }
 
@@ -5662,6 +6952,33 @@ func schema_pkg_apis_core_v1alpha1_OrderEntry(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_pkg_apis_core_v1alpha1_PersistentVolumeClaimSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the directory within the claim that contains the source",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"claimName"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_core_v1alpha1_Registry(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5675,6 +6992,13 @@ func schema_pkg_apis_core_v1alpha1_Registry(ref common.ReferenceCallback) common
 							Format:  "",
 						},
 					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Digest is populated by the source resolver and pins the image to the digest it resolved to",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"imagePullSecrets": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -5695,12 +7019,18 @@ func schema_pkg_apis_core_v1alpha1_Registry(ref common.ReferenceCallback) common
 							},
 						},
 					},
+					"verification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verification requires the image to carry a cosign signature from a trusted key",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification"),
+						},
+					},
 				},
 				Required: []string{"image"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.LocalObjectReference"},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification", "k8s.io/api/core/v1.LocalObjectReference"},
 	}
 }
 
@@ -5735,6 +7065,18 @@ func schema_pkg_apis_core_v1alpha1_ResolvedBlobSource(ref common.ReferenceCallba
 							Format: "int64",
 						},
 					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"versionKind": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
@@ -5787,10 +7129,55 @@ func schema_pkg_apis_core_v1alpha1_ResolvedGitSource(ref common.ReferenceCallbac
 							Format: "",
 						},
 					},
+					"lfs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"sparseCheckout": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"sparseCheckoutPaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"tag": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tag is the tag selected when the revision is a glob or semver constraint",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"verification": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification"),
+						},
+					},
 				},
 				Required: []string{"url", "revision", "type"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification"},
 	}
 }
 
@@ -5807,6 +7194,18 @@ func schema_pkg_apis_core_v1alpha1_ResolvedRegistrySource(ref common.ReferenceCa
 							Format:  "",
 						},
 					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
 					"subPath": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -5833,12 +7232,17 @@ func schema_pkg_apis_core_v1alpha1_ResolvedRegistrySource(ref common.ReferenceCa
 							},
 						},
 					},
+					"verification": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification"),
+						},
+					},
 				},
 				Required: []string{"image"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.LocalObjectReference"},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceVerification", "k8s.io/api/core/v1.LocalObjectReference"},
 	}
 }
 
@@ -5863,11 +7267,52 @@ func schema_pkg_apis_core_v1alpha1_ResolvedSourceConfig(ref common.ReferenceCall
 							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource"),
 						},
 					},
+					"volume": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedVolumeSource"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedBlobSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedGitSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedVolumeSource"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ResolvedVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"persistentVolumeClaim": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.PersistentVolumeClaimSource"),
+						},
+					},
+					"configMap": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ConfigMapSource"),
+						},
+					},
+					"subPath": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedBlobSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedGitSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource"},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ConfigMapSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.PersistentVolumeClaimSource"},
 	}
 }
 
@@ -5892,17 +7337,84 @@ func schema_pkg_apis_core_v1alpha1_SourceConfig(ref common.ReferenceCallback) co
 							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry"),
 						},
 					},
+					"volume": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Volume"),
+						},
+					},
 					"subPath": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
+					"includePaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludePaths are gitignore style patterns, relative to the repository root, of the files that trigger a build when changed by a new git commit",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"excludePaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludePaths are gitignore style patterns of files that never trigger a build when changed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Blob", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Git", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Volume"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_SourceVerification(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef references a secret with the public keys trusted to sign the source. Git commits are verified with the gpg-keys and ssh-keys entries and registry images with the cosign.pub entry.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
 				},
+				Required: []string{"secretRef"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Blob", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Git", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry"},
+			"k8s.io/api/core/v1.LocalObjectReference"},
 	}
 }
 
@@ -5968,3 +7480,36 @@ func schema_pkg_apis_core_v1alpha1_VolatileTime(ref common.ReferenceCallback) co
 			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
+
+func schema_pkg_apis_core_v1alpha1_Volume(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"persistentVolumeClaim": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PersistentVolumeClaim copies the source from a directory of a claim in the namespace of the Image",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.PersistentVolumeClaimSource"),
+						},
+					},
+					"configMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMap extracts the source from a zip, tar or tar.gz archive stored in a key of a ConfigMap",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ConfigMapSource"),
+						},
+					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Digest is populated by the source resolver and identifies the content of the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ConfigMapSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.PersistentVolumeClaimSource"},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
@@ -25,6 +26,7 @@ import (
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/buildchange"
 	"github.com/pivotal/kpack/pkg/buildpod"
+	"github.com/pivotal/kpack/pkg/buildqueue"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
 	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
@@ -78,6 +80,7 @@ func NewController(
 	attester SLSAAttester,
 	secretFetcher SecretFetcher,
 	featureFlags config.FeatureFlags,
+	queueLimits buildqueue.Limits,
 ) *controller.Impl {
 	c := &Reconciler{
 		Client:            opt.Client,
@@ -91,6 +94,10 @@ func NewController(
 		Attester:          attester,
 		SecretFetcher:     secretFetcher,
 		FeatureFlags:      featureFlags,
+		Queue: buildqueue.Queue{
+			Limits:      queueLimits,
+			BuildLister: informer.Lister(),
+		},
 	}
 
 	logger := opt.Logger.With(
@@ -109,6 +116,33 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	if queueLimits.Enabled() {
+		enqueueQueuedBuilds := func() {
+			if err := c.enqueueQueuedBuilds(impl.Enqueue); err != nil {
+				logger.Errorw("Failed listing queued builds", zap.Error(err))
+			}
+		}
+
+		informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldBuild, ok := oldObj.(*buildapi.Build)
+				if !ok {
+					return
+				}
+				newBuild, ok := newObj.(*buildapi.Build)
+				if !ok {
+					return
+				}
+				if !oldBuild.Finished() && newBuild.Finished() {
+					enqueueQueuedBuilds()
+				}
+			},
+			DeleteFunc: func(interface{}) {
+				enqueueQueuedBuilds()
+			},
+		})
+	}
+
 	return impl
 }
 
@@ -124,6 +158,7 @@ type Reconciler struct {
 	Attester          SLSAAttester
 	SecretFetcher     SecretFetcher
 	FeatureFlags      config.FeatureFlags
+	Queue             buildqueue.Queue
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -157,6 +192,15 @@ func (c *Reconciler) reconcile(ctx context.Context, build *buildapi.Build) error
 		return nil
 	}
 
+	if build.CancelRequested() {
+		return c.cancelBuild(ctx, build)
+	}
+
+	queued, err := c.queueBuild(build)
+	if err != nil || queued {
+		return err
+	}
+
 	pod, err := c.reconcileBuildPod(ctx, build)
 	if err != nil && !k8s_errors.IsInvalid(err) {
 		return err
@@ -206,6 +250,24 @@ func (c *Reconciler) reconcile(ctx context.Context, build *buildapi.Build) error
 	return nil
 }
 
+// cancelBuild stops the build pod, if it was created, and marks the build canceled
+func (c *Reconciler) cancelBuild(ctx context.Context, build *buildapi.Build) error {
+	_, err := c.PodLister.Pods(build.Namespace).Get(build.PodName())
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil {
+		err = c.K8sClient.CoreV1().Pods(build.Namespace).Delete(ctx, build.PodName(), metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	build.Status.Canceled()
+	return nil
+}
+
 func (c *Reconciler) setBuildReady(ctx context.Context, pod *corev1.Pod) (*corev1.Pod, error) {
 	if _, found := pod.Annotations[buildapi.BuildReadyAnnotation]; found {
 		return pod, nil
@@ -285,14 +347,14 @@ func (c *Reconciler) conditionForPod(pod *corev1.Pod, stepsCompleted []string) c
 				},
 			}
 		}
-		for _, s := range pod.Status.InitContainerStatuses {
+		for _, s := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
 			if s.State.Terminated != nil && s.State.Terminated.ExitCode != 0 && s.State.Terminated.Message != "" {
 				terminationMessage, _ := c.PodProgressLogger.GetTerminationMessage(pod, &s)
 				return corev1alpha1.Conditions{
 					{
 						Type:               corev1alpha1.ConditionSucceeded,
 						Status:             corev1.ConditionFalse,
-						Reason:             string(corev1.PodFailed),
+						Reason:             failureReason(pod),
 						Message:            "Error: " + pod.Status.Message + terminationMessage,
 						LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
 					},
@@ -303,7 +365,7 @@ func (c *Reconciler) conditionForPod(pod *corev1.Pod, stepsCompleted []string) c
 			{
 				Type:               corev1alpha1.ConditionSucceeded,
 				Status:             corev1.ConditionFalse,
-				Reason:             string(corev1.PodFailed),
+				Reason:             failureReason(pod),
 				Message:            pod.Status.Message,
 				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
 			},
@@ -334,6 +396,27 @@ func (c *Reconciler) conditionForPod(pod *corev1.Pod, stepsCompleted []string) c
 	}
 }
 
+// failureReason classifies the step that failed the pod. Detect and build failures are caused by the application
+// and are not retried. The steps are regular containers when the build pod uses standard containers.
+func failureReason(pod *corev1.Pod) string {
+	for _, s := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if s.State.Terminated == nil || s.State.Terminated.ExitCode == 0 {
+			continue
+		}
+
+		switch {
+		case s.Name == buildapi.PrepareContainerName && strings.HasPrefix(s.State.Terminated.Message, buildapi.SourceVerificationFailedReason+":"):
+			return buildapi.SourceVerificationFailedReason
+		case s.Name == buildapi.DetectContainerName:
+			return buildapi.DetectFailedReason
+		case s.Name == buildapi.BuildContainerName:
+			return buildapi.CompileFailedReason
+		}
+		break
+	}
+	return string(corev1.PodFailed)
+}
+
 func stepStates(pod *corev1.Pod) []corev1.ContainerState {
 	states := make([]corev1.ContainerState, 0, len(buildapi.BuildSteps()))
 	for _, s := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
@@ -34,6 +34,7 @@ import (
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/buildpod"
+	"github.com/pivotal/kpack/pkg/buildqueue"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/pivotal/kpack/pkg/config"
 	"github.com/pivotal/kpack/pkg/reconciler/build"
@@ -68,6 +69,7 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 		podProgressLogger     = &testPodProgressLogger{}
 		ctx                   = context.Background()
 		featureFlags          = config.FeatureFlags{}
+		queueLimits           = buildqueue.Limits{}
 		reactors              = make([]reactor, 0)
 	)
 
@@ -96,6 +98,10 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 				Attester:          fakeAttester,
 				SecretFetcher:     fakeSecretFetcher,
 				FeatureFlags:      featureFlags,
+				Queue: buildqueue.Queue{
+					Limits:      queueLimits,
+					BuildLister: listers.GetBuildLister(),
+				},
 			}
 
 			rtesting.PrependGenerateNameReactor(&fakeClient.Fake)
@@ -350,6 +356,196 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the build queue has limits", func() {
+			runningBuild := func(namespace string) *buildapi.Build {
+				running := bld.DeepCopy()
+				running.Name = "running-build"
+				running.Namespace = namespace
+				running.Status.PodName = "running-build-build-pod"
+				return running
+			}
+
+			it.Before(func() {
+				queueLimits = buildqueue.Limits{PerNamespace: 1}
+			})
+
+			it("queues the build when the limit is reached", func() {
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						bld,
+						runningBuild(namespace),
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: bld.ObjectMeta,
+								Spec:       bld.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionSucceeded,
+												Status:  corev1.ConditionUnknown,
+												Reason:  buildapi.BuildQueuedReason,
+												Message: "Build is queued at position 1",
+											},
+											{
+												Type:    buildapi.ConditionQueued,
+												Status:  corev1.ConditionTrue,
+												Reason:  buildapi.BuildQueuedReason,
+												Message: "Build is queued at position 1",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("schedules a pod once the build is admitted", func() {
+				buildPod, err := podGenerator.Generate(ctx, bld)
+				require.NoError(t, err)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						bld,
+						runningBuild("some-other-namespace"),
+					},
+					WantErr: false,
+					WantCreates: []runtime.Object{
+						buildPod,
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: bld.ObjectMeta,
+								Spec:       bld.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionSucceeded,
+												Status: corev1.ConditionUnknown,
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+								},
+							},
+						},
+					},
+				})
+			})
+		})
+
+		when("the build is canceled", func() {
+			canceledBuild := func() *buildapi.Build {
+				canceled := bld.DeepCopy()
+				canceled.Annotations = map[string]string{buildapi.BuildCancelAnnotation: ""}
+				return canceled
+			}
+
+			canceledStatus := corev1alpha1.Status{
+				ObservedGeneration: originalGeneration,
+				Conditions: corev1alpha1.Conditions{
+					{
+						Type:    corev1alpha1.ConditionSucceeded,
+						Status:  corev1.ConditionFalse,
+						Reason:  buildapi.BuildCanceledReason,
+						Message: "Build was canceled",
+					},
+				},
+			}
+
+			it("deletes the build pod and marks the build canceled", func() {
+				canceled := canceledBuild()
+				canceled.Status.PodName = "build-name-build-pod"
+				pod, err := podGenerator.Generate(ctx, canceled)
+				require.NoError(t, err)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						canceled,
+						pod,
+					},
+					WantErr: false,
+					WantDeletes: []clientgotesting.DeleteActionImpl{
+						{
+							ActionImpl: clientgotesting.ActionImpl{
+								Namespace: namespace,
+								Resource: schema.GroupVersionResource{
+									Resource: "pods",
+								},
+							},
+							Name: "build-name-build-pod",
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: canceled.ObjectMeta,
+								Spec:       canceled.Spec,
+								Status: buildapi.BuildStatus{
+									Status:  canceledStatus,
+									PodName: "build-name-build-pod",
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("marks a build canceled before its pod is created", func() {
+				canceled := canceledBuild()
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						canceled,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: canceled.ObjectMeta,
+								Spec:       canceled.Spec,
+								Status: buildapi.BuildStatus{
+									Status: canceledStatus,
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("does not cancel a finished build", func() {
+				canceled := canceledBuild()
+				canceled.Status.ObservedGeneration = originalGeneration
+				canceled.Status.Conditions = corev1alpha1.Conditions{
+					{
+						Type:   corev1alpha1.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						canceled,
+					},
+					WantErr: false,
+				})
+			})
+		})
+
 		when("pod executing", func() {
 			it("updates the status step states with the statuses of the containers", func() {
 				pod, err := podGenerator.Generate(ctx, bld)
@@ -769,6 +965,224 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			it("sets the reason to SourceVerificationFailed when the source is not trusted", func() {
+				pod, err := podGenerator.Generate(ctx, bld)
+				require.NoError(t, err)
+				pod.Status.Phase = corev1.PodFailed
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "prepare",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:    1,
+								Reason:      "Error",
+								Message:     "SourceVerificationFailed: commit is not signed",
+								ContainerID: "container.ID",
+							},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						bld,
+						pod,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: bld.ObjectMeta,
+								Spec:       bld.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionSucceeded,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.SourceVerificationFailedReason,
+												Message: "Error:  Fake container logs",
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+									StepStates: []corev1.ContainerState{
+										{
+											Terminated: &corev1.ContainerStateTerminated{
+												ExitCode:    1,
+												Reason:      "Error",
+												Message:     "SourceVerificationFailed: commit is not signed",
+												ContainerID: "container.ID",
+											},
+										},
+									},
+									StepsCompleted: []string{},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("sets the reason to DetectFailed when no buildpacks detect", func() {
+				pod, err := podGenerator.Generate(ctx, bld)
+				require.NoError(t, err)
+				pod.Status.Phase = corev1.PodFailed
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "prepare",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:    0,
+								Message:     "Container prepare terminated successfully",
+								ContainerID: "container.ID",
+							},
+						},
+					},
+					{
+						Name: "detect",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:    20,
+								Reason:      "Error",
+								Message:     "No buildpack groups passed detection",
+								ContainerID: "container.ID2",
+							},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						bld,
+						pod,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: bld.ObjectMeta,
+								Spec:       bld.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionSucceeded,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.DetectFailedReason,
+												Message: "Error:  Fake container logs",
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+									StepStates: []corev1.ContainerState{
+										{
+											Terminated: &corev1.ContainerStateTerminated{
+												ExitCode:    0,
+												Message:     "Container prepare terminated successfully",
+												ContainerID: "container.ID",
+											},
+										},
+										{
+											Terminated: &corev1.ContainerStateTerminated{
+												ExitCode:    20,
+												Reason:      "Error",
+												Message:     "No buildpack groups passed detection",
+												ContainerID: "container.ID2",
+											},
+										},
+									},
+									StepsCompleted: []string{"prepare"},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("sets the reason to CompileFailed when the build step runs as a standard container", func() {
+				pod, err := podGenerator.Generate(ctx, bld)
+				require.NoError(t, err)
+				pod.Status.Phase = corev1.PodFailed
+				pod.Status.InitContainerStatuses = nil
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "detect",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:    0,
+								Message:     "Container detect terminated successfully",
+								ContainerID: "container.ID",
+							},
+						},
+					},
+					{
+						Name: "build",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:    51,
+								Reason:      "Error",
+								Message:     "failed to build",
+								ContainerID: "container.ID2",
+							},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						bld,
+						pod,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: bld.ObjectMeta,
+								Spec:       bld.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionSucceeded,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.CompileFailedReason,
+												Message: "Error:  Fake container logs",
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+									StepStates: []corev1.ContainerState{
+										{
+											Terminated: &corev1.ContainerStateTerminated{
+												ExitCode:    0,
+												Message:     "Container detect terminated successfully",
+												ContainerID: "container.ID",
+											},
+										},
+										{
+											Terminated: &corev1.ContainerStateTerminated{
+												ExitCode:    51,
+												Reason:      "Error",
+												Message:     "failed to build",
+												ContainerID: "container.ID2",
+											},
+										},
+									},
+									StepsCompleted: []string{"detect"},
+								},
+							},
+						},
+					},
+				})
+			})
+
 			it("does not recreate pods if build has finished", func() {
 				rt.Test(rtesting.TableRow{
 					Key: key,
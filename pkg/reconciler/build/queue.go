@@ -0,0 +1,47 @@
+package build
+
+import (
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// queueBuild returns true and sets the Queued condition if the build does not have a pod and the build queue
+// has not admitted it yet
+func (c *Reconciler) queueBuild(build *buildapi.Build) (bool, error) {
+	if !c.Queue.Limits.Enabled() {
+		return false, nil
+	}
+
+	_, err := c.PodLister.Pods(build.Namespace).Get(build.PodName())
+	if err == nil {
+		return false, nil
+	} else if !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+
+	admitted, position, err := c.Queue.Admit(build)
+	if err != nil || admitted {
+		return false, err
+	}
+
+	build.Status.Queued(position)
+	return true, nil
+}
+
+// enqueueQueuedBuilds reconciles every queued build so they are admitted, or their position is updated, when a
+// running build finishes
+func (c *Reconciler) enqueueQueuedBuilds(enqueue func(interface{})) error {
+	builds, err := c.Lister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	for _, build := range builds {
+		if build.Status.GetCondition(buildapi.ConditionQueued).IsTrue() {
+			enqueue(build)
+		}
+	}
+	return nil
+}
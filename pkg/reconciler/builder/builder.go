@@ -65,9 +65,14 @@ func NewController(
 	clusterStoreInformer buildinformers.ClusterStoreInformer,
 	buildpackInformer buildinformers.BuildpackInformer,
 	clusterBuildpackInformer buildinformers.ClusterBuildpackInformer,
+	extensionInformer buildinformers.ExtensionInformer,
+	clusterExtensionInformer buildinformers.ClusterExtensionInformer,
 	clusterStackInformer buildinformers.ClusterStackInformer,
+	stackReader reconciler.StackReader,
 	clusterLifecycleInformer buildinformers.ClusterLifecycleInformer,
 	secretFetcher Fetcher,
+	imageInformer buildinformers.ImageInformer,
+	buildInformer buildinformers.BuildInformer,
 ) *controller.Impl {
 	c := &Reconciler{
 		Client:                 opt.Client,
@@ -77,9 +82,16 @@ func NewController(
 		ClusterStoreLister:     clusterStoreInformer.Lister(),
 		BuildpackLister:        buildpackInformer.Lister(),
 		ClusterBuildpackLister: clusterBuildpackInformer.Lister(),
+		ExtensionLister:        extensionInformer.Lister(),
+		ClusterExtensionLister: clusterExtensionInformer.Lister(),
 		ClusterStackLister:     clusterStackInformer.Lister(),
+		StackReader:            stackReader,
 		ClusterLifecycleLister: clusterLifecycleInformer.Lister(),
 		SecretFetcher:          secretFetcher,
+		Rollout: reconciler.BuilderRollout{
+			ImageLister: imageInformer.Lister(),
+			BuildLister: buildInformer.Lister(),
+		},
 	}
 
 	logger := opt.Logger.With(
@@ -98,6 +110,19 @@ func NewController(
 		Handler:    controller.HandleAll(impl.Enqueue),
 	})
 
+	// canary images progress the rollout of the builder as their builds complete
+	imageInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		image, ok := obj.(*buildapi.Image)
+		if !ok || image.Spec.Builder.Kind != buildapi.BuilderKind {
+			return
+		}
+		builder, err := c.BuilderLister.Builders(image.Namespace).Get(image.Spec.Builder.Name)
+		if err != nil || !reconciler.CanaryInProgress(builder.Spec.Rollout, builder.Status.Rollout, image) {
+			return
+		}
+		impl.EnqueueKey(builder.NamespacedName())
+	}))
+
 	c.Tracker = tracker.New(impl.EnqueueKey, opt.TrackerResyncPeriod())
 	clusterStoreInformer.Informer().AddEventHandler(controller.HandleAll(
 		controller.EnsureTypeMeta(
@@ -124,6 +149,16 @@ func NewController(
 			c.Tracker.OnChanged,
 			buildapi.SchemeGroupVersion.WithKind(buildapi.ClusterBuildpackKind)),
 	))
+	extensionInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.Tracker.OnChanged,
+			buildapi.SchemeGroupVersion.WithKind(buildapi.ExtensionKind)),
+	))
+	clusterExtensionInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.Tracker.OnChanged,
+			buildapi.SchemeGroupVersion.WithKind(buildapi.ClusterExtensionKind)),
+	))
 
 	return impl
 }
@@ -137,9 +172,13 @@ type Reconciler struct {
 	ClusterStoreLister     buildlisters.ClusterStoreLister
 	BuildpackLister        buildlisters.BuildpackLister
 	ClusterBuildpackLister buildlisters.ClusterBuildpackLister
+	ExtensionLister        buildlisters.ExtensionLister
+	ClusterExtensionLister buildlisters.ClusterExtensionLister
 	ClusterStackLister     buildlisters.ClusterStackLister
+	StackReader            reconciler.StackReader
 	ClusterLifecycleLister buildlisters.ClusterLifecycleLister
 	SecretFetcher          Fetcher
+	Rollout                reconciler.BuilderRollout
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -156,6 +195,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	builder = builder.DeepCopy()
+	previousImage := builder.Status.LatestImage
 
 	builderRecord, creationError := c.reconcileBuilder(ctx, builder)
 	if creationError != nil {
@@ -169,20 +209,25 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	builder.Status.BuilderRecord(builderRecord)
+	if err := c.Rollout.Reconcile(builderReference(builder), builder.Spec.Rollout, previousImage, &builder.Status); err != nil {
+		return err
+	}
 	return c.updateStatus(ctx, builder)
 }
 
 func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Builder) (buildapi.BuilderRecord, error) {
-	c.Tracker.Track(reconciler.Key{
-		NamespacedName: types.NamespacedName{
-			Name:      builder.Spec.Stack.Name,
-			Namespace: metav1.NamespaceAll,
-		},
-		GroupKind: schema.GroupKind{
-			Group: "kpack.io",
-			Kind:  buildapi.ClusterStackKind,
-		},
-	}, builder.NamespacedName())
+	if !builder.Spec.ReferencesStackImages() {
+		c.Tracker.Track(reconciler.Key{
+			NamespacedName: types.NamespacedName{
+				Name:      builder.Spec.Stack.Name,
+				Namespace: metav1.NamespaceAll,
+			},
+			GroupKind: schema.GroupKind{
+				Group: "kpack.io",
+				Kind:  buildapi.ClusterStackKind,
+			},
+		}, builder.NamespacedName())
+	}
 
 	lifecycleName := builder.Spec.Lifecycle.Name
 	if lifecycleName == "" {
@@ -242,13 +287,36 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Bui
 		return buildapi.BuilderRecord{}, err
 	}
 
-	clusterStack, err := c.ClusterStackLister.Get(builder.Spec.Stack.Name)
+	c.Tracker.TrackKind(schema.GroupKind{
+		Group: "kpack.io",
+		Kind:  buildapi.ExtensionKind,
+	}, builder.NamespacedName())
+
+	c.Tracker.TrackKind(schema.GroupKind{
+		Group: "kpack.io",
+		Kind:  buildapi.ClusterExtensionKind,
+	}, builder.NamespacedName())
+
+	extensions, err := c.ExtensionLister.Extensions(builder.Namespace).List(labels.Everything())
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
 	}
 
-	if !clusterStack.Status.GetCondition(corev1alpha1.ConditionReady).IsTrue() {
-		return buildapi.BuilderRecord{}, errors.Errorf("Error: clusterstack '%s' is not ready", clusterStack.Name)
+	clusterExtensions, err := c.ClusterExtensionLister.List(labels.Everything())
+	if err != nil {
+		return buildapi.BuilderRecord{}, err
+	}
+
+	var clusterStack *buildapi.ClusterStack
+	if !builder.Spec.ReferencesStackImages() {
+		clusterStack, err = c.ClusterStackLister.Get(builder.Spec.Stack.Name)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+
+		if !clusterStack.Status.GetCondition(corev1alpha1.ConditionReady).IsTrue() {
+			return buildapi.BuilderRecord{}, errors.Errorf("Error: clusterstack '%s' is not ready", clusterStack.Name)
+		}
 	}
 
 	clusterLifecycle, err := c.ClusterLifecycleLister.Get(lifecycleName)
@@ -268,6 +336,13 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Bui
 		return buildapi.BuilderRecord{}, err
 	}
 
+	if clusterStack == nil {
+		clusterStack, err = reconciler.StackFromImages(c.StackReader, builderKeychain, builder.Spec.BuilderSpec)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+	}
+
 	stackKeychain := builderKeychain
 	if clusterStack.Spec.ServiceAccountRef != nil {
 		stackKeychain, err = c.KeychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{
@@ -290,7 +365,7 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Bui
 		}
 	}
 
-	fetcher := cnb.NewRemoteBuildpackFetcher(c.KeychainFactory, clusterStore, buildpacks, clusterBuildpacks)
+	fetcher := cnb.NewRemoteBuildpackFetcher(c.KeychainFactory, clusterStore, buildpacks, clusterBuildpacks, extensions, clusterExtensions)
 
 	serviceAccountSecrets, err := c.SecretFetcher.SecretsForServiceAccount(ctx, builder.Spec.ServiceAccount(), builder.Namespace)
 	if err != nil {
@@ -321,6 +396,14 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Bui
 	return buildRecord, nil
 }
 
+func builderReference(builder *buildapi.Builder) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		Kind:      buildapi.BuilderKind,
+		Namespace: builder.Namespace,
+		Name:      builder.Name,
+	}
+}
+
 func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.Builder) error {
 	desired.Status.ObservedGeneration = desired.Generation
 
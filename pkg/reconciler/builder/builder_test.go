@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/controller"
@@ -26,6 +27,7 @@ import (
 	"github.com/pivotal/kpack/pkg/cnb"
 	kreconciler "github.com/pivotal/kpack/pkg/reconciler"
 	"github.com/pivotal/kpack/pkg/reconciler/builder"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterstack/clusterstackfakes"
 	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
 	"github.com/pivotal/kpack/pkg/registry"
 	"github.com/pivotal/kpack/pkg/registry/registryfakes"
@@ -50,6 +52,7 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 		builderCreator    = &testhelpers.FakeBuilderCreator{}
 		keychainFactory   = &registryfakes.FakeKeychainFactory{}
 		fakeTracker       = &testhelpers.FakeTracker{}
+		stackReader       = &clusterstackfakes.FakeClusterStackReader{}
 		fakeSecretFetcher = &secretfakes.FakeFetchSecret{
 			FakeSecrets: []*corev1.Secret{},
 		}
@@ -69,9 +72,16 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 				ClusterStoreLister:     listers.GetClusterStoreLister(),
 				BuildpackLister:        listers.GetBuildpackLister(),
 				ClusterBuildpackLister: listers.GetClusterBuildpackLister(),
+				ExtensionLister:        listers.GetExtensionLister(),
+				ClusterExtensionLister: listers.GetClusterExtensionLister(),
 				ClusterStackLister:     listers.GetClusterStackLister(),
+				StackReader:            stackReader,
 				ClusterLifecycleLister: listers.GetClusterLifecycleLister(),
 				SecretFetcher:          fakeSecretFetcher,
+				Rollout: kreconciler.BuilderRollout{
+					ImageLister: listers.GetImageLister(),
+					BuildLister: listers.GetBuildLister(),
+				},
 			}
 			return &kreconciler.NetworkErrorReconciler{Reconciler: r}, rtesting.ActionRecorderList{fakeClient, k8sfakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
 		})
@@ -304,7 +314,7 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 				},
 			}
 
-			expectedFetcher := cnb.NewRemoteBuildpackFetcher(keychainFactory, clusterStore, []*buildapi.Buildpack{buildpack}, []*buildapi.ClusterBuildpack{clusterBuildpack})
+			expectedFetcher := cnb.NewRemoteBuildpackFetcher(keychainFactory, clusterStore, []*buildapi.Buildpack{buildpack}, []*buildapi.ClusterBuildpack{clusterBuildpack}, nil, nil)
 
 			rt.Test(rtesting.TableRow{
 				Key: builderKey,
@@ -339,6 +349,89 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 			}}, builderCreator.CreateBuilderCalls)
 		})
 
+		it("creates the builder from the build and run images without a ClusterStack", func() {
+			builder.Spec.Stack = corev1.ObjectReference{Kind: buildapi.ClusterStackKind}
+			builder.Spec.BuildImage = buildapi.ClusterStackSpecImage{Image: "example.com/build-image"}
+			builder.Spec.RunImage = buildapi.ClusterStackSpecImage{Image: "example.com/run-image"}
+
+			resolvedStack := buildapi.ResolvedClusterStack{
+				BuildImage: buildapi.ClusterStackStatusImage{LatestImage: "example.com/build-image@sha256:build"},
+				RunImage:   buildapi.ClusterStackStatusImage{LatestImage: "example.com/run-image@sha256:run"},
+				Target:     &corev1alpha1.BuildpackTarget{OS: "linux", Arch: "amd64"},
+			}
+			stackReader.ReadReturns(resolvedStack, nil)
+
+			builderCreator.Record = buildapi.BuilderRecord{
+				Image: builderIdentifier,
+				Stack: corev1alpha1.BuildStack{
+					RunImage: "example.com/run-image@sha256:run",
+				},
+				Buildpacks: corev1alpha1.BuildpackMetadataList{},
+			}
+
+			expectedBuilder := &buildapi.Builder{
+				ObjectMeta: builder.ObjectMeta,
+				Spec:       builder.Spec,
+				Status: buildapi.BuilderStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: 1,
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionReady,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   buildapi.ConditionUpToDate,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					BuilderMetadata: []corev1alpha1.BuildpackMetadata{},
+					Stack: corev1alpha1.BuildStack{
+						RunImage: "example.com/run-image@sha256:run",
+					},
+					LatestImage: builderIdentifier,
+				},
+			}
+
+			rt.Test(rtesting.TableRow{
+				Key: builderKey,
+				Objects: []runtime.Object{
+					clusterLifecycle,
+					clusterStore,
+					builder,
+					buildpack,
+					clusterBuildpack,
+					&signingSecret,
+					&serviceAccount,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: expectedBuilder,
+					},
+				},
+			})
+
+			require.Equal(t, 1, stackReader.ReadCallCount())
+			_, stackSpec := stackReader.ReadArgsForCall(0)
+			assert.Equal(t, buildapi.ClusterStackSpec{
+				BuildImage: builder.Spec.BuildImage,
+				RunImage:   builder.Spec.RunImage,
+			}, stackSpec)
+
+			require.Len(t, builderCreator.CreateBuilderCalls, 1)
+			assert.Equal(t, &buildapi.ClusterStack{
+				Spec: stackSpec,
+				Status: buildapi.ClusterStackStatus{
+					ResolvedClusterStack: resolvedStack,
+				},
+			}, builderCreator.CreateBuilderCalls[0].ClusterStack)
+			assert.False(t, fakeTracker.IsTracking(
+				kreconciler.KeyForObject(clusterStack),
+				builder.NamespacedName()))
+		})
+
 		it("tracks the store and buildpack sources for a custom builder", func() {
 			builderCreator.Record = buildapi.BuilderRecord{
 				Image: builderIdentifier,
@@ -406,6 +499,12 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 			require.True(t, fakeTracker.IsTrackingKind(
 				kreconciler.KeyForObject(clusterBuildpack).GroupKind,
 				builder.NamespacedName()))
+			require.True(t, fakeTracker.IsTrackingKind(
+				schema.GroupKind{Group: "kpack.io", Kind: buildapi.ExtensionKind},
+				builder.NamespacedName()))
+			require.True(t, fakeTracker.IsTrackingKind(
+				schema.GroupKind{Group: "kpack.io", Kind: buildapi.ClusterExtensionKind},
+				builder.NamespacedName()))
 		})
 
 		it("does not update the status with no status change", func() {
@@ -0,0 +1,112 @@
+package reconciler
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+)
+
+// BuilderRollout stages the rebuild of the Images built with a builder when the latest image of the builder changes
+type BuilderRollout struct {
+	ImageLister buildlisters.ImageLister
+	BuildLister buildlisters.BuildLister
+}
+
+// Reconcile starts a rollout when the latest image of the builder changes from the previous image and promotes it
+// once enough canary Images have built the latest image successfully
+func (r *BuilderRollout) Reconcile(builder corev1.ObjectReference, rollout *buildapi.BuilderRollout, previousImage string, status *buildapi.BuilderStatus) error {
+	if rollout == nil || status.LatestImage == "" {
+		status.Rollout = nil
+		return nil
+	}
+
+	if status.Rollout == nil || status.Rollout.Image != status.LatestImage {
+		status.Rollout = &buildapi.BuilderRolloutStatus{Image: status.LatestImage, Phase: buildapi.RolloutPhaseCanary}
+
+		// there is nothing to roll out for the first image of the builder
+		if previousImage == "" || previousImage == status.LatestImage {
+			status.Rollout.Phase = buildapi.RolloutPhasePromoted
+			return nil
+		}
+	}
+
+	if status.Rollout.Phase == buildapi.RolloutPhasePromoted {
+		return nil
+	}
+
+	images, err := r.builderImages(builder)
+	if err != nil {
+		return err
+	}
+
+	var canary, succeeded, failed, skipped int64
+	for _, image := range images {
+		if !rollout.IsCanary(image) {
+			continue
+		}
+		canary++
+
+		build, err := r.latestBuild(image)
+		if err != nil {
+			return err
+		}
+		if build == nil || build.Spec.Builder.Image != status.LatestImage {
+			// canaries that are waiting for a build window or do not allow the builder change never build it
+			if image.Status.PendingBuild != nil {
+				skipped++
+			}
+			continue
+		}
+
+		switch {
+		case build.IsSuccess():
+			succeeded++
+		case build.IsFailure():
+			failed++
+		}
+	}
+
+	rollout.Progress(status.Rollout, canary, succeeded, failed, skipped)
+	return nil
+}
+
+func (r *BuilderRollout) builderImages(builder corev1.ObjectReference) ([]*buildapi.Image, error) {
+	var images []*buildapi.Image
+	var err error
+	if builder.Kind == buildapi.ClusterBuilderKind {
+		images, err = r.ImageLister.List(labels.Everything())
+	} else {
+		images, err = r.ImageLister.Images(builder.Namespace).List(labels.Everything())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var builderImages []*buildapi.Image
+	for _, image := range images {
+		if image.Spec.Builder.Kind == builder.Kind && image.Spec.Builder.Name == builder.Name {
+			builderImages = append(builderImages, image)
+		}
+	}
+	return builderImages, nil
+}
+
+func (r *BuilderRollout) latestBuild(image *buildapi.Image) (*buildapi.Build, error) {
+	if image.Status.LatestBuildRef == "" {
+		return nil, nil
+	}
+
+	build, err := r.BuildLister.Builds(image.Namespace).Get(image.Status.LatestBuildRef)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return build, err
+}
+
+// CanaryInProgress is true if the image is a canary of a builder rollout that has not been promoted
+func CanaryInProgress(rollout *buildapi.BuilderRollout, status *buildapi.BuilderRolloutStatus, image *buildapi.Image) bool {
+	return rollout != nil && status != nil && status.Phase != buildapi.RolloutPhasePromoted && rollout.IsCanary(image)
+}
@@ -63,9 +63,13 @@ func NewController(
 	keychainFactory registry.KeychainFactory,
 	clusterStoreInformer buildinformers.ClusterStoreInformer,
 	clusterBuildpackInformer buildinformers.ClusterBuildpackInformer,
+	clusterExtensionInformer buildinformers.ClusterExtensionInformer,
 	clusterStackInformer buildinformers.ClusterStackInformer,
+	stackReader reconciler.StackReader,
 	clusterLifecycleInformer buildinformers.ClusterLifecycleInformer,
 	secretFetcher Fetcher,
+	imageInformer buildinformers.ImageInformer,
+	buildInformer buildinformers.BuildInformer,
 ) *controller.Impl {
 	c := &Reconciler{
 		Client:                 opt.Client,
@@ -74,9 +78,15 @@ func NewController(
 		KeychainFactory:        keychainFactory,
 		ClusterStoreLister:     clusterStoreInformer.Lister(),
 		ClusterBuildpackLister: clusterBuildpackInformer.Lister(),
+		ClusterExtensionLister: clusterExtensionInformer.Lister(),
 		ClusterStackLister:     clusterStackInformer.Lister(),
+		StackReader:            stackReader,
 		ClusterLifecycleLister: clusterLifecycleInformer.Lister(),
 		SecretFetcher:          secretFetcher,
+		Rollout: reconciler.BuilderRollout{
+			ImageLister: imageInformer.Lister(),
+			BuildLister: buildInformer.Lister(),
+		},
 	}
 
 	logger := opt.Logger.With(
@@ -95,12 +105,30 @@ func NewController(
 		Handler:    controller.HandleAll(impl.Enqueue),
 	})
 
+	// canary images progress the rollout of the cluster builder as their builds complete
+	imageInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		image, ok := obj.(*buildapi.Image)
+		if !ok || image.Spec.Builder.Kind != buildapi.ClusterBuilderKind {
+			return
+		}
+		builder, err := c.ClusterBuilderLister.Get(image.Spec.Builder.Name)
+		if err != nil || !reconciler.CanaryInProgress(builder.Spec.Rollout, builder.Status.Rollout, image) {
+			return
+		}
+		impl.EnqueueKey(builder.NamespacedName())
+	}))
+
 	c.Tracker = tracker.New(impl.EnqueueKey, opt.TrackerResyncPeriod())
 	clusterBuildpackInformer.Informer().AddEventHandler(controller.HandleAll(
 		controller.EnsureTypeMeta(
 			c.Tracker.OnChanged,
 			buildapi.SchemeGroupVersion.WithKind(buildapi.ClusterBuildpackKind)),
 	))
+	clusterExtensionInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.Tracker.OnChanged,
+			buildapi.SchemeGroupVersion.WithKind(buildapi.ClusterExtensionKind)),
+	))
 
 	clusterStoreInformer.Informer().AddEventHandler(controller.HandleAll(
 		controller.EnsureTypeMeta(
@@ -129,9 +157,12 @@ type Reconciler struct {
 	Tracker                reconciler.Tracker
 	ClusterStoreLister     buildlisters.ClusterStoreLister
 	ClusterBuildpackLister buildlisters.ClusterBuildpackLister
+	ClusterExtensionLister buildlisters.ClusterExtensionLister
 	ClusterStackLister     buildlisters.ClusterStackLister
+	StackReader            reconciler.StackReader
 	ClusterLifecycleLister buildlisters.ClusterLifecycleLister
 	SecretFetcher          Fetcher
+	Rollout                reconciler.BuilderRollout
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -148,6 +179,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	builder = builder.DeepCopy()
+	previousImage := builder.Status.LatestImage
 
 	builderRecord, creationError := c.reconcileBuilder(ctx, builder)
 	if creationError != nil {
@@ -162,20 +194,25 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	builder.Status.BuilderRecord(builderRecord)
+	if err := c.Rollout.Reconcile(builderReference(builder), builder.Spec.Rollout, previousImage, &builder.Status); err != nil {
+		return err
+	}
 	return c.updateStatus(ctx, builder)
 }
 
 func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.ClusterBuilder) (buildapi.BuilderRecord, error) {
-	c.Tracker.Track(reconciler.Key{
-		NamespacedName: types.NamespacedName{
-			Name:      builder.Spec.Stack.Name,
-			Namespace: corev1.NamespaceAll,
-		},
-		GroupKind: schema.GroupKind{
-			Group: "kpack.io",
-			Kind:  buildapi.ClusterStackKind,
-		},
-	}, builder.NamespacedName())
+	if !builder.Spec.ReferencesStackImages() {
+		c.Tracker.Track(reconciler.Key{
+			NamespacedName: types.NamespacedName{
+				Name:      builder.Spec.Stack.Name,
+				Namespace: corev1.NamespaceAll,
+			},
+			GroupKind: schema.GroupKind{
+				Group: "kpack.io",
+				Kind:  buildapi.ClusterStackKind,
+			},
+		}, builder.NamespacedName())
+	}
 
 	lifecycleName := builder.Spec.Lifecycle.Name
 	if lifecycleName == "" {
@@ -225,13 +262,26 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Clu
 		return buildapi.BuilderRecord{}, err
 	}
 
-	clusterStack, err := c.ClusterStackLister.Get(builder.Spec.Stack.Name)
+	c.Tracker.TrackKind(schema.GroupKind{
+		Group: "kpack.io",
+		Kind:  buildapi.ClusterExtensionKind,
+	}, builder.NamespacedName())
+
+	clusterExtensions, err := c.ClusterExtensionLister.List(labels.Everything())
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
 	}
 
-	if !clusterStack.Status.GetCondition(corev1alpha1.ConditionReady).IsTrue() {
-		return buildapi.BuilderRecord{}, errors.Errorf("stack %s is not ready", clusterStack.Name)
+	var clusterStack *buildapi.ClusterStack
+	if !builder.Spec.ReferencesStackImages() {
+		clusterStack, err = c.ClusterStackLister.Get(builder.Spec.Stack.Name)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+
+		if !clusterStack.Status.GetCondition(corev1alpha1.ConditionReady).IsTrue() {
+			return buildapi.BuilderRecord{}, errors.Errorf("stack %s is not ready", clusterStack.Name)
+		}
 	}
 
 	clusterLifecycle, err := c.ClusterLifecycleLister.Get(lifecycleName)
@@ -251,6 +301,13 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Clu
 		return buildapi.BuilderRecord{}, err
 	}
 
+	if clusterStack == nil {
+		clusterStack, err = reconciler.StackFromImages(c.StackReader, builderKeychain, builder.Spec.BuilderSpec)
+		if err != nil {
+			return buildapi.BuilderRecord{}, err
+		}
+	}
+
 	stackKeychain := builderKeychain
 	if clusterStack.Spec.ServiceAccountRef != nil {
 		stackKeychain, err = c.KeychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{
@@ -273,7 +330,7 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Clu
 		}
 	}
 
-	fetcher := cnb.NewRemoteBuildpackFetcher(c.KeychainFactory, clusterStore, nil, clusterBuildpacks)
+	fetcher := cnb.NewRemoteBuildpackFetcher(c.KeychainFactory, clusterStore, nil, clusterBuildpacks, nil, clusterExtensions)
 
 	serviceAccountSecrets, err := c.SecretFetcher.SecretsForServiceAccount(ctx, builder.Spec.ServiceAccountRef.Name, builder.Spec.ServiceAccountRef.Namespace)
 	if err != nil {
@@ -304,6 +361,13 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Clu
 	return buildRecord, nil
 }
 
+func builderReference(builder *buildapi.ClusterBuilder) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		Kind: buildapi.ClusterBuilderKind,
+		Name: builder.Name,
+	}
+}
+
 func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.ClusterBuilder) error {
 	desired.Status.ObservedGeneration = desired.Generation
 
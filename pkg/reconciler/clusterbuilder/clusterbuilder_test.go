@@ -13,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/controller"
@@ -24,6 +25,7 @@ import (
 	"github.com/pivotal/kpack/pkg/cnb"
 	kreconciler "github.com/pivotal/kpack/pkg/reconciler"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterbuilder"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterstack/clusterstackfakes"
 	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
 	"github.com/pivotal/kpack/pkg/registry"
 	"github.com/pivotal/kpack/pkg/registry/registryfakes"
@@ -47,6 +49,7 @@ func testClusterBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 		builderCreator    = &testhelpers.FakeBuilderCreator{}
 		keychainFactory   = &registryfakes.FakeKeychainFactory{}
 		fakeTracker       = &testhelpers.FakeTracker{}
+		stackReader       = &clusterstackfakes.FakeClusterStackReader{}
 		fakeSecretFetcher = &secretfakes.FakeFetchSecret{
 			FakeSecrets: []*corev1.Secret{},
 		}
@@ -64,9 +67,15 @@ func testClusterBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 				Tracker:                fakeTracker,
 				ClusterStoreLister:     listers.GetClusterStoreLister(),
 				ClusterBuildpackLister: listers.GetClusterBuildpackLister(),
+				ClusterExtensionLister: listers.GetClusterExtensionLister(),
 				ClusterStackLister:     listers.GetClusterStackLister(),
+				StackReader:            stackReader,
 				ClusterLifecycleLister: listers.GetClusterLifecycleLister(),
 				SecretFetcher:          fakeSecretFetcher,
+				Rollout: kreconciler.BuilderRollout{
+					ImageLister: listers.GetImageLister(),
+					BuildLister: listers.GetBuildLister(),
+				},
 			}
 			return &kreconciler.NetworkErrorReconciler{Reconciler: r}, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
 		})
@@ -291,7 +300,7 @@ func testClusterBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 				},
 			}
 
-			expectedFetcher := cnb.NewRemoteBuildpackFetcher(keychainFactory, clusterStore, nil, []*buildapi.ClusterBuildpack{clusterBuildpack})
+			expectedFetcher := cnb.NewRemoteBuildpackFetcher(keychainFactory, clusterStore, nil, []*buildapi.ClusterBuildpack{clusterBuildpack}, nil, nil)
 
 			rt.Test(rtesting.TableRow{
 				Key: builderKey,
@@ -325,6 +334,89 @@ func testClusterBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 			}}, builderCreator.CreateBuilderCalls)
 		})
 
+		it("creates the builder from the build and run images without a ClusterStack", func() {
+			builder.Spec.Stack = corev1.ObjectReference{Kind: buildapi.ClusterStackKind}
+			builder.Spec.BuildImage = buildapi.ClusterStackSpecImage{Image: "example.com/build-image"}
+			builder.Spec.RunImage = buildapi.ClusterStackSpecImage{Image: "example.com/run-image"}
+
+			resolvedStack := buildapi.ResolvedClusterStack{
+				BuildImage: buildapi.ClusterStackStatusImage{LatestImage: "example.com/build-image@sha256:build"},
+				RunImage:   buildapi.ClusterStackStatusImage{LatestImage: "example.com/run-image@sha256:run"},
+				Target:     &corev1alpha1.BuildpackTarget{OS: "linux", Arch: "amd64"},
+			}
+			stackReader.ReadReturns(resolvedStack, nil)
+
+			builderCreator.Record = buildapi.BuilderRecord{
+				Image: builderIdentifier,
+				Stack: corev1alpha1.BuildStack{
+					RunImage: "example.com/run-image@sha256:run",
+				},
+				Buildpacks: corev1alpha1.BuildpackMetadataList{},
+			}
+
+			expectedBuilder := &buildapi.ClusterBuilder{
+				ObjectMeta: builder.ObjectMeta,
+				TypeMeta:   builder.TypeMeta,
+				Spec:       builder.Spec,
+				Status: buildapi.BuilderStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: 1,
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionReady,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   buildapi.ConditionUpToDate,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					BuilderMetadata: []corev1alpha1.BuildpackMetadata{},
+					Stack: corev1alpha1.BuildStack{
+						RunImage: "example.com/run-image@sha256:run",
+					},
+					LatestImage: builderIdentifier,
+				},
+			}
+
+			rt.Test(rtesting.TableRow{
+				Key: builderKey,
+				Objects: []runtime.Object{
+					clusterLifecycle,
+					clusterStore,
+					builder,
+					clusterBuildpack,
+					&signingSecret,
+					&serviceAccount,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: expectedBuilder,
+					},
+				},
+			})
+
+			require.Equal(t, 1, stackReader.ReadCallCount())
+			_, stackSpec := stackReader.ReadArgsForCall(0)
+			assert.Equal(t, buildapi.ClusterStackSpec{
+				BuildImage: builder.Spec.BuildImage,
+				RunImage:   builder.Spec.RunImage,
+			}, stackSpec)
+
+			require.Len(t, builderCreator.CreateBuilderCalls, 1)
+			assert.Equal(t, &buildapi.ClusterStack{
+				Spec: stackSpec,
+				Status: buildapi.ClusterStackStatus{
+					ResolvedClusterStack: resolvedStack,
+				},
+			}, builderCreator.CreateBuilderCalls[0].ClusterStack)
+			assert.False(t, fakeTracker.IsTracking(
+				kreconciler.KeyForObject(clusterStack),
+				builder.NamespacedName()))
+		})
+
 		it("tracks the stack and store for a custom builder", func() {
 			builderCreator.Record = buildapi.BuilderRecord{
 				Image: builderIdentifier,
@@ -384,6 +476,8 @@ func testClusterBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 
 			require.True(t, fakeTracker.IsTrackingKind(
 				kreconciler.KeyForObject(clusterBuildpack).GroupKind, builder.NamespacedName()))
+			require.True(t, fakeTracker.IsTrackingKind(
+				schema.GroupKind{Group: "kpack.io", Kind: buildapi.ClusterExtensionKind}, builder.NamespacedName()))
 		})
 
 		it("does not update the status with no status change", func() {
@@ -775,5 +869,271 @@ func testClusterBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 				},
 			})
 		})
+		when("the builder has a rollout", func() {
+			const previousBuilderIdentifier = "example.com/custom-builder@sha256:previous-builder-digest"
+
+			var (
+				rolloutBuilder *buildapi.ClusterBuilder
+				canaryImage    *buildapi.Image
+				otherImage     *buildapi.Image
+			)
+
+			it.Before(func() {
+				builderCreator.Record = buildapi.BuilderRecord{
+					Image: builderIdentifier,
+					Stack: corev1alpha1.BuildStack{
+						RunImage: "example.com/run-image@sha256:123456",
+						ID:       "fake.stack.id",
+					},
+					Buildpacks: corev1alpha1.BuildpackMetadataList{},
+				}
+
+				rolloutBuilder = builder.DeepCopy()
+				rolloutBuilder.Spec.Rollout = &buildapi.BuilderRollout{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "canary"}},
+				}
+				rolloutBuilder.Status = buildapi.BuilderStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: 1,
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionReady,
+								Status: corev1.ConditionTrue,
+							},
+							{
+								Type:   buildapi.ConditionUpToDate,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					BuilderMetadata: []corev1alpha1.BuildpackMetadata{},
+					Stack:           builderCreator.Record.Stack,
+					LatestImage:     previousBuilderIdentifier,
+				}
+
+				canaryImage = &buildapi.Image{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "canary-image",
+						Namespace: "some-namespace",
+						Labels:    map[string]string{"tier": "canary"},
+					},
+					Spec: buildapi.ImageSpec{
+						Builder: corev1.ObjectReference{Kind: buildapi.ClusterBuilderKind, Name: builderName},
+					},
+					Status: buildapi.ImageStatus{
+						LatestBuildRef: "canary-image-build-2",
+					},
+				}
+				otherImage = &buildapi.Image{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-image",
+						Namespace: "some-namespace",
+					},
+					Spec: buildapi.ImageSpec{
+						Builder: corev1.ObjectReference{Kind: buildapi.ClusterBuilderKind, Name: builderName},
+					},
+				}
+			})
+
+			canaryBuild := func(builderImage string, status corev1.ConditionStatus) *buildapi.Build {
+				return &buildapi.Build{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "canary-image-build-2",
+						Namespace: "some-namespace",
+					},
+					Spec: buildapi.BuildSpec{
+						Builder: corev1alpha1.BuildBuilderSpec{Image: builderImage},
+					},
+					Status: buildapi.BuildStatus{
+						Status: corev1alpha1.Status{
+							Conditions: corev1alpha1.Conditions{
+								{
+									Type:   corev1alpha1.ConditionSucceeded,
+									Status: status,
+								},
+							},
+						},
+					},
+				}
+			}
+
+			it("starts a rollout to the canary images when the latest image changes", func() {
+				expectedBuilder := rolloutBuilder.DeepCopy()
+				expectedBuilder.Status.LatestImage = builderIdentifier
+				expectedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image:        builderIdentifier,
+					Phase:        buildapi.RolloutPhaseCanary,
+					CanaryImages: 1,
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: builderKey,
+					Objects: []runtime.Object{
+						clusterLifecycle,
+						clusterStack,
+						clusterStore,
+						rolloutBuilder,
+						canaryImage,
+						otherImage,
+						canaryBuild(previousBuilderIdentifier, corev1.ConditionTrue),
+						&signingSecret,
+						&serviceAccount,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: expectedBuilder,
+						},
+					},
+				})
+			})
+
+			it("promotes the rollout once the canary images build successfully", func() {
+				rolloutBuilder.Status.LatestImage = builderIdentifier
+				rolloutBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image:        builderIdentifier,
+					Phase:        buildapi.RolloutPhaseCanary,
+					CanaryImages: 1,
+				}
+
+				expectedBuilder := rolloutBuilder.DeepCopy()
+				expectedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image:           builderIdentifier,
+					Phase:           buildapi.RolloutPhasePromoted,
+					CanaryImages:    1,
+					SucceededImages: 1,
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: builderKey,
+					Objects: []runtime.Object{
+						clusterLifecycle,
+						clusterStack,
+						clusterStore,
+						rolloutBuilder,
+						canaryImage,
+						otherImage,
+						canaryBuild(builderIdentifier, corev1.ConditionTrue),
+						&signingSecret,
+						&serviceAccount,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: expectedBuilder,
+						},
+					},
+				})
+			})
+
+			it("halts the rollout when the canary images fail to build", func() {
+				rolloutBuilder.Status.LatestImage = builderIdentifier
+				rolloutBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image:        builderIdentifier,
+					Phase:        buildapi.RolloutPhaseCanary,
+					CanaryImages: 1,
+				}
+
+				expectedBuilder := rolloutBuilder.DeepCopy()
+				expectedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image:        builderIdentifier,
+					Phase:        buildapi.RolloutPhaseHalted,
+					CanaryImages: 1,
+					FailedImages: 1,
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: builderKey,
+					Objects: []runtime.Object{
+						clusterLifecycle,
+						clusterStack,
+						clusterStore,
+						rolloutBuilder,
+						canaryImage,
+						otherImage,
+						canaryBuild(builderIdentifier, corev1.ConditionFalse),
+						&signingSecret,
+						&serviceAccount,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: expectedBuilder,
+						},
+					},
+				})
+			})
+
+			it("skips canary images with a pending build that is not allowed", func() {
+				rolloutBuilder.Status.LatestImage = builderIdentifier
+				rolloutBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image:        builderIdentifier,
+					Phase:        buildapi.RolloutPhaseCanary,
+					CanaryImages: 1,
+				}
+				canaryImage.Status.PendingBuild = &buildapi.PendingBuild{
+					Reason: buildapi.BuildReasonStack,
+				}
+
+				expectedBuilder := rolloutBuilder.DeepCopy()
+				expectedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image:         builderIdentifier,
+					Phase:         buildapi.RolloutPhasePromoted,
+					CanaryImages:  1,
+					SkippedImages: 1,
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: builderKey,
+					Objects: []runtime.Object{
+						clusterLifecycle,
+						clusterStack,
+						clusterStore,
+						rolloutBuilder,
+						canaryImage,
+						otherImage,
+						canaryBuild(previousBuilderIdentifier, corev1.ConditionTrue),
+						&signingSecret,
+						&serviceAccount,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: expectedBuilder,
+						},
+					},
+				})
+			})
+
+			it("does not roll out the first image of the builder", func() {
+				rolloutBuilder.Status.LatestImage = ""
+
+				expectedBuilder := rolloutBuilder.DeepCopy()
+				expectedBuilder.Status.LatestImage = builderIdentifier
+				expectedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image: builderIdentifier,
+					Phase: buildapi.RolloutPhasePromoted,
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: builderKey,
+					Objects: []runtime.Object{
+						clusterLifecycle,
+						clusterStack,
+						clusterStore,
+						rolloutBuilder,
+						canaryImage,
+						&signingSecret,
+						&serviceAccount,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: expectedBuilder,
+						},
+					},
+				})
+			})
+		})
 	})
 }
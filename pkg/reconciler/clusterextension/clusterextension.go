@@ -0,0 +1,148 @@
+package clusterextension
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging/logkey"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+const (
+	ReconcilerName = "ClusterExtensions"
+	Kind           = "ClusterExtension"
+)
+
+//go:generate counterfeiter . ExtensionReader
+type ExtensionReader interface {
+	ReadExtensions(keychain authn.Keychain, extensionImages []corev1alpha1.ImageSource) ([]corev1alpha1.BuildpackStatus, error)
+}
+
+func NewController(
+	ctx context.Context,
+	opt reconciler.Options,
+	keychainFactory registry.KeychainFactory,
+	clusterExtensionInformer buildinformers.ClusterExtensionInformer,
+	extensionReader ExtensionReader,
+) *controller.Impl {
+	c := &Reconciler{
+		Client:                 opt.Client,
+		ClusterExtensionLister: clusterExtensionInformer.Lister(),
+		ExtensionReader:        extensionReader,
+		KeychainFactory:        keychainFactory,
+	}
+
+	logger := opt.Logger.With(
+		zap.String(logkey.Kind, buildapi.ClusterExtensionCRName),
+	)
+
+	impl := controller.NewContext(
+		ctx,
+		&reconciler.NetworkErrorReconciler{
+			Reconciler: c,
+		},
+		controller.ControllerOptions{WorkQueueName: ReconcilerName, Logger: logger},
+	)
+	clusterExtensionInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: reconciler.FilterDeletionTimestamp,
+		Handler:    controller.HandleAll(impl.Enqueue),
+	})
+	return impl
+}
+
+type Reconciler struct {
+	Client                 versioned.Interface
+	ExtensionReader        ExtensionReader
+	ClusterExtensionLister buildlisters.ClusterExtensionLister
+	KeychainFactory        registry.KeychainFactory
+}
+
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	_, clusterExtensionName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	clusterExtension, err := c.ClusterExtensionLister.Get(clusterExtensionName)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	clusterExtension = clusterExtension.DeepCopy()
+
+	clusterExtension, err = c.reconcileClusterExtensionStatus(ctx, clusterExtension)
+
+	updateErr := c.updateClusterExtensionStatus(ctx, clusterExtension)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Reconciler) updateClusterExtensionStatus(ctx context.Context, desired *buildapi.ClusterExtension) error {
+	desired.Status.ObservedGeneration = desired.Generation
+
+	original, err := c.ClusterExtensionLister.Get(desired.Name)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(desired.Status, original.Status) {
+		return nil
+	}
+
+	_, err = c.Client.KpackV1alpha2().ClusterExtensions().UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Reconciler) reconcileClusterExtensionStatus(ctx context.Context, clusterExtension *buildapi.ClusterExtension) (*buildapi.ClusterExtension, error) {
+	secretRef := registry.SecretRef{}
+
+	if clusterExtension.Spec.ServiceAccountRef != nil {
+		secretRef = registry.SecretRef{
+			ServiceAccount: clusterExtension.Spec.ServiceAccountRef.Name,
+			Namespace:      clusterExtension.Spec.ServiceAccountRef.Namespace,
+		}
+	}
+
+	keychain, err := c.KeychainFactory.KeychainForSecretRef(ctx, secretRef)
+	if err != nil {
+		clusterExtension.Status = buildapi.ClusterExtensionStatus{
+			Status: corev1alpha1.CreateStatusWithReadyCondition(clusterExtension.Generation, err),
+		}
+		return clusterExtension, err
+	}
+
+	extensions, err := c.ExtensionReader.ReadExtensions(keychain, []corev1alpha1.ImageSource{clusterExtension.Spec.ImageSource})
+	if err != nil {
+		clusterExtension.Status = buildapi.ClusterExtensionStatus{
+			Status: corev1alpha1.CreateStatusWithReadyCondition(clusterExtension.Generation, err),
+		}
+		return clusterExtension, err
+	}
+
+	clusterExtension.Status = buildapi.ClusterExtensionStatus{
+		Extensions: extensions,
+		Status:     corev1alpha1.CreateStatusWithReadyCondition(clusterExtension.Generation, nil),
+	}
+	return clusterExtension, nil
+}
@@ -0,0 +1,245 @@
+package clusterextension_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	kreconciler "github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterextension"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterextension/clusterextensionfakes"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestClusterExtensionReconciler(t *testing.T) {
+	spec.Run(t, "ClusterExtension Reconciler", testClusterExtensionReconciler)
+}
+
+func testClusterExtensionReconciler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		clusterBpName           = "some-cluster-extension"
+		clusterBpKey            = clusterBpName
+		initialGeneration int64 = 1
+	)
+	var (
+		fakeExtensionReader = &clusterextensionfakes.FakeExtensionReader{}
+		fakeKeyChainFactory = &registryfakes.FakeKeychainFactory{}
+	)
+
+	rt := testhelpers.ReconcilerTester(t,
+		func(_ *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
+			listers := testhelpers.NewListers(row.Objects)
+
+			fakeClient := fake.NewSimpleClientset(listers.BuildServiceObjects()...)
+
+			r := &clusterextension.Reconciler{
+				Client:                 fakeClient,
+				ExtensionReader:        fakeExtensionReader,
+				ClusterExtensionLister: listers.GetClusterExtensionLister(),
+				KeychainFactory:        fakeKeyChainFactory,
+			}
+			return &kreconciler.NetworkErrorReconciler{Reconciler: r}, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
+		})
+
+	cext := &buildapi.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       clusterBpName,
+			Generation: initialGeneration,
+		},
+		Spec: buildapi.ClusterExtensionSpec{
+			ImageSource: corev1alpha1.ImageSource{
+				Image: "some.registry/some-image-2",
+			},
+		},
+	}
+
+	when("#Reconcile", func() {
+		readExtensions := []corev1alpha1.BuildpackStatus{
+			{
+				BuildpackInfo: corev1alpha1.BuildpackInfo{
+					Id:      "paketo-community/curl",
+					Version: "0.0.116",
+				},
+				DiffId: "sha256:d57937f5ccb6f524afa02dd95224e1914c94a02483d37b07aa668e560dcb3bf4",
+				StoreImage: corev1alpha1.ImageSource{
+					Image: "some.registry/some-image-1",
+				},
+				Order: nil,
+			},
+			{
+				BuildpackInfo: corev1alpha1.BuildpackInfo{
+					Id:      "paketo-community/git",
+					Version: "0.0.71",
+				},
+				DiffId: "sha256:c67840e5ccb6f524afa02dd95224e1914c94a02483d37b07aa668e560dcb3bf5",
+				StoreImage: corev1alpha1.ImageSource{
+					Image: "some.registry/some-image-2",
+				},
+				Order: nil,
+			},
+		}
+
+		it("saves metadata to the status", func() {
+			fakeExtensionReader.ReadExtensionsReturns(readExtensions, nil)
+
+			emptySecretRef := registry.SecretRef{}
+			defaultKeyChain := &registryfakes.FakeKeychain{Name: "default"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, emptySecretRef, defaultKeyChain)
+
+			rt.Test(rtesting.TableRow{
+				Key: clusterBpKey,
+				Objects: []runtime.Object{
+					cext,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.ClusterExtension{
+							ObjectMeta: cext.ObjectMeta,
+							Spec:       cext.Spec,
+							Status: buildapi.ClusterExtensionStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								Extensions: readExtensions,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, 1, fakeExtensionReader.ReadExtensionsCallCount())
+
+			_, clusterextensionImages := fakeExtensionReader.ReadExtensionsArgsForCall(0)
+			assert.Equal(t, []corev1alpha1.ImageSource{cext.Spec.ImageSource}, clusterextensionImages)
+		})
+
+		it("uses the keychain of the referenced service account", func() {
+			fakeExtensionReader.ReadExtensionsReturns(readExtensions, nil)
+
+			cext.Spec.ServiceAccountRef = &corev1.ObjectReference{Name: "private-account", Namespace: "my-namespace"}
+			secretRef := registry.SecretRef{
+				ServiceAccount: "private-account",
+				Namespace:      "my-namespace",
+			}
+			expectedKeyChain := &registryfakes.FakeKeychain{Name: "secret"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, secretRef, expectedKeyChain)
+
+			rt.Test(rtesting.TableRow{
+				Key: clusterBpKey,
+				Objects: []runtime.Object{
+					cext,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.ClusterExtension{
+							ObjectMeta: cext.ObjectMeta,
+							Spec:       cext.Spec,
+							Status: buildapi.ClusterExtensionStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								Extensions: readExtensions,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, 1, fakeExtensionReader.ReadExtensionsCallCount())
+			actualKeyChain, _ := fakeExtensionReader.ReadExtensionsArgsForCall(0)
+			assert.Equal(t, expectedKeyChain, actualKeyChain)
+		})
+
+		it("does not update the status with no status change", func() {
+			fakeExtensionReader.ReadExtensionsReturns(readExtensions, nil)
+
+			emptySecretRef := registry.SecretRef{}
+			defaultKeyChain := &registryfakes.FakeKeychain{Name: "default"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, emptySecretRef, defaultKeyChain)
+
+			cext.Status = buildapi.ClusterExtensionStatus{
+				Status: corev1alpha1.Status{
+					ObservedGeneration: 1,
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:   corev1alpha1.ConditionReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				Extensions: readExtensions,
+			}
+			rt.Test(rtesting.TableRow{
+				Key: clusterBpKey,
+				Objects: []runtime.Object{
+					cext,
+				},
+				WantErr: false,
+			})
+		})
+
+		it("sets the status to Ready False if error reading extensions", func() {
+			fakeExtensionReader.ReadExtensionsReturns(nil, fmt.Errorf("no extensions left"))
+
+			emptySecretRef := registry.SecretRef{}
+			defaultKeyChain := &registryfakes.FakeKeychain{Name: "default"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, emptySecretRef, defaultKeyChain)
+
+			rt.Test(rtesting.TableRow{
+				Key: clusterBpKey,
+				Objects: []runtime.Object{
+					cext,
+				},
+				WantErr: true,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.ClusterExtension{
+							ObjectMeta: cext.ObjectMeta,
+							Spec:       cext.Spec,
+							Status: buildapi.ClusterExtensionStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Message: "no extensions left",
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionFalse,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		})
+	})
+}
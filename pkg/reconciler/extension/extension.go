@@ -0,0 +1,149 @@
+package extension
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging/logkey"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+const (
+	ReconcilerName = "Extensions"
+	Kind           = "Extension"
+)
+
+//go:generate counterfeiter . ExtensionReader
+type ExtensionReader interface {
+	ReadExtensions(keychain authn.Keychain, extensionImages []corev1alpha1.ImageSource) ([]corev1alpha1.BuildpackStatus, error)
+}
+
+func NewController(
+	ctx context.Context,
+	opt reconciler.Options,
+	keychainFactory registry.KeychainFactory,
+	extensionInformer buildinformers.ExtensionInformer,
+	extensionReader ExtensionReader,
+) *controller.Impl {
+	c := &Reconciler{
+		Client:          opt.Client,
+		ExtensionLister: extensionInformer.Lister(),
+		ExtensionReader: extensionReader,
+		KeychainFactory: keychainFactory,
+	}
+
+	logger := opt.Logger.With(
+		zap.String(logkey.Kind, buildapi.ExtensionCRName),
+	)
+
+	impl := controller.NewContext(
+		ctx,
+		&reconciler.NetworkErrorReconciler{
+			Reconciler: c,
+		},
+		controller.ControllerOptions{WorkQueueName: ReconcilerName, Logger: logger},
+	)
+	extensionInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: reconciler.FilterDeletionTimestamp,
+		Handler:    controller.HandleAll(impl.Enqueue),
+	})
+
+	return impl
+}
+
+type Reconciler struct {
+	Client          versioned.Interface
+	ExtensionReader ExtensionReader
+	ExtensionLister buildlisters.ExtensionLister
+	KeychainFactory registry.KeychainFactory
+}
+
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, extensionName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	extension, err := c.ExtensionLister.Extensions(namespace).Get(extensionName)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	extension = extension.DeepCopy()
+
+	extension, err = c.reconcileExtensionStatus(ctx, extension)
+
+	updateErr := c.updateExtensionStatus(ctx, extension)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Reconciler) updateExtensionStatus(ctx context.Context, desired *buildapi.Extension) error {
+	desired.Status.ObservedGeneration = desired.Generation
+
+	original, err := c.ExtensionLister.Extensions(desired.Namespace).Get(desired.Name)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(desired.Status, original.Status) {
+		return nil
+	}
+
+	_, err = c.Client.KpackV1alpha2().Extensions(desired.Namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Reconciler) reconcileExtensionStatus(ctx context.Context, extension *buildapi.Extension) (*buildapi.Extension, error) {
+	secretRef := registry.SecretRef{}
+
+	if extension.Spec.ServiceAccountName != "" {
+		secretRef = registry.SecretRef{
+			ServiceAccount: extension.Spec.ServiceAccountName,
+			Namespace:      extension.Namespace,
+		}
+	}
+
+	keychain, err := c.KeychainFactory.KeychainForSecretRef(ctx, secretRef)
+	if err != nil {
+		extension.Status = buildapi.ExtensionStatus{
+			Status: corev1alpha1.CreateStatusWithReadyCondition(extension.Generation, err),
+		}
+		return extension, err
+	}
+
+	extensions, err := c.ExtensionReader.ReadExtensions(keychain, []corev1alpha1.ImageSource{extension.Spec.ImageSource})
+	if err != nil {
+		extension.Status = buildapi.ExtensionStatus{
+			Status: corev1alpha1.CreateStatusWithReadyCondition(extension.Generation, err),
+		}
+		return extension, err
+	}
+
+	extension.Status = buildapi.ExtensionStatus{
+		Extensions: extensions,
+		Status:     corev1alpha1.CreateStatusWithReadyCondition(extension.Generation, nil),
+	}
+	return extension, nil
+}
@@ -0,0 +1,248 @@
+package extension_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	kreconciler "github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/reconciler/extension"
+	"github.com/pivotal/kpack/pkg/reconciler/extension/extensionfakes"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestExtensionReconciler(t *testing.T) {
+	spec.Run(t, "Extension Reconciler", testExtensionReconciler)
+}
+
+func testExtensionReconciler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		testNamespace           = "some-namespace"
+		extensionName           = "some-extension"
+		extensionKey            = testNamespace + "/" + extensionName
+		initialGeneration int64 = 1
+	)
+	var (
+		fakeExtensionReader = &extensionfakes.FakeExtensionReader{}
+		fakeKeyChainFactory = &registryfakes.FakeKeychainFactory{}
+	)
+
+	rt := testhelpers.ReconcilerTester(t,
+		func(_ *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
+			listers := testhelpers.NewListers(row.Objects)
+
+			fakeClient := fake.NewSimpleClientset(listers.BuildServiceObjects()...)
+
+			r := &extension.Reconciler{
+				Client:          fakeClient,
+				ExtensionReader: fakeExtensionReader,
+				ExtensionLister: listers.GetExtensionLister(),
+				KeychainFactory: fakeKeyChainFactory,
+			}
+			return &kreconciler.NetworkErrorReconciler{Reconciler: r}, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
+		})
+
+	ext := &buildapi.Extension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       extensionName,
+			Namespace:  testNamespace,
+			Generation: initialGeneration,
+		},
+		Spec: buildapi.ExtensionSpec{
+			ImageSource: corev1alpha1.ImageSource{
+				Image: "some.registry/some-image-2",
+			},
+		},
+	}
+
+	when("#Reconcile", func() {
+		readExtensions := []corev1alpha1.BuildpackStatus{
+			{
+				BuildpackInfo: corev1alpha1.BuildpackInfo{
+					Id:      "paketo-community/curl",
+					Version: "0.0.116",
+				},
+				DiffId: "sha256:d57937f5ccb6f524afa02dd95224e1914c94a02483d37b07aa668e560dcb3bf4",
+				StoreImage: corev1alpha1.ImageSource{
+					Image: "some.registry/some-image-1",
+				},
+				Order: nil,
+			},
+			{
+				BuildpackInfo: corev1alpha1.BuildpackInfo{
+					Id:      "paketo-community/git",
+					Version: "0.0.71",
+				},
+				DiffId: "sha256:c67840e5ccb6f524afa02dd95224e1914c94a02483d37b07aa668e560dcb3bf5",
+				StoreImage: corev1alpha1.ImageSource{
+					Image: "some.registry/some-image-2",
+				},
+				Order: nil,
+			},
+		}
+
+		it("saves metadata to the status", func() {
+			fakeExtensionReader.ReadExtensionsReturns(readExtensions, nil)
+
+			emptySecretRef := registry.SecretRef{}
+			defaultKeyChain := &registryfakes.FakeKeychain{Name: "default"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, emptySecretRef, defaultKeyChain)
+
+			rt.Test(rtesting.TableRow{
+				Key: extensionKey,
+				Objects: []runtime.Object{
+					ext,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Extension{
+							ObjectMeta: ext.ObjectMeta,
+							Spec:       ext.Spec,
+							Status: buildapi.ExtensionStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								Extensions: readExtensions,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, 1, fakeExtensionReader.ReadExtensionsCallCount())
+
+			_, extensionImages := fakeExtensionReader.ReadExtensionsArgsForCall(0)
+			assert.Equal(t, []corev1alpha1.ImageSource{ext.Spec.ImageSource}, extensionImages)
+		})
+
+		it("uses the keychain of the referenced service account", func() {
+			fakeExtensionReader.ReadExtensionsReturns(readExtensions, nil)
+
+			serviceAccountName := "private-account"
+			ext.Spec.ServiceAccountName = serviceAccountName
+			secretRef := registry.SecretRef{
+				ServiceAccount: serviceAccountName,
+				Namespace:      testNamespace,
+			}
+			expectedKeyChain := &registryfakes.FakeKeychain{Name: "secret"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, secretRef, expectedKeyChain)
+
+			rt.Test(rtesting.TableRow{
+				Key: extensionKey,
+				Objects: []runtime.Object{
+					ext,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Extension{
+							ObjectMeta: ext.ObjectMeta,
+							Spec:       ext.Spec,
+							Status: buildapi.ExtensionStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								Extensions: readExtensions,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, 1, fakeExtensionReader.ReadExtensionsCallCount())
+			actualKeyChain, _ := fakeExtensionReader.ReadExtensionsArgsForCall(0)
+			assert.Equal(t, expectedKeyChain, actualKeyChain)
+		})
+
+		it("does not update the status with no status change", func() {
+			fakeExtensionReader.ReadExtensionsReturns(readExtensions, nil)
+
+			emptySecretRef := registry.SecretRef{}
+			defaultKeyChain := &registryfakes.FakeKeychain{Name: "default"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, emptySecretRef, defaultKeyChain)
+
+			ext.Status = buildapi.ExtensionStatus{
+				Status: corev1alpha1.Status{
+					ObservedGeneration: 1,
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:   corev1alpha1.ConditionReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				Extensions: readExtensions,
+			}
+			rt.Test(rtesting.TableRow{
+				Key: extensionKey,
+				Objects: []runtime.Object{
+					ext,
+				},
+				WantErr: false,
+			})
+		})
+
+		it("sets the status to Ready False if error reading extensions", func() {
+			fakeExtensionReader.ReadExtensionsReturns(nil, fmt.Errorf("no extensions left"))
+
+			emptySecretRef := registry.SecretRef{}
+			defaultKeyChain := &registryfakes.FakeKeychain{Name: "default"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, emptySecretRef, defaultKeyChain)
+
+			rt.Test(rtesting.TableRow{
+				Key: extensionKey,
+				Objects: []runtime.Object{
+					ext,
+				},
+				WantErr: true,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Extension{
+							ObjectMeta: ext.ObjectMeta,
+							Spec:       ext.Spec,
+							Status: buildapi.ExtensionStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Message: "no extensions left",
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionFalse,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		})
+	})
+}
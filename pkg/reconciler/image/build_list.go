@@ -9,19 +9,25 @@ import (
 )
 
 type buildList struct {
+	builds           []*buildapi.Build
 	successfulBuilds []*buildapi.Build
 	failedBuilds     []*buildapi.Build
+	canceledBuilds   []*buildapi.Build
 	lastBuild        *buildapi.Build
+	// lastBuilds are the builds of the last build number, an image with platforms has a build for every platform
+	lastBuilds []*buildapi.Build
 }
 
 func newBuildList(builds []*buildapi.Build) (buildList, error) {
 	sort.Sort(v1alpha1build.ByCreationTimestamp(builds)) //nobody enforcing this
 
-	buildList := buildList{}
+	buildList := buildList{builds: builds}
 
 	for _, build := range builds {
 		if build.IsSuccess() {
 			buildList.successfulBuilds = append(buildList.successfulBuilds, build)
+		} else if build.IsCanceled() {
+			buildList.canceledBuilds = append(buildList.canceledBuilds, build)
 		} else if build.IsFailure() {
 			buildList.failedBuilds = append(buildList.failedBuilds, build)
 		}
@@ -29,23 +35,89 @@ func newBuildList(builds []*buildapi.Build) (buildList, error) {
 
 	if len(builds) > 0 {
 		buildList.lastBuild = builds[len(builds)-1]
+		for _, build := range builds {
+			if build.Labels[buildapi.BuildNumberLabel] == buildList.lastBuild.Labels[buildapi.BuildNumberLabel] {
+				buildList.lastBuilds = append(buildList.lastBuilds, build)
+			}
+		}
+
+		if buildList.lastBuild.Platform() != "" {
+			buildList.lastBuild = lastPlatformBuild(buildList.lastBuilds)
+		}
 	}
 
 	return buildList, nil
 }
 
+// lastPlatformBuild returns a running or unsuccessful platform build of the last build number if there is one, so
+// the image is only up to date when every platform build succeeded
+func lastPlatformBuild(builds []*buildapi.Build) *buildapi.Build {
+	// platform builds are created at the same time, sort them by name so the same build is returned every time
+	sorted := append([]*buildapi.Build{}, builds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, build := range sorted {
+		if build.IsRunning() {
+			return build
+		}
+	}
+	for _, build := range sorted {
+		if !build.IsSuccess() {
+			return build
+		}
+	}
+	return sorted[0]
+}
+
+// NumberFailedBuilds counts the build numbers with a failed build, the builds of every platform of an image share a
+// build number and are deleted together
 func (l buildList) NumberFailedBuilds() int64 {
-	return int64(len(l.failedBuilds))
+	return countBuildNumbers(l.failedBuilds)
 }
 
-func (l buildList) OldestFailure() *buildapi.Build {
-	return l.failedBuilds[0]
+func (l buildList) OldestFailure() []*buildapi.Build {
+	return l.buildsOfNumber(l.failedBuilds[0])
+}
+
+func (l buildList) NumberCanceledBuilds() int64 {
+	return countBuildNumbers(l.canceledBuilds)
+}
+
+func (l buildList) OldestCanceled() []*buildapi.Build {
+	return l.buildsOfNumber(l.canceledBuilds[0])
 }
 
 func (l buildList) NumberSuccessfulBuilds() int64 {
-	return int64(len(l.successfulBuilds))
+	return countBuildNumbers(l.successfulBuilds)
+}
+
+func (l buildList) OldestSuccess() []*buildapi.Build {
+	return l.buildsOfNumber(l.successfulBuilds[0])
+}
+
+// buildsOfNumber returns every build with the build number of the build
+func (l buildList) buildsOfNumber(build *buildapi.Build) []*buildapi.Build {
+	var builds []*buildapi.Build
+	for _, b := range l.builds {
+		if buildNumberKey(b) == buildNumberKey(build) {
+			builds = append(builds, b)
+		}
+	}
+	return builds
+}
+
+func countBuildNumbers(builds []*buildapi.Build) int64 {
+	numbers := map[string]bool{}
+	for _, build := range builds {
+		numbers[buildNumberKey(build)] = true
+	}
+	return int64(len(numbers))
 }
 
-func (l buildList) OldestSuccess() *buildapi.Build {
-	return l.successfulBuilds[0]
+// buildNumberKey falls back to the build name for builds without a build number so they are counted individually
+func buildNumberKey(build *buildapi.Build) string {
+	if number := build.Labels[buildapi.BuildNumberLabel]; number != "" {
+		return number
+	}
+	return build.Name
 }
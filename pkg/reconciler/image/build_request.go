@@ -0,0 +1,76 @@
+package image
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/buildchange"
+)
+
+// pendingBuildRequest returns the build request annotated on the image if no build has been created for it
+func pendingBuildRequest(image *buildapi.Image) (*buildapi.BuildRequest, error) {
+	request, err := image.BuildRequest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation", buildapi.BuildRequestAnnotation)
+	}
+
+	if request == nil || request.ID == image.Status.LastBuildRequestID {
+		return nil, nil
+	}
+	return request, nil
+}
+
+func requestChange(img *buildapi.Image, srcResolver *buildapi.SourceResolver, request *buildapi.BuildRequest) buildchange.Change {
+	if request == nil {
+		return nil
+	}
+
+	var replaced buildapi.BuildRequest
+	if request.Revision != "" && srcResolver.Status.Source.Git != nil {
+		replaced.Revision = srcResolver.Status.Source.Git.Revision
+	}
+	if len(request.Env) > 0 {
+		replaced.Env = img.Env()
+	}
+	return buildchange.NewRequestChange(replaced, *request)
+}
+
+// steadyStateBuild returns the last build with the Image config its build request replaced, so that the overrides
+// of a requested build do not require another build
+func steadyStateBuild(lastBuild *buildapi.Build) (*buildapi.Build, error) {
+	request, err := lastBuild.BuildRequest()
+	if err != nil || request == nil {
+		return lastBuild, err
+	}
+
+	var changes []struct {
+		Reason string          `json:"reason"`
+		Old    json.RawMessage `json:"old"`
+	}
+	if err := json.Unmarshal([]byte(lastBuild.BuildChanges()), &changes); err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		if change.Reason != buildapi.BuildReasonRequest {
+			continue
+		}
+
+		var replaced buildapi.BuildRequest
+		if err := json.Unmarshal(change.Old, &replaced); err != nil {
+			return nil, err
+		}
+
+		steadyState := lastBuild.DeepCopy()
+		if request.Revision != "" && steadyState.Spec.Source.Git != nil {
+			steadyState.Spec.Source.Git.Revision = replaced.Revision
+		}
+		if len(request.Env) > 0 {
+			steadyState.Spec.Env = replaced.Env
+		}
+		return steadyState, nil
+	}
+	return lastBuild, nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -15,11 +16,13 @@ type buildRequiredResult struct {
 	ReasonsStr      string
 	ChangesStr      string
 	PriorityClass   string
+	// Suppressed is true if the changes are only for reasons that the image does not allow to build automatically
+	Suppressed bool
 }
 
 func newBuildRequiredResult(summary buildchange.ChangeSummary) buildRequiredResult {
 	var result buildRequiredResult
-	if summary.HasChanges {
+	if summary.HasChanges && !summary.Suppressed {
 		result.ConditionStatus = corev1.ConditionTrue
 	} else {
 		result.ConditionStatus = corev1.ConditionFalse
@@ -27,6 +30,7 @@ func newBuildRequiredResult(summary buildchange.ChangeSummary) buildRequiredResu
 	result.ReasonsStr = summary.ReasonsStr
 	result.ChangesStr = summary.ChangesStr
 	result.PriorityClass = summary.Priority.PriorityClass()
+	result.Suppressed = summary.Suppressed
 	return result
 }
 
@@ -34,19 +38,53 @@ func isBuildRequired(img *buildapi.Image,
 	lastBuild *buildapi.Build,
 	srcResolver *buildapi.SourceResolver,
 	builder buildapi.BuilderResource,
+	scheduledBuild *time.Time,
+	upstreamImages map[string]string,
+	envSources []buildapi.BuildEnvSource,
+	retryAttempt int64,
+	request *buildapi.BuildRequest,
 ) (buildRequiredResult, error) {
 	result := buildRequiredResult{ConditionStatus: corev1.ConditionUnknown}
 	if !srcResolver.Ready() || !builder.Ready() {
 		return result, nil
 	}
 
-	changeSummary, err := buildchange.NewChangeProcessor().
+	lastBuild, err := steadyStateBuild(lastBuild)
+	if err != nil {
+		return result, err
+	}
+
+	upstream, err := upstreamChange(lastBuild, upstreamImages)
+	if err != nil {
+		return result, err
+	}
+
+	buildpack, stack, lifecycle := buildpackChange(lastBuild, builder), stackChange(lastBuild, builder), lifecycleChange(lastBuild, builder)
+	// images outside the canary set do not rebuild for a new builder image until its rollout is promoted
+	if builder.RolloutPending(img) {
+		buildpack, stack, lifecycle = nil, nil, nil
+	}
+
+	processor := buildchange.NewChangeProcessor()
+	// the first build is always allowed
+	if lastBuild != nil && len(img.Spec.AllowedBuildReasons) > 0 {
+		processor.Allow(img.Spec.AllowedBuildReasons)
+	}
+
+	changeSummary, err := processor.
 		Process(triggerChange(lastBuild)).
+		Process(requestChange(img, srcResolver, request)).
+		Process(scheduledChange(img, lastBuild, scheduledBuild)).
+		Process(retryChange(lastBuild, retryAttempt)).
 		Process(commitChange(lastBuild, srcResolver)).
-		Process(configChange(img, lastBuild, srcResolver)).
-		Process(buildpackChange(lastBuild, builder)).
-		Process(stackChange(lastBuild, builder)).
-		Process(lifecycleChange(lastBuild, builder)).
+		Process(blobChange(lastBuild, srcResolver)).
+		Process(registryChange(lastBuild, srcResolver)).
+		Process(volumeChange(lastBuild, srcResolver)).
+		Process(configChange(img, lastBuild, srcResolver, envSources)).
+		Process(buildpack).
+		Process(stack).
+		Process(lifecycle).
+		Process(upstream).
 		Summarize()
 	if err != nil {
 		return result, err
@@ -69,6 +107,39 @@ func triggerChange(lastBuild *buildapi.Build) buildchange.Change {
 	return buildchange.NewTriggerChange(time)
 }
 
+func scheduledChange(img *buildapi.Image, lastBuild *buildapi.Build, scheduledBuild *time.Time) buildchange.Change {
+	if lastBuild == nil || scheduledBuild == nil {
+		return nil
+	}
+
+	var lastScheduled string
+	if img.Status.LastScheduledBuildTime != nil {
+		lastScheduled = img.Status.LastScheduledBuildTime.Format(time.RFC3339)
+	}
+	return buildchange.NewScheduledChange(lastScheduled, scheduledBuild.Format(time.RFC3339))
+}
+
+func retryChange(lastBuild *buildapi.Build, retryAttempt int64) buildchange.Change {
+	if lastBuild == nil || retryAttempt == 0 {
+		return nil
+	}
+
+	return buildchange.NewRetryChange(lastBuild.Attempt(), retryAttempt)
+}
+
+func upstreamChange(lastBuild *buildapi.Build, upstreamImages map[string]string) (buildchange.Change, error) {
+	if lastBuild == nil || len(upstreamImages) == 0 {
+		return nil, nil
+	}
+
+	lastUpstreamImages, err := buildUpstreamImages(lastBuild)
+	if err != nil || lastUpstreamImages == nil {
+		return nil, err
+	}
+
+	return buildchange.NewUpstreamChange(lastUpstreamImages, upstreamImages), nil
+}
+
 func commitChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
 	// If the lastBuild was not a Git source, then it is not a COMMIT change
 	if lastBuild == nil || lastBuild.Spec.Source.Git == nil || srcResolver.Status.Source.Git == nil {
@@ -80,26 +151,84 @@ func commitChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolve
 	return buildchange.NewCommitChange(oldRevision, newRevision)
 }
 
-func configChange(img *buildapi.Image, lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+func blobChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+	if lastBuild == nil || lastBuild.Spec.Source.Blob == nil || srcResolver.Status.Source.Blob == nil {
+		return nil
+	}
+
+	// Builds created before the blob was resolvable by version, or from a different url, are not a BLOB change
+	oldBlob := lastBuild.Spec.Source.Blob
+	newBlob := srcResolver.Status.Source.Blob
+	if oldBlob.Version == "" || newBlob.Version == "" || oldBlob.URL != newBlob.URL {
+		return nil
+	}
+
+	return buildchange.NewBlobChange(oldBlob.Version, newBlob.Version)
+}
+
+func registryChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+	if lastBuild == nil || lastBuild.Spec.Source.Registry == nil || srcResolver.Status.Source.Registry == nil {
+		return nil
+	}
+
+	// Builds created before the image was resolved to a digest, or from a different image, are not a REGISTRY change
+	oldRegistry := lastBuild.Spec.Source.Registry
+	newRegistry := srcResolver.Status.Source.Registry
+	if oldRegistry.Digest == "" || newRegistry.Digest == "" || oldRegistry.Image != newRegistry.Image {
+		return nil
+	}
+
+	return buildchange.NewRegistryChange(oldRegistry.Digest, newRegistry.Digest)
+}
+
+func volumeChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+	if lastBuild == nil || lastBuild.Spec.Source.Volume == nil || srcResolver.Status.Source.Volume == nil {
+		return nil
+	}
+
+	// Builds from a different claim or config map are a CONFIG change
+	oldVolume := lastBuild.Spec.Source.Volume
+	newVolume := srcResolver.Status.Source.Volume
+	if oldVolume.Digest == "" || newVolume.Digest == "" ||
+		!equality.Semantic.DeepEqual(oldVolume.PersistentVolumeClaim, newVolume.PersistentVolumeClaim) ||
+		!equality.Semantic.DeepEqual(oldVolume.ConfigMap, newVolume.ConfigMap) {
+		return nil
+	}
+
+	return buildchange.NewVolumeChange(oldVolume.Digest, newVolume.Digest)
+}
+
+func configChange(img *buildapi.Image, lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver, envSources []buildapi.BuildEnvSource) buildchange.Change {
 	var old buildchange.Config
 	var new buildchange.Config
 
 	if lastBuild != nil {
 		old = buildchange.Config{
 			Env:         lastBuild.Spec.Env,
+			EnvFrom:     lastBuild.Spec.EnvFrom,
+			EnvSources:  lastBuild.Spec.EnvSources,
 			Resources:   lastBuild.Spec.Resources,
 			Services:    lastBuild.Spec.Services,
 			CNBBindings: lastBuild.Spec.CNBBindings,
 			Source:      lastBuild.Spec.Source,
+			Platforms:   lastBuild.Platforms(),
+		}
+
+		// builds created before env sources were hashed are not rebuilt until the env sources change
+		if lastBuild.Spec.EnvSources == nil {
+			old.EnvSources = envSources
 		}
 	}
 
 	new = buildchange.Config{
 		Env:         img.Env(),
+		EnvFrom:     img.EnvFrom(),
+		EnvSources:  envSources,
 		Resources:   img.Resources(),
 		Services:    img.Services(),
 		CNBBindings: img.CNBBindings(),
 		Source:      srcResolver.Status.Source.ResolvedSource().SourceConfig(),
+		Platforms:   img.Spec.Platforms,
 	}
 
 	return buildchange.NewConfigChange(old, new)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -115,7 +116,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 		}
 
 		it("false for no changes", func() {
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 			assert.Equal(t, "", result.ReasonsStr)
@@ -126,7 +127,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 		it("false for different ServiceAccount", func() {
 			image.Spec.ServiceAccountName = "different"
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 			assert.Equal(t, "", result.ReasonsStr)
@@ -170,7 +171,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -215,7 +216,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -260,7 +261,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -281,7 +282,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				Stack: corev1alpha1.BuildStack{},
 			}
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 			assert.Equal(t, "", result.ReasonsStr)
@@ -294,7 +295,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				buildapi.BuildNeededAnnotation: "true",
 			}
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonTrigger, result.ReasonsStr)
@@ -309,6 +310,259 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			assert.True(t, strings.HasPrefix((changes[0].New).(string), "A new build was manually triggered on "))
 		})
 
+		it("true if a scheduled build is due", func() {
+			image.Status.LastScheduledBuildTime = &metav1.Time{Time: time.Date(2024, time.January, 7, 0, 0, 0, 0, time.UTC)}
+			scheduled := time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)
+
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, &scheduled, nil, nil, 0, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+			assert.Equal(t, buildapi.BuildReasonScheduled, result.ReasonsStr)
+			assert.Equal(t, buildapi.BuildPriorityClassLow, result.PriorityClass)
+			assert.Equal(t, testhelpers.CompactJSON(`
+[
+  {
+    "reason": "SCHEDULED",
+    "old": "2024-01-07T00:00:00Z",
+    "new": "2024-01-14T00:00:00Z"
+  }
+]`), result.ChangesStr)
+		})
+
+		it("true if a failed build is retried", func() {
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 2, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+			assert.Equal(t, buildapi.BuildReasonRetry, result.ReasonsStr)
+			assert.Equal(t, buildapi.BuildPriorityClassLow, result.PriorityClass)
+			assert.Equal(t, testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": 1,
+    "new": 2
+  }
+]`), result.ChangesStr)
+		})
+
+		when("the image has a build request", func() {
+			request := &buildapi.BuildRequest{
+				ID:       "some-request",
+				Revision: "requested-revision",
+				Env:      []corev1.EnvVar{{Name: "DEBUG", Value: "true"}},
+				LogLevel: "debug",
+			}
+
+			requestedBuild := func() *buildapi.Build {
+				requested := latestBuild.DeepCopy()
+				requested.Annotations = map[string]string{
+					buildapi.BuildRequestAnnotation: `{"id":"some-request","revision":"requested-revision","env":[{"name":"DEBUG","value":"true"}]}`,
+					buildapi.BuildChangesAnnotation: `[{"reason":"REQUEST","old":{"revision":"revision"},"new":{"id":"some-request","revision":"requested-revision","env":[{"name":"DEBUG","value":"true"}]}}]`,
+				}
+				requested.Spec.Source.Git.Revision = "requested-revision"
+				requested.Spec.Env = request.Env
+				return requested
+			}
+
+			it("true for a pending build request", func() {
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, request)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonRequest, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, testhelpers.CompactJSON(`
+[
+  {
+    "reason": "REQUEST",
+    "old": {
+      "revision": "revision"
+    },
+    "new": {
+      "id": "some-request",
+      "revision": "requested-revision",
+      "env": [
+        {
+          "name": "DEBUG",
+          "value": "true"
+        }
+      ],
+      "logLevel": "debug"
+    }
+  }
+]`), result.ChangesStr)
+			})
+
+			it("false after a requested build if the image has not changed", func() {
+				result, err := isBuildRequired(image, requestedBuild(), sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+
+			it("compares changes after a requested build to the replaced image config", func() {
+				sourceResolver.Status.Source.Git.Revision = "new-revision"
+
+				result, err := isBuildRequired(image, requestedBuild(), sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonCommit, result.ReasonsStr)
+
+				var changes []buildchange.GenericChange
+				err = json.Unmarshal([]byte(result.ChangesStr), &changes)
+				assert.NoError(t, err)
+				assert.Equal(t, "revision", changes[0].Old)
+				assert.Equal(t, "new-revision", changes[0].New)
+			})
+		})
+
+		when("the image has allowed build reasons", func() {
+			it.Before(func() {
+				image.Spec.AllowedBuildReasons = []string{buildapi.BuildReasonCommit}
+				builder.LatestRunImage = "some.registry.io/run-image@sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca"
+			})
+
+			it("suppresses changes for reasons that are not allowed", func() {
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.True(t, result.Suppressed)
+				assert.Equal(t, buildapi.BuildReasonStack, result.ReasonsStr)
+				assert.NotEmpty(t, result.ChangesStr)
+			})
+
+			it("builds suppressed changes with an allowed change", func() {
+				sourceResolver.Status.Source.Git.Revision = "new-revision"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.False(t, result.Suppressed)
+				assert.Equal(t, "COMMIT,STACK", result.ReasonsStr)
+			})
+
+			it("always allows the first build", func() {
+				result, err := isBuildRequired(image, nil, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
+			})
+		})
+
+		when("the rollout of the builder is pending for the image", func() {
+			it.Before(func() {
+				builder.RolloutPendingImages = true
+				builder.LatestRunImage = "some.registry.io/run-image@sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca"
+			})
+
+			it("does not build for builder changes", func() {
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, "", result.ReasonsStr)
+			})
+
+			it("builds for other changes", func() {
+				sourceResolver.Status.Source.Git.Revision = "new-revision"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonCommit, result.ReasonsStr)
+			})
+		})
+
+		when("the image has upstream images", func() {
+			it.Before(func() {
+				latestBuild.Annotations = map[string]string{
+					buildapi.UpstreamImagesAnnotation: `{"base":"some/base@sha256:old","runtime":""}`,
+				}
+			})
+
+			it("true if the latest image of an upstream image changed", func() {
+				upstreamImages := map[string]string{"base": "some/base@sha256:new", "runtime": "some/runtime@sha256:built"}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, upstreamImages, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonUpstream, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassLow, result.PriorityClass)
+				assert.Equal(t, testhelpers.CompactJSON(`
+[
+  {
+    "reason": "UPSTREAM",
+    "old": {
+      "base": "some/base@sha256:old",
+      "runtime": ""
+    },
+    "new": {
+      "base": "some/base@sha256:new",
+      "runtime": "some/runtime@sha256:built"
+    }
+  }
+]`), result.ChangesStr)
+			})
+
+			it("false if the upstream images are unchanged, unbuilt or were not recorded by the last build", func() {
+				upstreamImages := map[string]string{"base": "some/base@sha256:old", "runtime": "", "added": "some/added@sha256:built"}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, upstreamImages, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, "", result.ReasonsStr)
+			})
+
+			it("false if the last build did not record upstream images", func() {
+				latestBuild.Annotations = nil
+				upstreamImages := map[string]string{"base": "some/base@sha256:new"}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, upstreamImages, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+		})
+
+		when("the image has env sources", func() {
+			envFrom := []corev1.EnvFromSource{
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "some-config-map"}}},
+			}
+
+			it.Before(func() {
+				image.Spec.Build = &buildapi.ImageBuild{EnvFrom: envFrom}
+				latestBuild.Spec.EnvFrom = envFrom
+				latestBuild.Spec.EnvSources = []buildapi.BuildEnvSource{
+					{Kind: "ConfigMap", Name: "some-config-map", Hash: "sha256:old"},
+				}
+			})
+
+			it("true if the data of an env source changed", func() {
+				envSources := []buildapi.BuildEnvSource{{Kind: "ConfigMap", Name: "some-config-map", Hash: "sha256:new"}}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, envSources, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Contains(t, result.ChangesStr, `"envSources":[{"kind":"ConfigMap","name":"some-config-map","hash":"sha256:old"}]`)
+				assert.Contains(t, result.ChangesStr, `"envSources":[{"kind":"ConfigMap","name":"some-config-map","hash":"sha256:new"}]`)
+			})
+
+			it("false if the data of the env sources is unchanged", func() {
+				envSources := []buildapi.BuildEnvSource{{Kind: "ConfigMap", Name: "some-config-map", Hash: "sha256:old"}}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, envSources, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+
+			it("false if the last build did not record env sources", func() {
+				latestBuild.Spec.EnvSources = nil
+				envSources := []buildapi.BuildEnvSource{{Kind: "ConfigMap", Name: "some-config-map", Hash: "sha256:new"}}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, envSources, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+		})
+
 		when("Builder Metadata changes", func() {
 			it("false if builder has additional unused buildpacks", func() {
 				builder.BuilderMetadata = []corev1alpha1.BuildpackMetadata{
@@ -316,7 +570,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 					{Id: "buildpack.unused", Version: "unused"},
 				}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -344,7 +598,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonBuildpack, result.ReasonsStr)
@@ -372,7 +626,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonBuildpack, result.ReasonsStr)
@@ -392,7 +646,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonStack, result.ReasonsStr)
@@ -412,7 +666,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonLifecycle, result.ReasonsStr)
@@ -450,7 +704,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -487,7 +741,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -507,7 +761,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonCommit, result.ReasonsStr)
@@ -523,7 +777,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 						Status: corev1.ConditionFalse,
 					}}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -535,7 +789,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.Status.Source.Git.URL = "some-change"
 				builder.BuilderReady = false
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -547,7 +801,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.Status.Source.Git.Revision = "different"
 				sourceResolver.Status.Conditions = []corev1alpha1.Condition{}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -559,7 +813,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.Status.Source.Git.Revision = "different"
 				sourceResolver.Status.Conditions = []corev1alpha1.Condition{}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -572,7 +826,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.ObjectMeta.Generation = 2
 				sourceResolver.Status.ObservedGeneration = 1
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -628,7 +882,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -648,7 +902,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonCommit, result.ReasonsStr)
@@ -694,7 +948,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -729,13 +983,48 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
 				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
+
+			it("true for different Blob version", func() {
+				sourceResolver.Status.Source.Blob.URL = "some-url"
+				sourceResolver.Status.Source.Blob.Version = `"new-etag"`
+				sourceResolver.Status.Source.Blob.VersionKind = corev1alpha1.BlobVersionETag
+				latestBuild.Spec.Source.Blob.Version = `"old-etag"`
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "BLOB",
+    "old": "\"old-etag\"",
+    "new": "\"new-etag\""
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonBlob, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false if the last build did not track the Blob version", func() {
+				sourceResolver.Status.Source.Blob.URL = "some-url"
+				sourceResolver.Status.Source.Blob.Version = `"new-etag"`
+				sourceResolver.Status.Source.Blob.VersionKind = corev1alpha1.BlobVersionETag
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, "", result.ReasonsStr)
+				assert.Equal(t, "", result.ChangesStr)
+			})
 		})
 
 		when("Registry", func() {
@@ -775,7 +1064,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -809,13 +1098,103 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
 				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
+
+			it("true for different Registry digest", func() {
+				sourceResolver.Status.Source.Registry.Image = "some-image"
+				sourceResolver.Status.Source.Registry.Digest = "sha256:new"
+				sourceResolver.Status.Source.Registry.Type = corev1alpha1.RegistryTag
+				latestBuild.Spec.Source.Registry.Digest = "sha256:old"
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "REGISTRY",
+    "old": "sha256:old",
+    "new": "sha256:new"
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonRegistry, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false if the last build was not pinned to a Registry digest", func() {
+				sourceResolver.Status.Source.Registry.Image = "some-image"
+				sourceResolver.Status.Source.Registry.Digest = "sha256:new"
+				sourceResolver.Status.Source.Registry.Type = corev1alpha1.RegistryTag
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, "", result.ReasonsStr)
+				assert.Equal(t, "", result.ChangesStr)
+			})
+		})
+
+		when("Volume", func() {
+			claim := &corev1alpha1.PersistentVolumeClaimSource{ClaimName: "some-claim"}
+
+			sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
+				Volume: &corev1alpha1.ResolvedVolumeSource{
+					PersistentVolumeClaim: claim,
+					Digest:                "sha256:new",
+				},
+			}
+
+			latestBuild.Spec.Source = corev1alpha1.SourceConfig{
+				Volume: &corev1alpha1.Volume{
+					PersistentVolumeClaim: claim,
+					Digest:                "sha256:old",
+				},
+			}
+
+			it("true for different Volume digest", func() {
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "VOLUME",
+    "old": "sha256:old",
+    "new": "sha256:new"
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonVolume, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("true for different claim", func() {
+				sourceResolver.Status.Source.Volume.PersistentVolumeClaim = &corev1alpha1.PersistentVolumeClaimSource{ClaimName: "different"}
+				sourceResolver.Status.Source.Volume.Digest = "sha256:old"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
+			})
+
+			it("false for the same Volume digest", func() {
+				sourceResolver.Status.Source.Volume.Digest = "sha256:old"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil, nil, nil, 0, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, "", result.ReasonsStr)
+			})
 		})
 	})
 }
@@ -831,6 +1210,7 @@ type TestBuilderResource struct {
 	Name                   string
 	Namespace              string
 	Kind                   string
+	RolloutPendingImages   bool
 }
 
 func (t TestBuilderResource) BuildBuilderSpec() corev1alpha1.BuildBuilderSpec {
@@ -875,3 +1255,11 @@ func (t TestBuilderResource) ConditionReadyMessage() string {
 func (t TestBuilderResource) GetNamespace() string {
 	return t.Namespace
 }
+
+func (t TestBuilderResource) RolloutPending(*buildapi.Image) bool {
+	return t.RolloutPendingImages
+}
+
+func (t TestBuilderResource) Platforms() []string {
+	return nil
+}
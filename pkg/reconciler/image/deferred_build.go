@@ -0,0 +1,50 @@
+package image
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// buildDeferredUntil returns when a build for the reasons may be created if the image or namespace build windows
+// are closed. The first build of an image is never deferred.
+func (c *Reconciler) buildDeferredUntil(image *buildapi.Image, lastBuild *buildapi.Build, reasons string) (*time.Time, error) {
+	if lastBuild == nil {
+		return nil, nil
+	}
+
+	windows, err := c.buildWindows(image)
+	if err != nil || len(windows) == 0 {
+		return nil, err
+	}
+
+	return windows.DeferredUntil(reasons, c.Clock.Now())
+}
+
+// buildWindows returns the image build windows, or the namespace build windows if the image has none
+func (c *Reconciler) buildWindows(image *buildapi.Image) (buildapi.BuildWindows, error) {
+	if len(image.Spec.BuildWindows) > 0 {
+		return image.Spec.BuildWindows, nil
+	}
+
+	namespace, err := c.NamespaceLister.Get(image.Namespace)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	windowsJson, ok := namespace.Annotations[buildapi.BuildWindowsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	windows, err := buildapi.ParseBuildWindows(windowsJson)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation on namespace '%s'", buildapi.BuildWindowsAnnotation, image.Namespace)
+	}
+	return windows, nil
+}
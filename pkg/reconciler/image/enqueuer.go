@@ -0,0 +1,15 @@
+package image
+
+import (
+	"time"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+type workQueueEnqueuer struct {
+	enqueueAfter func(obj interface{}, after time.Duration)
+}
+
+func (e *workQueueEnqueuer) EnqueueAfter(image *buildapi.Image, delay time.Duration) {
+	e.enqueueAfter(image, delay)
+}
@@ -0,0 +1,90 @@
+package image
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+)
+
+// envSources hashes the referenced keys of the Secrets and ConfigMaps of the build env and tracks them so the image is
+// reconciled when they change. Only Secrets and ConfigMaps labeled as build env sources are in the listers, others have
+// an empty hash like sources that do not exist.
+func (c *Reconciler) envSources(image *buildapi.Image) ([]buildapi.BuildEnvSource, error) {
+	sources := image.EnvSources()
+	for i, source := range sources {
+		c.Tracker.Track(reconciler.Key{
+			GroupKind:      schema.GroupKind{Kind: source.Kind},
+			NamespacedName: types.NamespacedName{Namespace: image.Namespace, Name: source.Name},
+		}, image.NamespacedName())
+
+		data, err := c.envSourceData(image.Namespace, source)
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		keys, all := image.EnvSourceKeys(source)
+		if !all {
+			data = selectKeys(data, keys)
+		}
+		sources[i].Hash = hashData(data)
+	}
+	return sources, nil
+}
+
+func (c *Reconciler) envSourceData(namespace string, source buildapi.BuildEnvSource) (map[string][]byte, error) {
+	if source.Kind == buildapi.EnvSourceSecretKind {
+		secret, err := c.SecretLister.Secrets(namespace).Get(source.Name)
+		if err != nil {
+			return nil, err
+		}
+		return secret.Data, nil
+	}
+
+	configMap, err := c.ConfigMapLister.ConfigMaps(namespace).Get(source.Name)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		data[key] = value
+	}
+	return data, nil
+}
+
+func selectKeys(data map[string][]byte, keys []string) map[string][]byte {
+	selected := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if value, ok := data[key]; ok {
+			selected[key] = value
+		}
+	}
+	return selected
+}
+
+func hashData(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil))
+}
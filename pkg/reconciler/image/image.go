@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -18,6 +19,7 @@ import (
 	k8sclient "k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging/logkey"
 
@@ -27,6 +29,7 @@ import (
 	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/duckbuilder"
 	"github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/registry"
 	"github.com/pivotal/kpack/pkg/tracker"
 )
 
@@ -44,6 +47,11 @@ func NewController(
 	duckbuilderInformer *duckbuilder.DuckBuilderInformer,
 	sourceResolverInformer buildinformers.SourceResolverInformer,
 	pvcInformer coreinformers.PersistentVolumeClaimInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
+	secretInformer coreinformers.SecretInformer,
+	configMapInformer coreinformers.ConfigMapInformer,
+	keychainFactory registry.KeychainFactory,
+	indexWriter IndexWriter,
 	enablePriorityClasses bool,
 ) *controller.Impl {
 	c := &Reconciler{
@@ -54,7 +62,13 @@ func NewController(
 		DuckBuilderLister:     duckbuilderInformer.Lister(),
 		SourceResolverLister:  sourceResolverInformer.Lister(),
 		PvcLister:             pvcInformer.Lister(),
+		NamespaceLister:       namespaceInformer.Lister(),
+		SecretLister:          secretInformer.Lister(),
+		ConfigMapLister:       configMapInformer.Lister(),
+		KeychainFactory:       keychainFactory,
+		IndexWriter:           indexWriter,
 		EnablePriorityClasses: enablePriorityClasses,
+		Clock:                 clock.RealClock{},
 	}
 
 	logger := opt.Logger.With(
@@ -63,6 +77,10 @@ func NewController(
 
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{WorkQueueName: ReconcilerName, Logger: logger})
 
+	c.Enqueuer = &workQueueEnqueuer{
+		enqueueAfter: impl.EnqueueAfter,
+	}
+
 	imageInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: reconciler.FilterDeletionTimestamp,
 		Handler:    controller.HandleAll(impl.Enqueue),
@@ -83,6 +101,38 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	// downstream images are rebuilt when the latest image of an upstream image changes
+	imageInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		upstream, ok := obj.(*buildapi.Image)
+		if !ok {
+			return
+		}
+		images, err := c.downstreamImages(upstream)
+		if err != nil {
+			logger.Errorw("Failed listing downstream images", zap.Error(err))
+			return
+		}
+		for _, image := range images {
+			impl.Enqueue(image)
+		}
+	}))
+
+	// namespace build windows apply to every image in the namespace
+	namespaceInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		namespace, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return
+		}
+		images, err := c.ImageLister.Images(namespace.Name).List(labels.Everything())
+		if err != nil {
+			logger.Errorw("Failed listing images in namespace", zap.Error(err))
+			return
+		}
+		for _, image := range images {
+			impl.Enqueue(image)
+		}
+	}))
+
 	c.Tracker = tracker.New(impl.EnqueueKey, opt.TrackerResyncPeriod())
 
 	duckbuilderInformer.AddBuilderEventHandler(controller.HandleAll(
@@ -96,9 +146,26 @@ func NewController(
 			buildapi.SchemeGroupVersion.WithKind(buildapi.ClusterBuilderKind)),
 	))
 
+	// images are rebuilt when the data of a secret or config map referenced by the build env changes
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.Tracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind(buildapi.EnvSourceSecretKind)),
+	))
+	configMapInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.Tracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind(buildapi.EnvSourceConfigMapKind)),
+	))
+
 	return impl
 }
 
+//go:generate counterfeiter . Enqueuer
+type Enqueuer interface {
+	EnqueueAfter(*buildapi.Image, time.Duration)
+}
+
 type Reconciler struct {
 	Client                versioned.Interface
 	DuckBuilderLister     *duckbuilder.DuckBuilderLister
@@ -106,9 +173,16 @@ type Reconciler struct {
 	BuildLister           buildlisters.BuildLister
 	SourceResolverLister  buildlisters.SourceResolverLister
 	PvcLister             corelisters.PersistentVolumeClaimLister
+	NamespaceLister       corelisters.NamespaceLister
+	SecretLister          corelisters.SecretLister
+	ConfigMapLister       corelisters.ConfigMapLister
 	Tracker               reconciler.Tracker
 	K8sClient             k8sclient.Interface
+	KeychainFactory       registry.KeychainFactory
+	IndexWriter           IndexWriter
 	EnablePriorityClasses bool
+	Enqueuer              Enqueuer
+	Clock                 clock.PassiveClock
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -146,10 +220,11 @@ func (c *Reconciler) reconcileImage(ctx context.Context, image *buildapi.Image)
 		return image, nil
 	}
 
-	lastBuild, err := c.fetchLastBuild(image)
+	builds, err := c.fetchAllBuilds(image)
 	if err != nil {
 		return nil, err
 	}
+	lastBuild := builds.lastBuild
 
 	if lastBuild.IsRunning() {
 		image.Status.Conditions = buildRunningCondition(lastBuild, builder)
@@ -166,11 +241,19 @@ func (c *Reconciler) reconcileImage(ctx context.Context, image *buildapi.Image)
 		return nil, err
 	}
 
-	image.Status, err = c.reconcileBuild(ctx, image, lastBuild, sourceResolver, builder, buildCacheName)
+	if err := c.reconcileIndex(ctx, image, builds.lastBuilds); err != nil {
+		return nil, err
+	}
+
+	image.Status, err = c.reconcileBuild(ctx, image, lastBuild, builds.lastBuilds, sourceResolver, builder, buildCacheName)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := c.enqueueScheduledBuild(image); err != nil {
+		return nil, err
+	}
+
 	return image, c.deleteOldBuilds(ctx, image)
 }
 
@@ -240,20 +323,24 @@ func (c *Reconciler) deleteOldBuilds(ctx context.Context, image *buildapi.Image)
 		return fmt.Errorf("failed fetching all builds for image: %s", err)
 	}
 
-	if builds.NumberFailedBuilds() > *image.Spec.FailedBuildHistoryLimit {
-		oldestFailedBuild := builds.OldestFailure()
+	// a build can be in more than one history when the platform builds of its build number finished differently
+	deleted := map[string]bool{}
 
-		err := c.Client.KpackV1alpha2().Builds(image.Namespace).Delete(ctx, oldestFailedBuild.Name, metav1.DeleteOptions{})
-		if err != nil {
+	if builds.NumberFailedBuilds() > *image.Spec.FailedBuildHistoryLimit {
+		if err := c.deleteBuilds(ctx, builds.OldestFailure(), deleted); err != nil {
 			return fmt.Errorf("failed deleting failed build: %s", err)
 		}
 	}
 
-	if builds.NumberSuccessfulBuilds() > *image.Spec.SuccessBuildHistoryLimit {
-		oldestSuccess := builds.OldestSuccess()
+	// canceled builds are limited separately so they do not count toward, or prune, the failed build history
+	if builds.NumberCanceledBuilds() > *image.Spec.FailedBuildHistoryLimit {
+		if err := c.deleteBuilds(ctx, builds.OldestCanceled(), deleted); err != nil {
+			return fmt.Errorf("failed deleting canceled build: %s", err)
+		}
+	}
 
-		err := c.Client.KpackV1alpha2().Builds(image.Namespace).Delete(ctx, oldestSuccess.Name, metav1.DeleteOptions{})
-		if err != nil {
+	if builds.NumberSuccessfulBuilds() > *image.Spec.SuccessBuildHistoryLimit {
+		if err := c.deleteBuilds(ctx, builds.OldestSuccess(), deleted); err != nil {
 			return fmt.Errorf("failed deleting successful build: %s", err)
 		}
 	}
@@ -261,6 +348,20 @@ func (c *Reconciler) deleteOldBuilds(ctx context.Context, image *buildapi.Image)
 	return nil
 }
 
+func (c *Reconciler) deleteBuilds(ctx context.Context, builds []*buildapi.Build, deleted map[string]bool) error {
+	for _, build := range builds {
+		if deleted[build.Name] {
+			continue
+		}
+
+		if err := c.Client.KpackV1alpha2().Builds(build.Namespace).Delete(ctx, build.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		deleted[build.Name] = true
+	}
+	return nil
+}
+
 func (c *Reconciler) fetchAllBuilds(image *buildapi.Image) (buildList, error) {
 	imageNameReq, err := labels.NewRequirement(buildapi.ImageLabel, selection.DoubleEquals, []string{image.Name})
 	if err != nil {
@@ -276,14 +377,6 @@ func (c *Reconciler) fetchAllBuilds(image *buildapi.Image) (buildList, error) {
 	return newBuildList(builds)
 }
 
-func (c *Reconciler) fetchLastBuild(image *buildapi.Image) (*buildapi.Build, error) {
-	builds, err := c.fetchAllBuilds(image)
-	if err != nil {
-		return nil, err
-	}
-	return builds.lastBuild, nil
-}
-
 func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.Image) error {
 	desired.Status.ObservedGeneration = desired.Generation
 	original, err := c.ImageLister.Images(desired.Namespace).Get(desired.Name)
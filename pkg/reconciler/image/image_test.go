@@ -1,6 +1,7 @@
 package image_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -15,9 +16,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
 	rtesting "knative.dev/pkg/reconciler/testing"
@@ -27,7 +30,10 @@ import (
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/pivotal/kpack/pkg/reconciler"
 	"github.com/pivotal/kpack/pkg/reconciler/image"
+	"github.com/pivotal/kpack/pkg/reconciler/image/imagefakes"
 	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
 )
 
 func TestImageReconciler(t *testing.T) {
@@ -47,6 +53,10 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 		originalGeneration     int64 = 1
 	)
 	fakeTracker := &testhelpers.FakeTracker{}
+	fakeEnqueuer := &imagefakes.FakeEnqueuer{}
+	fakeIndexWriter := &imagefakes.FakeIndexWriter{}
+	keychainFactory := &registryfakes.FakeKeychainFactory{}
+	now := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
 
 	rt := testhelpers.ReconcilerTester(t,
 		func(t *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
@@ -66,8 +76,15 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				DuckBuilderLister:    listers.GetDuckBuilderLister(),
 				SourceResolverLister: listers.GetSourceResolverLister(),
 				PvcLister:            listers.GetPersistentVolumeClaimLister(),
+				NamespaceLister:      listers.GetNamespaceLister(),
+				SecretLister:         listers.GetSecretLister(),
+				ConfigMapLister:      listers.GetConfigMapLister(),
 				Tracker:              fakeTracker,
 				K8sClient:            k8sfakeClient,
+				Enqueuer:             fakeEnqueuer,
+				KeychainFactory:      keychainFactory,
+				IndexWriter:          fakeIndexWriter,
+				Clock:                clocktesting.NewFakePassiveClock(now),
 			}
 
 			rtesting.PrependGenerateNameReactor(&fakeClient.Fake)
@@ -1705,6 +1722,150 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			it("schedules a build with the previous builder image while the builder rollout is pending", func() {
+				const (
+					previousBuilderImage = "some/builder@sha256:previous"
+					previousRunImage     = "some/run@sha256:previous"
+				)
+				imageWithBuilder.Status.BuildCounter = 1
+				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+
+				rolloutBuilder := builder.DeepCopy()
+				rolloutBuilder.Spec.Rollout = &buildapi.BuilderRollout{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "canary"}},
+				}
+				rolloutBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+					Image: builder.Status.LatestImage,
+					Phase: buildapi.RolloutPhaseCanary,
+				}
+
+				sourceResolver := imageWithBuilder.SourceResolver()
+				sourceResolver.ResolvedSource(corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      imageWithBuilder.Spec.Source.Git.URL,
+						Revision: "new-commit",
+						Type:     corev1alpha1.Branch,
+					},
+				})
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						imageWithBuilder,
+						rolloutBuilder,
+						sourceResolver,
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "image-name-build-1",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(imageWithBuilder),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       imageName,
+								},
+								Annotations: map[string]string{
+									buildapi.BuildReasonAnnotation: buildapi.BuildReasonCommit,
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{imageWithBuilder.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: previousBuilderImage,
+								},
+								RunImage:           buildapi.BuildSpecImage{Image: previousRunImage},
+								ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      imageWithBuilder.Spec.Source.Git.URL,
+										Revision: imageWithBuilder.Spec.Source.Git.Revision,
+									},
+								},
+							},
+							Status: buildapi.BuildStatus{
+								LatestImage: imageWithBuilder.Spec.Tag + "@sha256:just-built",
+								Stack: corev1alpha1.BuildStack{
+									RunImage: previousRunImage,
+									ID:       "io.buildpacks.stacks.bionic",
+								},
+								LifecycleVersion: "previous-version",
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+							},
+						},
+					},
+					WantErr: false,
+					WantCreates: []runtime.Object{
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      imageName + "-build-2",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(imageWithBuilder),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel:     "2",
+									buildapi.ImageLabel:           imageName,
+									buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+									someLabelKey:                  someValueToPassThrough,
+								},
+								Annotations: map[string]string{
+									buildapi.BuilderNameAnnotation:  builderName,
+									buildapi.BuilderKindAnnotation:  buildapi.BuilderKind,
+									buildapi.BuildReasonAnnotation:  buildapi.BuildReasonCommit,
+									buildapi.BuildChangesAnnotation: `[{"reason":"COMMIT","old":"1234567","new":"new-commit"}]`,
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{imageWithBuilder.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: previousBuilderImage,
+								},
+								ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      sourceResolver.Status.Source.Git.URL,
+										Revision: sourceResolver.Status.Source.Git.Revision,
+									},
+								},
+								Cache:    &buildapi.BuildCacheConfig{},
+								RunImage: buildapi.BuildSpecImage{Image: previousRunImage},
+								LastBuild: &buildapi.LastBuild{
+									Image:   "some/image@sha256:just-built",
+									StackId: "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: imageWithBuilder.ObjectMeta,
+								Spec:       imageWithBuilder.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions:         conditionBuildExecuting("image-name-build-2"),
+									},
+									LatestBuildRef:             "image-name-build-2",
+									LatestBuildReason:          "COMMIT",
+									LatestBuildImageGeneration: originalGeneration,
+									LatestImage:                imageWithBuilder.Spec.Tag + "@sha256:just-built",
+									BuildCounter:               2,
+								},
+							},
+						},
+					},
+				})
+			})
+
 			it("schedules a build when the builder buildpacks are updated", func() {
 				imageWithBuilder.Status.BuildCounter = 1
 				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
@@ -2521,101 +2682,1512 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
-			it("reports the last successful build on the image when the last build is successful", func() {
-				imageWithBuilder.Status.BuildCounter = 1
-				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
-				imageWithBuilder.Status.LatestImage = "some/image@some-old-sha"
-				imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+			when("the image has a schedule", func() {
+				var sourceResolver *buildapi.SourceResolver
 
-				sourceResolver := resolvedSourceResolver(imageWithBuilder)
-				rt.Test(rtesting.TableRow{
-					Key: key,
-					Objects: runtimeObjects(
-						successfulBuilds(imageWithBuilder, sourceResolver, 1),
-						imageWithBuilder,
-						builder,
-						sourceResolver,
-					),
-					WantErr: false,
-					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
-						{
-							Object: &buildapi.Image{
-								ObjectMeta: imageWithBuilder.ObjectMeta,
-								Spec:       imageWithBuilder.Spec,
-								Status: buildapi.ImageStatus{
-									Status: corev1alpha1.Status{
-										ObservedGeneration: originalGeneration,
-										Conditions: corev1alpha1.Conditions{
-											{
-												Type:   corev1alpha1.ConditionReady,
-												Status: corev1.ConditionTrue,
-												Reason: image.UpToDateReason,
-											},
-											{
-												Type:   buildapi.ConditionBuilderReady,
-												Status: corev1.ConditionTrue,
-												Reason: buildapi.BuilderReady,
-											},
-											{
-												Type:   buildapi.ConditionBuilderUpToDate,
-												Status: corev1.ConditionTrue,
-												Reason: buildapi.BuilderUpToDate,
-											},
-										},
+				it.Before(func() {
+					imageWithBuilder.Spec.Schedule = "@weekly"
+					imageWithBuilder.CreationTimestamp = metav1.NewTime(time.Date(2023, time.December, 30, 0, 0, 0, 0, time.UTC))
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+					imageWithBuilder.Status.LatestImage = "some/image@sha256:ad3f454c"
+					imageWithBuilder.Status.Conditions = conditionReady()
+					imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+					sourceResolver = resolvedSourceResolver(imageWithBuilder)
+				})
+
+				lastBuild := func() *buildapi.Build {
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      imageWithBuilder.Status.LatestBuildRef,
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(imageWithBuilder),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel: "1",
+								buildapi.ImageLabel:       imageName,
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{imageWithBuilder.Spec.Tag},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: builder.Status.LatestImage,
+							},
+							ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      sourceResolver.Status.Source.Git.URL,
+									Revision: sourceResolver.Status.Source.Git.Revision,
+								},
+							},
+						},
+						Status: buildapi.BuildStatus{
+							LatestImage: imageWithBuilder.Status.LatestImage,
+							Stack: corev1alpha1.BuildStack{
+								RunImage: "some/run@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
+								ID:       "io.buildpacks.stacks.bionic",
+							},
+							LifecycleVersion: "some-version",
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionSucceeded,
+										Status: corev1.ConditionTrue,
 									},
-									LatestBuildRef: "image-name-build-1",
-									LatestImage:    "some/image@sha256:build-1",
-									BuildCounter:   1,
-									LatestStack:    "io.buildpacks.stacks.bionic",
 								},
 							},
 						},
-					},
-				})
-			})
-
-			it("reports unknown when last build was successful and source resolver is unknown", func() {
-				imageWithBuilder.Status.BuildCounter = 1
-				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
-				imageWithBuilder.Status.LatestImage = "some/image@some-old-sha"
-				imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+					}
+				}
 
-				sourceResolver := resolvedSourceResolver(imageWithBuilder)
-				rt.Test(rtesting.TableRow{
-					Key: key,
-					Objects: runtimeObjects(
-						successfulBuilds(imageWithBuilder, sourceResolver, 1),
-						imageWithBuilder,
-						builder,
-						unresolvedSourceResolver(imageWithBuilder),
-					),
-					WantErr: false,
-					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
-						{
-							Object: &buildapi.Image{
-								ObjectMeta: imageWithBuilder.ObjectMeta,
-								Spec:       imageWithBuilder.Spec,
-								Status: buildapi.ImageStatus{
-									Status: corev1alpha1.Status{
-										ObservedGeneration: originalGeneration,
-										Conditions:         conditionReadyUnknown(),
+				it("schedules a build when the schedule is due", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation: builderName,
+										buildapi.BuilderKindAnnotation: buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation: buildapi.BuildReasonScheduled,
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "SCHEDULED",
+    "old": "",
+    "new": "2023-12-31T00:00:00Z"
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+									Cache:    &buildapi.BuildCacheConfig{},
+									RunImage: builderRunImage,
+									LastBuild: &buildapi.LastBuild{
+										Image:   imageWithBuilder.Status.LatestImage,
+										StackId: "io.buildpacks.stacks.bionic",
 									},
-									LatestBuildRef: "image-name-build-1",
-									LatestImage:    "some/image@sha256:build-1",
-									BuildCounter:   1,
-									LatestStack:    "io.buildpacks.stacks.bionic",
 								},
 							},
 						},
-					},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          buildapi.BuildReasonScheduled,
+										LatestImage:                imageWithBuilder.Status.LatestImage,
+										BuildCounter:               2,
+										LastScheduledBuildTime:     &metav1.Time{Time: now},
+									},
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+					_, delay := fakeEnqueuer.EnqueueAfterArgsForCall(0)
+					assert.Equal(t, 84*time.Hour, delay)
 				})
-			})
 
-			it("reports false when last build was successful and builder is not ready", func() {
-				imageWithBuilder.Status.BuildCounter = 1
-				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
-				imageWithBuilder.Status.LatestImage = "some/image@some-old-sha"
-				imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+				it("requeues the image until the schedule is due", func() {
+					imageWithBuilder.Status.LastScheduledBuildTime = &metav1.Time{Time: time.Date(2024, time.January, 7, 0, 0, 0, 0, time.UTC)}
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+					_, delay := fakeEnqueuer.EnqueueAfterArgsForCall(0)
+					assert.Equal(t, 84*time.Hour, delay)
+				})
+			})
+
+			when("the image has a retry policy", func() {
+				var sourceResolver *buildapi.SourceResolver
+
+				it.Before(func() {
+					imageWithBuilder.Spec.Build = &buildapi.ImageBuild{
+						RetryPolicy: &buildapi.BuildRetryPolicy{
+							MaxAttempts: 3,
+							Backoff:     &metav1.Duration{Duration: 2 * time.Minute},
+						},
+					}
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+					imageWithBuilder.Status.LatestImage = "some/image@sha256:ad3f454c"
+					imageWithBuilder.Status.Conditions = conditionNotReady(imageWithBuilder)
+
+					sourceResolver = resolvedSourceResolver(imageWithBuilder)
+				})
+
+				failedBuild := func(attempt string, reason string, finished time.Time) *buildapi.Build {
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      imageWithBuilder.Status.LatestBuildRef,
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(imageWithBuilder),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel: "1",
+								buildapi.ImageLabel:       imageName,
+							},
+							Annotations: map[string]string{
+								buildapi.BuildAttemptAnnotation: attempt,
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{imageWithBuilder.Spec.Tag},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: builder.Status.LatestImage,
+							},
+							ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      sourceResolver.Status.Source.Git.URL,
+									Revision: sourceResolver.Status.Source.Git.Revision,
+								},
+							},
+							LastBuild: &buildapi.LastBuild{
+								Image:   imageWithBuilder.Status.LatestImage,
+								StackId: "io.buildpacks.stacks.bionic",
+							},
+						},
+						Status: buildapi.BuildStatus{
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:               corev1alpha1.ConditionSucceeded,
+										Status:             corev1.ConditionFalse,
+										Reason:             reason,
+										LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.NewTime(finished)},
+									},
+								},
+							},
+						},
+					}
+				}
+
+				it("retries a failed build once the backoff has elapsed", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							failedBuild("1", "PodFailed", now.Add(-3*time.Minute)),
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation:  builderName,
+										buildapi.BuilderKindAnnotation:  buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation:  buildapi.BuildReasonRetry,
+										buildapi.BuildAttemptAnnotation: "2",
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": 1,
+    "new": 2
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+									Cache:    &buildapi.BuildCacheConfig{},
+									RunImage: builderRunImage,
+									LastBuild: &buildapi.LastBuild{
+										Image:   imageWithBuilder.Status.LatestImage,
+										StackId: "io.buildpacks.stacks.bionic",
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          buildapi.BuildReasonRetry,
+										LatestImage:                imageWithBuilder.Status.LatestImage,
+										BuildCounter:               2,
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("keeps the reasons and changes of the failed build on the retry", func() {
+					failed := failedBuild("1", "PodFailed", now.Add(-3*time.Minute))
+					failed.Annotations[buildapi.BuildReasonAnnotation] = buildapi.BuildReasonCommit
+					failed.Annotations[buildapi.BuildChangesAnnotation] = `[{"reason":"COMMIT","old":"previous-commit","new":"1234567"}]`
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							failed,
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation:  builderName,
+										buildapi.BuilderKindAnnotation:  buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation:  "RETRY,COMMIT",
+										buildapi.BuildAttemptAnnotation: "2",
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": 1,
+    "new": 2
+  },
+  {
+    "reason": "COMMIT",
+    "old": "previous-commit",
+    "new": "1234567"
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+									Cache:    &buildapi.BuildCacheConfig{},
+									RunImage: builderRunImage,
+									LastBuild: &buildapi.LastBuild{
+										Image:   imageWithBuilder.Status.LatestImage,
+										StackId: "io.buildpacks.stacks.bionic",
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          "RETRY,COMMIT",
+										LatestImage:                imageWithBuilder.Status.LatestImage,
+										BuildCounter:               2,
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("starts at the first attempt when the build is for a new change", func() {
+					failed := failedBuild("1", "PodFailed", now.Add(-3*time.Minute))
+					sourceResolver.ResolvedSource(corev1alpha1.ResolvedSourceConfig{
+						Git: &corev1alpha1.ResolvedGitSource{
+							URL:      sourceResolver.Status.Source.Git.URL,
+							Revision: "new-commit",
+							Type:     corev1alpha1.Branch,
+						},
+					})
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							failed,
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation: builderName,
+										buildapi.BuilderKindAnnotation: buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation: "RETRY,COMMIT",
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": 1,
+    "new": 2
+  },
+  {
+    "reason": "COMMIT",
+    "old": "1234567-resolved",
+    "new": "new-commit"
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: "new-commit",
+										},
+									},
+									Cache:    &buildapi.BuildCacheConfig{},
+									RunImage: builderRunImage,
+									LastBuild: &buildapi.LastBuild{
+										Image:   imageWithBuilder.Status.LatestImage,
+										StackId: "io.buildpacks.stacks.bionic",
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          "RETRY,COMMIT",
+										LatestImage:                imageWithBuilder.Status.LatestImage,
+										BuildCounter:               2,
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("requeues the image until the backoff of the attempt has elapsed", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							failedBuild("2", "PodFailed", now.Add(-3*time.Minute)),
+						},
+						WantErr: false,
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+					_, delay := fakeEnqueuer.EnqueueAfterArgsForCall(0)
+					assert.Equal(t, time.Minute, delay)
+				})
+
+				it("does not retry a build after the max attempts", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							failedBuild("3", "PodFailed", now.Add(-time.Hour)),
+						},
+						WantErr: false,
+					})
+
+					require.Equal(t, 0, fakeEnqueuer.EnqueueAfterCallCount())
+				})
+
+				it("does not retry a build that failed to detect or compile the application", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							failedBuild("1", buildapi.CompileFailedReason, now.Add(-time.Hour)),
+						},
+						WantErr: false,
+					})
+
+					require.Equal(t, 0, fakeEnqueuer.EnqueueAfterCallCount())
+				})
+			})
+
+			when("the image has a build request", func() {
+				const buildRequest = `{"id":"some-request","revision":"requested-revision","env":[{"name":"DEBUG","value":"true"}],"logLevel":"debug"}`
+				var sourceResolver *buildapi.SourceResolver
+
+				it.Before(func() {
+					imageWithBuilder.Annotations = map[string]string{buildapi.BuildRequestAnnotation: buildRequest}
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+					imageWithBuilder.Status.LatestImage = "some/image@sha256:ad3f454c"
+					imageWithBuilder.Status.Conditions = conditionReady()
+					imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+					sourceResolver = resolvedSourceResolver(imageWithBuilder)
+				})
+
+				lastBuild := func() *buildapi.Build {
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      imageWithBuilder.Status.LatestBuildRef,
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(imageWithBuilder),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel: "1",
+								buildapi.ImageLabel:       imageName,
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{imageWithBuilder.Spec.Tag},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: builder.Status.LatestImage,
+							},
+							ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      sourceResolver.Status.Source.Git.URL,
+									Revision: sourceResolver.Status.Source.Git.Revision,
+								},
+							},
+						},
+						Status: buildapi.BuildStatus{
+							LatestImage: imageWithBuilder.Status.LatestImage,
+							Stack: corev1alpha1.BuildStack{
+								RunImage: "some/run@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
+								ID:       "io.buildpacks.stacks.bionic",
+							},
+							LifecycleVersion: "some-version",
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionSucceeded,
+										Status: corev1.ConditionTrue,
+									},
+								},
+							},
+						},
+					}
+				}
+
+				it("creates a build with the overrides of the request", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuildRequestAnnotation: buildRequest,
+										buildapi.BuilderNameAnnotation:  builderName,
+										buildapi.BuilderKindAnnotation:  buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation:  buildapi.BuildReasonRequest,
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "REQUEST",
+    "old": {
+      "revision": "1234567-resolved"
+    },
+    "new": {
+      "id": "some-request",
+      "revision": "requested-revision",
+      "env": [
+        {
+          "name": "DEBUG",
+          "value": "true"
+        }
+      ],
+      "logLevel": "debug"
+    }
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: "requested-revision",
+										},
+									},
+									Env:      []corev1.EnvVar{{Name: "DEBUG", Value: "true"}},
+									LogLevel: "debug",
+									Cache:    &buildapi.BuildCacheConfig{},
+									RunImage: builderRunImage,
+									LastBuild: &buildapi.LastBuild{
+										Image:   imageWithBuilder.Status.LatestImage,
+										StackId: "io.buildpacks.stacks.bionic",
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          buildapi.BuildReasonRequest,
+										LatestImage:                imageWithBuilder.Status.LatestImage,
+										BuildCounter:               2,
+										LastBuildRequestID:         "some-request",
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("does not create another build for the same request", func() {
+					imageWithBuilder.Status.LastBuildRequestID = "some-request"
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+					})
+				})
+			})
+
+			when("build windows are closed", func() {
+				var sourceResolver *buildapi.SourceResolver
+
+				stackWindow := buildapi.BuildWindow{
+					Reasons:  []string{buildapi.BuildReasonStack},
+					Schedule: "0 22 * * *",
+					Duration: metav1.Duration{Duration: 6 * time.Hour},
+				}
+
+				it.Before(func() {
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+					imageWithBuilder.Status.LatestImage = "some/image@sha256:ad3f454c"
+					imageWithBuilder.Status.Conditions = conditionReady()
+					imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+					sourceResolver = resolvedSourceResolver(imageWithBuilder)
+				})
+
+				lastBuild := func() *buildapi.Build {
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      imageWithBuilder.Status.LatestBuildRef,
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(imageWithBuilder),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel: "1",
+								buildapi.ImageLabel:       imageName,
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{imageWithBuilder.Spec.Tag},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: builder.Status.LatestImage,
+							},
+							ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      sourceResolver.Status.Source.Git.URL,
+									Revision: sourceResolver.Status.Source.Git.Revision,
+								},
+							},
+						},
+						Status: buildapi.BuildStatus{
+							LatestImage: imageWithBuilder.Status.LatestImage,
+							Stack: corev1alpha1.BuildStack{
+								RunImage: "some/run@sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+								ID:       "io.buildpacks.stacks.bionic",
+							},
+							LifecycleVersion: "some-version",
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionSucceeded,
+										Status: corev1.ConditionTrue,
+									},
+								},
+							},
+						},
+					}
+				}
+
+				deferredStatus := func() buildapi.ImageStatus {
+					return buildapi.ImageStatus{
+						Status: corev1alpha1.Status{
+							ObservedGeneration: originalGeneration,
+							Conditions: append(conditionReady(), corev1alpha1.Condition{
+								Type:    buildapi.ConditionBuildPending,
+								Status:  corev1.ConditionTrue,
+								Reason:  image.BuildWindowClosedReason,
+								Message: "Build for STACK is deferred until the build window opens at 2024-01-10T22:00:00Z",
+							}),
+						},
+						LatestBuildRef: "image-name-build-1",
+						LatestImage:    imageWithBuilder.Status.LatestImage,
+						LatestStack:    "io.buildpacks.stacks.bionic",
+						BuildCounter:   1,
+						PendingBuild: &buildapi.PendingBuild{
+							Reason: buildapi.BuildReasonStack,
+							Changes: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "STACK",
+    "old": "sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+    "new": "sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb"
+  }
+]`),
+							DeferredUntil: &metav1.Time{Time: time.Date(2024, time.January, 10, 22, 0, 0, 0, time.UTC)},
+						},
+					}
+				}
+
+				it("defers the build until the image build window opens", func() {
+					imageWithBuilder.Spec.BuildWindows = buildapi.BuildWindows{stackWindow}
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status:     deferredStatus(),
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+					_, delay := fakeEnqueuer.EnqueueAfterArgsForCall(0)
+					assert.Equal(t, 10*time.Hour, delay)
+				})
+
+				it("defers the build until the namespace build window opens", func() {
+					windows, err := json.Marshal(buildapi.BuildWindows{stackWindow})
+					require.NoError(t, err)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							&corev1.Namespace{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: namespace,
+									Annotations: map[string]string{
+										buildapi.BuildWindowsAnnotation: string(windows),
+									},
+								},
+							},
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status:     deferredStatus(),
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+				})
+
+				it("does not defer commit builds", func() {
+					imageWithBuilder.Spec.BuildWindows = buildapi.BuildWindows{stackWindow}
+					build := lastBuild()
+					sourceResolver.Status.Source.Git.Revision = "some-new-revision"
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							build,
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation: builderName,
+										buildapi.BuilderKindAnnotation: buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation: strings.Join([]string{buildapi.BuildReasonCommit, buildapi.BuildReasonStack}, ","),
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "COMMIT",
+    "old": "` + build.Spec.Source.Git.Revision + `",
+    "new": "some-new-revision"
+  },
+  {
+    "reason": "STACK",
+    "old": "sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+    "new": "sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb"
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: "some-new-revision",
+										},
+									},
+									Cache:    &buildapi.BuildCacheConfig{},
+									RunImage: builderRunImage,
+									LastBuild: &buildapi.LastBuild{
+										Image:   imageWithBuilder.Status.LatestImage,
+										StackId: "io.buildpacks.stacks.bionic",
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          strings.Join([]string{buildapi.BuildReasonCommit, buildapi.BuildReasonStack}, ","),
+										LatestImage:                imageWithBuilder.Status.LatestImage,
+										BuildCounter:               2,
+									},
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 0, fakeEnqueuer.EnqueueAfterCallCount())
+				})
+			})
+
+			when("the image only allows some build reasons", func() {
+				var sourceResolver *buildapi.SourceResolver
+
+				it.Before(func() {
+					imageWithBuilder.Spec.AllowedBuildReasons = []string{buildapi.BuildReasonCommit}
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+					imageWithBuilder.Status.LatestImage = "some/image@sha256:ad3f454c"
+					imageWithBuilder.Status.Conditions = conditionReady()
+					imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+					sourceResolver = resolvedSourceResolver(imageWithBuilder)
+				})
+
+				it("reports changes for other reasons as a pending build", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageWithBuilder.Status.LatestBuildRef,
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel: "1",
+										buildapi.ImageLabel:       imageName,
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+								},
+								Status: buildapi.BuildStatus{
+									LatestImage: imageWithBuilder.Status.LatestImage,
+									Stack: corev1alpha1.BuildStack{
+										RunImage: "some/run@sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+										ID:       "io.buildpacks.stacks.bionic",
+									},
+									LifecycleVersion: "some-version",
+									Status: corev1alpha1.Status{
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionSucceeded,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+								},
+							},
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: append(conditionReady(), corev1alpha1.Condition{
+												Type:    buildapi.ConditionBuildPending,
+												Status:  corev1.ConditionTrue,
+												Reason:  image.BuildNotAllowedReason,
+												Message: "Build for STACK is not allowed automatically and is pending until a build is triggered",
+											}),
+										},
+										LatestBuildRef: "image-name-build-1",
+										LatestImage:    imageWithBuilder.Status.LatestImage,
+										LatestStack:    "io.buildpacks.stacks.bionic",
+										BuildCounter:   1,
+										PendingBuild: &buildapi.PendingBuild{
+											Reason: buildapi.BuildReasonStack,
+											Changes: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "STACK",
+    "old": "sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+    "new": "sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb"
+  }
+]`),
+										},
+									},
+								},
+							},
+						},
+					})
+				})
+			})
+
+			when("the image has upstream images", func() {
+				var sourceResolver *buildapi.SourceResolver
+
+				upstreamImage := func(latestImage string) *buildapi.Image {
+					return &buildapi.Image{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "base-image",
+							Namespace: namespace,
+						},
+						Status: buildapi.ImageStatus{
+							LatestImage: latestImage,
+						},
+					}
+				}
+
+				it.Before(func() {
+					imageWithBuilder.Spec.UpstreamImages = []corev1.LocalObjectReference{{Name: "base-image"}}
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+					imageWithBuilder.Status.LatestImage = "some/image@sha256:ad3f454c"
+					imageWithBuilder.Status.Conditions = conditionReady()
+					imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+					sourceResolver = resolvedSourceResolver(imageWithBuilder)
+				})
+
+				lastBuild := func() *buildapi.Build {
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      imageWithBuilder.Status.LatestBuildRef,
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(imageWithBuilder),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel: "1",
+								buildapi.ImageLabel:       imageName,
+							},
+							Annotations: map[string]string{
+								buildapi.UpstreamImagesAnnotation: `{"base-image":"some/base@sha256:old"}`,
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{imageWithBuilder.Spec.Tag},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: builder.Status.LatestImage,
+							},
+							ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      sourceResolver.Status.Source.Git.URL,
+									Revision: sourceResolver.Status.Source.Git.Revision,
+								},
+							},
+						},
+						Status: buildapi.BuildStatus{
+							LatestImage: imageWithBuilder.Status.LatestImage,
+							Stack: corev1alpha1.BuildStack{
+								RunImage: "some/run@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
+								ID:       "io.buildpacks.stacks.bionic",
+							},
+							LifecycleVersion: "some-version",
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionSucceeded,
+										Status: corev1.ConditionTrue,
+									},
+								},
+							},
+						},
+					}
+				}
+
+				it("schedules a build when the latest image of an upstream image changes", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							upstreamImage("some/base@sha256:new"),
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation:    builderName,
+										buildapi.BuilderKindAnnotation:    buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation:    buildapi.BuildReasonUpstream,
+										buildapi.UpstreamImagesAnnotation: `{"base-image":"some/base@sha256:new"}`,
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "UPSTREAM",
+    "old": {
+      "base-image": "some/base@sha256:old"
+    },
+    "new": {
+      "base-image": "some/base@sha256:new"
+    }
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+									Cache:    &buildapi.BuildCacheConfig{},
+									RunImage: builderRunImage,
+									LastBuild: &buildapi.LastBuild{
+										Image:   imageWithBuilder.Status.LatestImage,
+										StackId: "io.buildpacks.stacks.bionic",
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          buildapi.BuildReasonUpstream,
+										LatestImage:                imageWithBuilder.Status.LatestImage,
+										BuildCounter:               2,
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("does not schedule a build when the upstream image is unchanged", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							upstreamImage("some/base@sha256:old"),
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							lastBuild(),
+						},
+						WantErr: false,
+					})
+				})
+			})
+
+			when("the image has env sources", func() {
+				it.Before(func() {
+					imageWithBuilder.Spec.Build = &buildapi.ImageBuild{
+						Env: []corev1.EnvVar{
+							{
+								Name: "NPM_TOKEN",
+								ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "npm-secret"},
+										Key:                  "token",
+									},
+								},
+							},
+						},
+						EnvFrom: []corev1.EnvFromSource{
+							{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing-config-map"}}},
+						},
+					}
+				})
+
+				it("schedules a build with the hashes of the referenced keys of the env sources and tracks them", func() {
+					sourceResolver := resolvedSourceResolver(imageWithBuilder)
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							&corev1.Secret{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:        "npm-secret",
+									Namespace:   namespace,
+									Labels:      map[string]string{buildapi.EnvSourceLabel: "true"},
+									Annotations: map[string]string{"some": "annotation"},
+								},
+								Data: map[string][]byte{"token": []byte("secret-value"), "unreferenced": []byte("other-value")},
+							},
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-1",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "1",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation: builderName,
+										buildapi.BuilderKindAnnotation: buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation: buildapi.BuildReasonConfig,
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "CONFIG",
+    "old": {
+      "resources": {},
+      "source": {}
+    },
+    "new": {
+      "env": [
+        {
+          "name": "NPM_TOKEN",
+          "valueFrom": {
+            "secretKeyRef": {
+              "name": "npm-secret",
+              "key": "token"
+            }
+          }
+        }
+      ],
+      "envFrom": [
+        {
+          "configMapRef": {
+            "name": "missing-config-map"
+          }
+        }
+      ],
+      "envSources": [
+        {
+          "kind": "ConfigMap",
+          "name": "missing-config-map"
+        },
+        {
+          "kind": "Secret",
+          "name": "npm-secret",
+          "hash": "sha256:5bb9b0c0e674e4b96dc1162be3d1c8a15295170f5f21c51aa5e0a13d0ae5027e"
+        }
+      ],
+      "resources": {},
+      "source": {
+        "git": {
+          "url": "https://some.git/url-resolved",
+          "revision": "1234567-resolved"
+        }
+      }
+    }
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{imageWithBuilder.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Cache:              &buildapi.BuildCacheConfig{},
+									RunImage:           builderRunImage,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+									Env:     imageWithBuilder.Spec.Build.Env,
+									EnvFrom: imageWithBuilder.Spec.Build.EnvFrom,
+									EnvSources: []buildapi.BuildEnvSource{
+										{Kind: "ConfigMap", Name: "missing-config-map"},
+										{Kind: "Secret", Name: "npm-secret", Hash: "sha256:5bb9b0c0e674e4b96dc1162be3d1c8a15295170f5f21c51aa5e0a13d0ae5027e"},
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-1"),
+										},
+										LatestBuildRef:             "image-name-build-1",
+										LatestBuildReason:          "CONFIG",
+										LatestBuildImageGeneration: originalGeneration,
+										BuildCounter:               1,
+									},
+								},
+							},
+						},
+					})
+
+					require.True(t, fakeTracker.IsTracking(reconciler.Key{
+						GroupKind:      schema.GroupKind{Kind: "Secret"},
+						NamespacedName: types.NamespacedName{Namespace: namespace, Name: "npm-secret"},
+					}, imageWithBuilder.NamespacedName()))
+					require.True(t, fakeTracker.IsTracking(reconciler.Key{
+						GroupKind:      schema.GroupKind{Kind: "ConfigMap"},
+						NamespacedName: types.NamespacedName{Namespace: namespace, Name: "missing-config-map"},
+					}, imageWithBuilder.NamespacedName()))
+				})
+			})
+
+			it("reports the last successful build on the image when the last build is successful", func() {
+				imageWithBuilder.Status.BuildCounter = 1
+				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+				imageWithBuilder.Status.LatestImage = "some/image@some-old-sha"
+				imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(imageWithBuilder)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						successfulBuilds(imageWithBuilder, sourceResolver, 1),
+						imageWithBuilder,
+						builder,
+						sourceResolver,
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: imageWithBuilder.ObjectMeta,
+								Spec:       imageWithBuilder.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+												Reason: image.UpToDateReason,
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+												Reason: buildapi.BuilderReady,
+											},
+											{
+												Type:   buildapi.ConditionBuilderUpToDate,
+												Status: corev1.ConditionTrue,
+												Reason: buildapi.BuilderUpToDate,
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("reports unknown when last build was successful and source resolver is unknown", func() {
+				imageWithBuilder.Status.BuildCounter = 1
+				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+				imageWithBuilder.Status.LatestImage = "some/image@some-old-sha"
+				imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(imageWithBuilder)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						successfulBuilds(imageWithBuilder, sourceResolver, 1),
+						imageWithBuilder,
+						builder,
+						unresolvedSourceResolver(imageWithBuilder),
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: imageWithBuilder.ObjectMeta,
+								Spec:       imageWithBuilder.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions:         conditionReadyUnknown(),
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("reports false when last build was successful and builder is not ready", func() {
+				imageWithBuilder.Status.BuildCounter = 1
+				imageWithBuilder.Status.LatestBuildRef = "image-name-build-1"
+				imageWithBuilder.Status.LatestImage = "some/image@some-old-sha"
+				imageWithBuilder.Status.LatestStack = "io.buildpacks.stacks.bionic"
 
 				sourceResolver := resolvedSourceResolver(imageWithBuilder)
 				rt.Test(rtesting.TableRow{
@@ -2643,23 +4215,111 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 												Message: "Error: Builder 'builder-name' is not ready in namespace 'some-namespace'",
 											},
 											{
-												Type:    buildapi.ConditionBuilderReady,
-												Status:  corev1.ConditionFalse,
-												Reason:  buildapi.BuilderNotReady,
-												Message: "Error: Builder 'builder-name' is not ready in namespace 'some-namespace'",
+												Type:    buildapi.ConditionBuilderReady,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.BuilderNotReady,
+												Message: "Error: Builder 'builder-name' is not ready in namespace 'some-namespace'",
+											},
+											{
+												Type:    buildapi.ConditionBuilderUpToDate,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.BuilderNotUpToDate,
+												Message: "Builder is not up to date. The latest stack and buildpacks may not be in use.",
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("includes failed builds status in not ready condition", func() {
+				imageWithBuilder.Status.BuildCounter = 1
+				failureMessage := "something went wrong"
+				sourceResolver := resolvedSourceResolver(imageWithBuilder)
+				failedBuild := &buildapi.Build{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-build-%d", imageWithBuilder.Name, 1),
+						Namespace: imageWithBuilder.Namespace,
+						OwnerReferences: []metav1.OwnerReference{
+							*kmeta.NewControllerRef(imageWithBuilder),
+						},
+						Labels: map[string]string{
+							buildapi.BuildNumberLabel: "1",
+							buildapi.ImageLabel:       imageWithBuilder.Name,
+						},
+						CreationTimestamp: metav1.NewTime(time.Now().Add(time.Duration(1) * time.Minute)),
+					},
+					Spec: buildapi.BuildSpec{
+						Tags: []string{imageWithBuilder.Spec.Tag},
+						Builder: corev1alpha1.BuildBuilderSpec{
+							Image: "builder-image/foo@sha256:112312",
+						},
+						ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+						Source: corev1alpha1.SourceConfig{
+							Git: &corev1alpha1.Git{
+								URL:      sourceResolver.Status.Source.Git.URL,
+								Revision: sourceResolver.Status.Source.Git.Revision,
+							},
+						},
+					},
+					Status: buildapi.BuildStatus{
+						Status: corev1alpha1.Status{
+							Conditions: corev1alpha1.Conditions{
+								corev1alpha1.Condition{
+									Type:    corev1alpha1.ConditionSucceeded,
+									Status:  corev1.ConditionFalse,
+									Message: failureMessage,
+								},
+							},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						[]runtime.Object{failedBuild},
+						imageWithBuilder,
+						builder,
+						sourceResolver,
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: imageWithBuilder.ObjectMeta,
+								Spec:       imageWithBuilder.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+												Reason:  image.BuildFailedReason,
+												Message: fmt.Sprintf("Error: Build '%s' in namespace '%s' failed: %s", failedBuild.Name, failedBuild.Namespace, failureMessage),
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+												Reason: buildapi.BuilderReady,
 											},
 											{
-												Type:    buildapi.ConditionBuilderUpToDate,
-												Status:  corev1.ConditionFalse,
-												Reason:  buildapi.BuilderNotUpToDate,
-												Message: "Builder is not up to date. The latest stack and buildpacks may not be in use.",
+												Type:   buildapi.ConditionBuilderUpToDate,
+												Status: corev1.ConditionTrue,
+												Reason: buildapi.BuilderUpToDate,
 											},
 										},
 									},
 									LatestBuildRef: "image-name-build-1",
-									LatestImage:    "some/image@sha256:build-1",
 									BuildCounter:   1,
-									LatestStack:    "io.buildpacks.stacks.bionic",
 								},
 							},
 						},
@@ -2667,7 +4327,7 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
-			it("includes failed builds status in not ready condition", func() {
+			it("reports source verification failures in not ready condition", func() {
 				imageWithBuilder.Status.BuildCounter = 1
 				failureMessage := "something went wrong"
 				sourceResolver := resolvedSourceResolver(imageWithBuilder)
@@ -2703,6 +4363,7 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 								corev1alpha1.Condition{
 									Type:    corev1alpha1.ConditionSucceeded,
 									Status:  corev1.ConditionFalse,
+									Reason:  buildapi.SourceVerificationFailedReason,
 									Message: failureMessage,
 								},
 							},
@@ -2731,7 +4392,7 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 											{
 												Type:    corev1alpha1.ConditionReady,
 												Status:  corev1.ConditionFalse,
-												Reason:  image.BuildFailedReason,
+												Reason:  buildapi.SourceVerificationFailedReason,
 												Message: fmt.Sprintf("Error: Build '%s' in namespace '%s' failed: %s", failedBuild.Name, failedBuild.Namespace, failureMessage),
 											},
 											{
@@ -2755,6 +4416,428 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("the image has platforms", func() {
+				var sourceResolver *buildapi.SourceResolver
+
+				it.Before(func() {
+					imageWithBuilder.Spec.Platforms = []string{"linux/amd64", "linux/arm64"}
+					sourceResolver = resolvedSourceResolver(imageWithBuilder)
+					builder.Status.PlatformImages = []buildapi.BuilderPlatformImage{
+						{Platform: "linux/amd64", Image: "some/builder@sha256:linux-amd64", RunImage: "some/run@sha256:linux-amd64"},
+						{Platform: "linux/arm64", Image: "some/builder@sha256:linux-arm64", RunImage: "some/run@sha256:linux-arm64"},
+					}
+				})
+
+				platformsChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "CONFIG",
+    "old": {
+      "resources": {},
+      "source": {}
+    },
+    "new": {
+      "resources": {},
+      "source": {
+        "git": {
+          "url": "https://some.git/url-resolved",
+          "revision": "1234567-resolved"
+        }
+      },
+      "platforms": ["linux/amd64", "linux/arm64"]
+    }
+  }
+]`)
+
+				platformBuild := func(platform string, condition corev1.ConditionStatus) *buildapi.Build {
+					suffix := strings.ReplaceAll(platform, "/", "-")
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      imageName + "-build-1-" + suffix,
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(imageWithBuilder),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel:     "1",
+								buildapi.ImageLabel:           imageName,
+								buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+							},
+							Annotations: map[string]string{
+								buildapi.BuildReasonAnnotation:    buildapi.BuildReasonConfig,
+								buildapi.BuildPlatformAnnotation:  platform,
+								buildapi.BuildPlatformsAnnotation: "linux/amd64,linux/arm64",
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{"some/image:" + suffix},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: builder.Status.LatestImage,
+							},
+							ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+							RunImage:           builderRunImage,
+							NodeSelector:       map[string]string{"kubernetes.io/arch": strings.TrimPrefix(platform, "linux/")},
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      sourceResolver.Status.Source.Git.URL,
+									Revision: sourceResolver.Status.Source.Git.Revision,
+								},
+							},
+						},
+						Status: buildapi.BuildStatus{
+							LatestImage:   "some/image@sha256:" + strings.ReplaceAll(platform, "/", "-"),
+							BuildMetadata: builder.Status.BuilderMetadata,
+							Stack:         builder.Status.Stack,
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionSucceeded,
+										Status: condition,
+									},
+								},
+							},
+							LifecycleVersion: "some-version",
+						},
+					}
+				}
+
+				it("schedules a build for every platform", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-1-linux-amd64",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "1",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation:    builderName,
+										buildapi.BuilderKindAnnotation:    buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation:    buildapi.BuildReasonConfig,
+										buildapi.BuildPlatformAnnotation:  "linux/amd64",
+										buildapi.BuildPlatformsAnnotation: "linux/amd64,linux/arm64",
+										buildapi.BuildChangesAnnotation:   platformsChanges,
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{"some/image:linux-amd64"},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Cache:              &buildapi.BuildCacheConfig{},
+									RunImage:           builderRunImage,
+									NodeSelector:       map[string]string{"kubernetes.io/arch": "amd64"},
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+								},
+							},
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-1-linux-arm64",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(imageWithBuilder),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "1",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(imageWithBuilder),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuilderNameAnnotation:    builderName,
+										buildapi.BuilderKindAnnotation:    buildapi.BuilderKind,
+										buildapi.BuildReasonAnnotation:    buildapi.BuildReasonConfig,
+										buildapi.BuildPlatformAnnotation:  "linux/arm64",
+										buildapi.BuildPlatformsAnnotation: "linux/amd64,linux/arm64",
+										buildapi.BuildChangesAnnotation:   platformsChanges,
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{"some/image:linux-arm64"},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: imageWithBuilder.Spec.ServiceAccountName,
+									Cache:              &buildapi.BuildCacheConfig{},
+									RunImage:           builderRunImage,
+									NodeSelector:       map[string]string{"kubernetes.io/arch": "arm64"},
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-1-linux-amd64"),
+										},
+										LatestBuildRef:             "image-name-build-1-linux-amd64",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          buildapi.BuildReasonConfig,
+										BuildCounter:               1,
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("does not schedule builds while the builder does not support every platform", func() {
+					builder.Status.PlatformImages = builder.Status.PlatformImages[:1]
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:    corev1alpha1.ConditionReady,
+													Status:  corev1.ConditionUnknown,
+													Reason:  image.UnknownStateReason,
+													Message: "Image has not been built",
+												},
+												{
+													Type:   buildapi.ConditionBuilderReady,
+													Status: corev1.ConditionTrue,
+													Reason: buildapi.BuilderReady,
+												},
+												{
+													Type:   buildapi.ConditionBuilderUpToDate,
+													Status: corev1.ConditionTrue,
+													Reason: buildapi.BuilderUpToDate,
+												},
+												{
+													Type:    buildapi.ConditionBuildPending,
+													Status:  corev1.ConditionTrue,
+													Reason:  image.PlatformsNotSupported,
+													Message: "Build for CONFIG is pending until the builder supports the platforms linux/arm64",
+												},
+											},
+										},
+										PendingBuild: &buildapi.PendingBuild{
+											Reason:  buildapi.BuildReasonConfig,
+											Changes: platformsChanges,
+										},
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("pushes an image index once every platform build succeeded", func() {
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1-linux-amd64"
+					keychain := &registryfakes.FakeKeychain{}
+					keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+						ServiceAccount: serviceAccount,
+						Namespace:      namespace,
+					}, keychain)
+					fakeIndexWriter.WriteReturns("some/image@sha256:index", nil)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							platformBuild("linux/amd64", corev1.ConditionTrue),
+							platformBuild("linux/arm64", corev1.ConditionTrue),
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionReady(),
+										},
+										LatestBuildRef:             "image-name-build-1-linux-amd64",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          buildapi.BuildReasonConfig,
+										LatestImage:                "some/image@sha256:index",
+										LatestStack:                "io.buildpacks.stacks.bionic",
+										BuildCounter:               1,
+										LatestPlatformImages: []buildapi.PlatformImage{
+											{Platform: "linux/amd64", Image: "some/image@sha256:linux-amd64"},
+											{Platform: "linux/arm64", Image: "some/image@sha256:linux-arm64"},
+										},
+									},
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 1, fakeIndexWriter.WriteCallCount())
+					actualKeychain, tags, images := fakeIndexWriter.WriteArgsForCall(0)
+					assert.Equal(t, keychain, actualKeychain)
+					assert.Equal(t, []string{"some/image"}, tags)
+					assert.Equal(t, []buildapi.PlatformImage{
+						{Platform: "linux/amd64", Image: "some/image@sha256:linux-amd64"},
+						{Platform: "linux/arm64", Image: "some/image@sha256:linux-arm64"},
+					}, images)
+				})
+
+				it("deletes every platform build of the oldest build number over the history limit", func() {
+					imageWithBuilder.Spec.SuccessBuildHistoryLimit = limit(1)
+					imageWithBuilder.Status = buildapi.ImageStatus{
+						Status: corev1alpha1.Status{
+							ObservedGeneration: originalGeneration,
+							Conditions:         conditionReady(),
+						},
+						LatestBuildRef:             "image-name-build-2-linux-amd64",
+						LatestBuildImageGeneration: originalGeneration,
+						LatestBuildReason:          buildapi.BuildReasonConfig,
+						LatestImage:                "some/image@sha256:index",
+						LatestStack:                "io.buildpacks.stacks.bionic",
+						BuildCounter:               2,
+						LatestPlatformImages: []buildapi.PlatformImage{
+							{Platform: "linux/amd64", Image: "some/image@sha256:linux-amd64"},
+							{Platform: "linux/arm64", Image: "some/image@sha256:linux-arm64"},
+						},
+					}
+
+					firstBuild := func(build *buildapi.Build) *buildapi.Build {
+						build.CreationTimestamp = metav1.NewTime(time.Now())
+						return build
+					}
+					secondBuild := func(build *buildapi.Build) *buildapi.Build {
+						build.Name = strings.Replace(build.Name, "-build-1-", "-build-2-", 1)
+						build.Labels[buildapi.BuildNumberLabel] = "2"
+						build.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+						return build
+					}
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							firstBuild(platformBuild("linux/amd64", corev1.ConditionTrue)),
+							firstBuild(platformBuild("linux/arm64", corev1.ConditionTrue)),
+							secondBuild(platformBuild("linux/amd64", corev1.ConditionTrue)),
+							secondBuild(platformBuild("linux/arm64", corev1.ConditionTrue)),
+						},
+						WantErr: false,
+						WantDeletes: []clientgotesting.DeleteActionImpl{
+							{
+								ActionImpl: clientgotesting.ActionImpl{
+									Namespace: namespace,
+									Resource:  schema.GroupVersionResource{Resource: "builds"},
+								},
+								Name: imageName + "-build-1-linux-amd64",
+							},
+							{
+								ActionImpl: clientgotesting.ActionImpl{
+									Namespace: namespace,
+									Resource:  schema.GroupVersionResource{Resource: "builds"},
+								},
+								Name: imageName + "-build-1-linux-arm64",
+							},
+						},
+					})
+				})
+
+				it("does not push an image index while a platform build is running", func() {
+					imageWithBuilder.Status.BuildCounter = 1
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-1-linux-amd64"
+					runningBuild := platformBuild("linux/arm64", corev1.ConditionUnknown)
+					runningBuild.Status.Conditions[0].Message = "Some build message"
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+							platformBuild("linux/amd64", corev1.ConditionTrue),
+							runningBuild,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: imageWithBuilder.ObjectMeta,
+									Spec:       imageWithBuilder.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:    corev1alpha1.ConditionReady,
+													Status:  corev1.ConditionUnknown,
+													Reason:  image.BuildRunningReason,
+													Message: "Some build message",
+												},
+												{
+													Type:   buildapi.ConditionBuilderReady,
+													Status: corev1.ConditionTrue,
+													Reason: buildapi.BuilderReady,
+												},
+												{
+													Type:   buildapi.ConditionBuilderUpToDate,
+													Status: corev1.ConditionTrue,
+													Reason: buildapi.BuilderUpToDate,
+												},
+											},
+										},
+										LatestBuildRef: "image-name-build-1-linux-amd64",
+										BuildCounter:   1,
+									},
+								},
+							},
+						},
+					})
+
+					assert.Equal(t, 0, fakeIndexWriter.WriteCallCount())
+				})
+			})
+
 			when("reconciling old builds", func() {
 				it("deletes a failed build if more than the limit", func() {
 					imageWithBuilder.Spec.FailedBuildHistoryLimit = limit(4)
@@ -2788,6 +4871,58 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 
+				it("deletes a canceled build if more than the failed build limit", func() {
+					imageWithBuilder.Spec.FailedBuildHistoryLimit = limit(4)
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-5"
+					imageWithBuilder.Status.Conditions = conditionCanceled(imageWithBuilder)
+					imageWithBuilder.Status.BuildCounter = 5
+					sourceResolver := resolvedSourceResolver(imageWithBuilder)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: runtimeObjects(
+							canceledBuilds(imageWithBuilder, sourceResolver, 5),
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+						),
+						WantErr: false,
+						WantDeletes: []clientgotesting.DeleteActionImpl{
+							{
+								ActionImpl: clientgotesting.ActionImpl{
+									Namespace: "some-namespace",
+									Resource: schema.GroupVersionResource{
+										Resource: "builds",
+									},
+								},
+								Name: imageWithBuilder.Name + "-build-1",
+							},
+						},
+					})
+				})
+
+				it("does not count canceled builds as failed builds", func() {
+					imageWithBuilder.Spec.FailedBuildHistoryLimit = limit(4)
+					imageWithBuilder.Status.LatestBuildRef = "image-name-build-5"
+					imageWithBuilder.Status.Conditions = conditionNotReady(imageWithBuilder)
+					imageWithBuilder.Status.BuildCounter = 5
+					sourceResolver := resolvedSourceResolver(imageWithBuilder)
+
+					canceled := canceledBuilds(imageWithBuilder, sourceResolver, 2)
+					failed := failedBuilds(imageWithBuilder, sourceResolver, 5)[2:]
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: runtimeObjects(
+							append(canceled, failed...),
+							imageWithBuilder,
+							builder,
+							sourceResolver,
+						),
+						WantErr: false,
+					})
+				})
+
 				it("deletes a successful build if more than the limit", func() {
 					imageWithBuilder.Spec.SuccessBuildHistoryLimit = limit(4)
 					imageWithBuilder.Status.LatestBuildRef = "image-name-build-5"
@@ -2886,6 +5021,14 @@ func failedBuilds(image *buildapi.Image, sourceResolver *buildapi.SourceResolver
 	})
 }
 
+func canceledBuilds(image *buildapi.Image, sourceResolver *buildapi.SourceResolver, count int) []runtime.Object {
+	return builds(image, sourceResolver, count, corev1alpha1.Condition{
+		Type:   corev1alpha1.ConditionSucceeded,
+		Status: corev1.ConditionFalse,
+		Reason: buildapi.BuildCanceledReason,
+	})
+}
+
 func successfulBuilds(image *buildapi.Image, sourceResolver *buildapi.SourceResolver, count int) []runtime.Object {
 	return builds(image, sourceResolver, count, corev1alpha1.Condition{
 		Type:   corev1alpha1.ConditionSucceeded,
@@ -3013,6 +5156,27 @@ func conditionReady() corev1alpha1.Conditions {
 	}
 }
 
+func conditionCanceled(canceledBuild *buildapi.Image) corev1alpha1.Conditions {
+	return corev1alpha1.Conditions{
+		{
+			Type:    corev1alpha1.ConditionReady,
+			Status:  corev1.ConditionUnknown,
+			Reason:  image.BuildCanceledReason,
+			Message: fmt.Sprintf("Build '%s' in namespace '%s' was canceled", canceledBuild.Status.LatestBuildRef, canceledBuild.Namespace),
+		},
+		{
+			Type:   buildapi.ConditionBuilderReady,
+			Status: corev1.ConditionTrue,
+			Reason: buildapi.BuilderReady,
+		},
+		{
+			Type:   buildapi.ConditionBuilderUpToDate,
+			Status: corev1.ConditionTrue,
+			Reason: buildapi.BuilderUpToDate,
+		},
+	}
+}
+
 func conditionNotReady(failedBuild *buildapi.Image) corev1alpha1.Conditions {
 	return corev1alpha1.Conditions{
 		{
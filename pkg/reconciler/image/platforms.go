@@ -0,0 +1,104 @@
+package image
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+//go:generate counterfeiter . IndexWriter
+type IndexWriter interface {
+	Write(keychain authn.Keychain, tags []string, images []buildapi.PlatformImage) (string, error)
+}
+
+// reconcileIndex pushes the image index of the platform images once every platform build of the last build number
+// succeeded. The index is only pushed again when the platform images change.
+func (c *Reconciler) reconcileIndex(ctx context.Context, image *buildapi.Image, lastBuilds []*buildapi.Build) error {
+	platformImages := latestPlatformImages(lastBuilds)
+	if platformImages == nil || equality.Semantic.DeepEqual(platformImages, image.Status.LatestPlatformImages) {
+		return nil
+	}
+
+	buildNumber, err := buildCounter(lastBuilds[0])
+	if err != nil {
+		return errors.Wrap(err, "error parsing the image build number")
+	}
+
+	keychain, err := c.KeychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{
+		ServiceAccount: image.Spec.ServiceAccountName,
+		Namespace:      image.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	latestImage, err := c.IndexWriter.Write(keychain, image.IndexTags(buildNumber), platformImages)
+	if err != nil {
+		return errors.Wrap(err, "error pushing the image index")
+	}
+
+	image.Status.LatestImage = latestImage
+	image.Status.LatestPlatformImages = platformImages
+	return nil
+}
+
+// latestPlatformImages returns the images of the platform builds if every platform build succeeded, otherwise nil
+func latestPlatformImages(builds []*buildapi.Build) []buildapi.PlatformImage {
+	if len(builds) == 0 || builds[0].Platform() == "" {
+		return nil
+	}
+
+	platforms := builds[0].Platforms()
+	images := make([]buildapi.PlatformImage, 0, len(platforms))
+	for _, platform := range platforms {
+		build := platformBuild(builds, platform)
+		if !build.IsSuccess() {
+			return nil
+		}
+		images = append(images, buildapi.PlatformImage{Platform: platform, Image: build.BuiltImage()})
+	}
+	return images
+}
+
+// newBuilds returns the build of the image, or a build for every platform if the image has platforms
+func newBuilds(image *buildapi.Image, sourceResolver *buildapi.SourceResolver, builder buildapi.BuilderResource, latestBuild *buildapi.Build, lastBuilds []*buildapi.Build, reasons, changes string, nextBuildNumber int64, priorityClass string) []*buildapi.Build {
+	if len(image.Spec.Platforms) == 0 {
+		return []*buildapi.Build{image.Build(sourceResolver, builder, latestBuild, reasons, changes, nextBuildNumber, priorityClass)}
+	}
+
+	builds := make([]*buildapi.Build, 0, len(image.Spec.Platforms))
+	for _, platform := range image.Spec.Platforms {
+		builds = append(builds, image.PlatformBuild(platform, sourceResolver, builder, platformBuild(lastBuilds, platform), reasons, changes, nextBuildNumber, priorityClass))
+	}
+	return builds
+}
+
+// unsupportedPlatforms returns the platforms of the image the builder has no platform image for
+func unsupportedPlatforms(image *buildapi.Image, builder buildapi.BuilderResource) []string {
+	supported := map[string]bool{}
+	for _, platform := range builder.Platforms() {
+		supported[platform] = true
+	}
+
+	var missing []string
+	for _, platform := range image.Spec.Platforms {
+		if !supported[platform] {
+			missing = append(missing, platform)
+		}
+	}
+	return missing
+}
+
+func platformBuild(builds []*buildapi.Build, platform string) *buildapi.Build {
+	for _, build := range builds {
+		if build.Platform() == platform {
+			return build
+		}
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -14,21 +16,47 @@ import (
 )
 
 const (
-	BuildRunningReason     = "BuildRunning"
-	ResolverNotReadyReason = "ResolverNotReady"
-	UnknownStateReason     = "UnknownState"
-	BuildFailedReason      = "BuildFailed"
-	UpToDateReason         = "UpToDate"
-	NotUpToDateMessage     = "Builder is not up to date. The latest stack and buildpacks may not be in use."
+	BuildRunningReason      = "BuildRunning"
+	ResolverNotReadyReason  = "ResolverNotReady"
+	UnknownStateReason      = "UnknownState"
+	BuildFailedReason       = "BuildFailed"
+	UpToDateReason          = "UpToDate"
+	BuildWindowClosedReason = "BuildWindowClosed"
+	BuildNotAllowedReason   = "BuildNotAllowed"
+	BuildCanceledReason     = "BuildCanceled"
+	PlatformsNotSupported   = "PlatformsNotSupported"
+	NotUpToDateMessage      = "Builder is not up to date. The latest stack and buildpacks may not be in use."
 )
 
-func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image, latestBuild *buildapi.Build, sourceResolver *buildapi.SourceResolver, builder buildapi.BuilderResource, buildCacheName string) (buildapi.ImageStatus, error) {
+func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image, latestBuild *buildapi.Build, lastBuilds []*buildapi.Build, sourceResolver *buildapi.SourceResolver, builder buildapi.BuilderResource, buildCacheName string) (buildapi.ImageStatus, error) {
 	currentBuildNumber, err := buildCounter(latestBuild)
 	if err != nil {
 		return buildapi.ImageStatus{}, errors.Wrap(err, "error parsing the image build number")
 	}
 
-	result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+	scheduledBuild, err := c.dueScheduledBuild(image)
+	if err != nil {
+		return buildapi.ImageStatus{}, err
+	}
+
+	upstreamImages, err := c.upstreamImages(image)
+	if err != nil {
+		return buildapi.ImageStatus{}, err
+	}
+
+	envSources, err := c.envSources(image)
+	if err != nil {
+		return buildapi.ImageStatus{}, err
+	}
+
+	retryAttempt := c.retryAttempt(image, latestBuild)
+
+	request, err := pendingBuildRequest(image)
+	if err != nil {
+		return buildapi.ImageStatus{}, err
+	}
+
+	result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, scheduledBuild, upstreamImages, envSources, retryAttempt, request)
 	if err != nil {
 		return buildapi.ImageStatus{}, errors.Wrap(err, "error determining if an image build is needed")
 	}
@@ -38,11 +66,75 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 	}
 	switch result.ConditionStatus {
 	case corev1.ConditionTrue:
-		nextBuildNumber := currentBuildNumber + 1
-		build := image.Build(sourceResolver, builder, latestBuild, result.ReasonsStr, result.ChangesStr, nextBuildNumber, priorityClass)
-		build, err = c.Client.KpackV1alpha2().Builds(build.Namespace).Create(ctx, build, metav1.CreateOptions{})
+		result, retryAttempt, err = retriedBuildResult(result, latestBuild, retryAttempt)
 		if err != nil {
-			return buildapi.ImageStatus{}, errors.WithMessage(err, fmt.Sprintf("error creating build '%s' in namespace '%s'", build.Name, build.Namespace))
+			return buildapi.ImageStatus{}, err
+		}
+
+		deferredUntil, err := c.buildDeferredUntil(image, latestBuild, result.ReasonsStr)
+		if err != nil {
+			return buildapi.ImageStatus{}, err
+		}
+		if deferredUntil != nil {
+			c.Enqueuer.EnqueueAfter(image, deferredUntil.Sub(c.Clock.Now()))
+
+			status := currentBuildStatus(image, corev1.ConditionFalse, latestBuild, sourceResolver, builder, buildCacheName, currentBuildNumber)
+			status.Conditions = append(status.Conditions, buildPendingCondition(result.ReasonsStr, *deferredUntil))
+			status.PendingBuild = &buildapi.PendingBuild{
+				Reason:        result.ReasonsStr,
+				Changes:       result.ChangesStr,
+				DeferredUntil: &metav1.Time{Time: *deferredUntil},
+			}
+			return status, nil
+		}
+
+		if missing := unsupportedPlatforms(image, builder); len(missing) > 0 {
+			status := currentBuildStatus(image, corev1.ConditionFalse, latestBuild, sourceResolver, builder, buildCacheName, currentBuildNumber)
+			status.Conditions = append(status.Conditions, platformsNotSupportedCondition(result.ReasonsStr, missing))
+			status.PendingBuild = &buildapi.PendingBuild{
+				Reason:  result.ReasonsStr,
+				Changes: result.ChangesStr,
+			}
+			return status, nil
+		}
+
+		nextBuildNumber := currentBuildNumber + 1
+		// the image status refers to the first build when a build is created for every platform
+		var build *buildapi.Build
+		lastBuildRequestID := image.Status.LastBuildRequestID
+		for _, newBuild := range newBuilds(image, sourceResolver, builder, latestBuild, lastBuilds, result.ReasonsStr, result.ChangesStr, nextBuildNumber, priorityClass) {
+			if err := setUpstreamImages(newBuild, upstreamImages); err != nil {
+				return buildapi.ImageStatus{}, err
+			}
+			newBuild.Spec.EnvSources = envSources
+			// images outside the canary set keep building with the previous builder image until the rollout is promoted
+			if builder.RolloutPending(image) && latestBuild != nil {
+				newBuild.Spec.Builder.Image = latestBuild.Spec.Builder.Image
+				newBuild.Spec.RunImage = latestBuild.Spec.RunImage
+			}
+			if retryAttempt > 0 {
+				newBuild.Annotations[buildapi.BuildAttemptAnnotation] = strconv.FormatInt(retryAttempt, 10)
+			}
+			// the build request annotation is copied from the image and only kept on the build created for the request
+			if request != nil {
+				request.Apply(newBuild)
+				lastBuildRequestID = request.ID
+			} else {
+				delete(newBuild.Annotations, buildapi.BuildRequestAnnotation)
+			}
+			created, err := c.Client.KpackV1alpha2().Builds(newBuild.Namespace).Create(ctx, newBuild, metav1.CreateOptions{})
+			if err != nil {
+				return buildapi.ImageStatus{}, errors.WithMessage(err, fmt.Sprintf("error creating build '%s' in namespace '%s'", newBuild.Name, newBuild.Namespace))
+			}
+			if build == nil {
+				build = created
+			}
+		}
+
+		// any build satisfies a due schedule
+		lastScheduledBuildTime := image.Status.LastScheduledBuildTime
+		if scheduledBuild != nil {
+			lastScheduledBuildTime = &metav1.Time{Time: c.Clock.Now()}
 		}
 
 		return buildapi.ImageStatus{
@@ -56,27 +148,45 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 			LatestImage:                image.LatestForImage(latestBuild),
 			LatestStack:                build.Stack(),
 			LatestBuildImageGeneration: build.ImageGeneration(),
+			LastScheduledBuildTime:     lastScheduledBuildTime,
+			LastBuildRequestID:         lastBuildRequestID,
+			LatestPlatformImages:       image.Status.LatestPlatformImages,
 		}, nil
 	case corev1.ConditionUnknown:
 		fallthrough
 	case corev1.ConditionFalse:
-		return buildapi.ImageStatus{
-			Status: corev1alpha1.Status{
-				Conditions: noScheduledBuild(result.ConditionStatus, builder, latestBuild, sourceResolver),
-			},
-			LatestBuildRef:             latestBuild.BuildRef(),
-			LatestBuildReason:          latestBuild.BuildReason(),
-			LatestBuildImageGeneration: latestBuild.ImageGeneration(),
-			LatestImage:                image.LatestForImage(latestBuild),
-			LatestStack:                latestBuild.Stack(),
-			BuildCounter:               currentBuildNumber,
-			BuildCacheName:             buildCacheName,
-		}, nil
+		status := currentBuildStatus(image, result.ConditionStatus, latestBuild, sourceResolver, builder, buildCacheName, currentBuildNumber)
+		if result.Suppressed {
+			status.Conditions = append(status.Conditions, buildNotAllowedCondition(result.ReasonsStr))
+			status.PendingBuild = &buildapi.PendingBuild{
+				Reason:  result.ReasonsStr,
+				Changes: result.ChangesStr,
+			}
+		}
+		return status, nil
 	default:
 		return buildapi.ImageStatus{}, errors.Errorf("Error: unexpected build needed condition %s", result.ConditionStatus)
 	}
 }
 
+func currentBuildStatus(image *buildapi.Image, buildNeeded corev1.ConditionStatus, latestBuild *buildapi.Build, sourceResolver *buildapi.SourceResolver, builder buildapi.BuilderResource, buildCacheName string, currentBuildNumber int64) buildapi.ImageStatus {
+	return buildapi.ImageStatus{
+		Status: corev1alpha1.Status{
+			Conditions: noScheduledBuild(buildNeeded, builder, latestBuild, sourceResolver),
+		},
+		LatestBuildRef:             latestBuild.BuildRef(),
+		LatestBuildReason:          latestBuild.BuildReason(),
+		LatestBuildImageGeneration: latestBuild.ImageGeneration(),
+		LatestImage:                image.LatestForImage(latestBuild),
+		LatestStack:                latestBuild.Stack(),
+		BuildCounter:               currentBuildNumber,
+		BuildCacheName:             buildCacheName,
+		LastScheduledBuildTime:     image.Status.LastScheduledBuildTime,
+		LastBuildRequestID:         image.Status.LastBuildRequestID,
+		LatestPlatformImages:       image.Status.LatestPlatformImages,
+	}
+}
+
 func noScheduledBuild(buildNeeded corev1.ConditionStatus, builder buildapi.BuilderResource, build *buildapi.Build, sourceResolver *buildapi.SourceResolver) corev1alpha1.Conditions {
 	ready := corev1alpha1.Condition{
 		Type:               corev1alpha1.ConditionReady,
@@ -97,13 +207,22 @@ func noScheduledBuild(buildNeeded corev1.ConditionStatus, builder buildapi.Build
 		ready.Status = corev1.ConditionUnknown
 		ready.Reason = UnknownStateReason
 		ready.Message = "Error: Build status unknown"
+	case build == nil:
+		ready.Status = corev1.ConditionUnknown
+		ready.Reason = UnknownStateReason
+		ready.Message = "Image has not been built"
 	case build.Status.GetCondition(corev1alpha1.ConditionSucceeded).IsTrue():
 		ready.Status = corev1.ConditionTrue
 		ready.Reason = UpToDateReason
 		ready.Message = defaultMessageIfNil(build.Status.GetCondition(corev1alpha1.ConditionSucceeded), "Last build succeeded")
+	case build.IsCanceled():
+		// a canceled build did not fail, the image is not ready until it is built again
+		ready.Status = corev1.ConditionUnknown
+		ready.Reason = BuildCanceledReason
+		ready.Message = fmt.Sprintf("Build '%s' in namespace '%s' was canceled", build.Name, build.Namespace)
 	default:
 		ready.Status = unknownStatusIfNil(build.Status.GetCondition(corev1alpha1.ConditionSucceeded))
-		ready.Reason = BuildFailedReason
+		ready.Reason = buildFailedReason(build)
 		ready.Message = fmt.Sprintf("Error: Build '%s' in namespace '%s' failed: %s", build.Name, build.Namespace, defaultMessageIfNil(build.Status.GetCondition(corev1alpha1.ConditionSucceeded), "unknown error"))
 	}
 
@@ -111,6 +230,14 @@ func noScheduledBuild(buildNeeded corev1.ConditionStatus, builder buildapi.Build
 
 }
 
+// Surfaces failures the user must act on, such as an untrusted source, instead of the generic build failure
+func buildFailedReason(build *buildapi.Build) string {
+	if condition := build.Status.GetCondition(corev1alpha1.ConditionSucceeded); condition != nil && condition.Reason == buildapi.SourceVerificationFailedReason {
+		return buildapi.SourceVerificationFailedReason
+	}
+	return BuildFailedReason
+}
+
 func unknownStatusIfNil(condition *corev1alpha1.Condition) corev1.ConditionStatus {
 	if condition == nil {
 		return corev1.ConditionUnknown
@@ -193,6 +320,36 @@ func scheduledBuildCondition(build *buildapi.Build, builder buildapi.BuilderReso
 	}
 }
 
+func buildPendingCondition(reasons string, deferredUntil time.Time) corev1alpha1.Condition {
+	return corev1alpha1.Condition{
+		Type:               buildapi.ConditionBuildPending,
+		Status:             corev1.ConditionTrue,
+		Reason:             BuildWindowClosedReason,
+		Message:            fmt.Sprintf("Build for %s is deferred until the build window opens at %s", reasons, deferredUntil.Format(time.RFC3339)),
+		LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+	}
+}
+
+func buildNotAllowedCondition(reasons string) corev1alpha1.Condition {
+	return corev1alpha1.Condition{
+		Type:               buildapi.ConditionBuildPending,
+		Status:             corev1.ConditionTrue,
+		Reason:             BuildNotAllowedReason,
+		Message:            fmt.Sprintf("Build for %s is not allowed automatically and is pending until a build is triggered", reasons),
+		LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+	}
+}
+
+func platformsNotSupportedCondition(reasons string, platforms []string) corev1alpha1.Condition {
+	return corev1alpha1.Condition{
+		Type:               buildapi.ConditionBuildPending,
+		Status:             corev1.ConditionTrue,
+		Reason:             PlatformsNotSupported,
+		Message:            fmt.Sprintf("Build for %s is pending until the builder supports the platforms %s", reasons, strings.Join(platforms, ", ")),
+		LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+	}
+}
+
 func buildCounter(build *buildapi.Build) (int64, error) {
 	if build == nil {
 		return 0, nil
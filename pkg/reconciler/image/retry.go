@@ -0,0 +1,81 @@
+package image
+
+import (
+	"encoding/json"
+	"strings"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// retryAttempt returns the attempt number of the build that retries the failed last build, or 0 if the last build
+// is not retried. Images are requeued until the backoff of the failed attempt has elapsed.
+func (c *Reconciler) retryAttempt(image *buildapi.Image, lastBuild *buildapi.Build) int64 {
+	if image.Spec.Build == nil || image.Spec.Build.RetryPolicy == nil || lastBuild == nil || !lastBuild.RetryableFailure() {
+		return 0
+	}
+
+	// the overrides of a build request are not applied to later builds
+	if _, requested := lastBuild.Annotations[buildapi.BuildRequestAnnotation]; requested {
+		return 0
+	}
+
+	policy := image.Spec.Build.RetryPolicy
+	attempt := lastBuild.Attempt()
+	if attempt >= policy.MaxAttempts {
+		return 0
+	}
+
+	retryAt := lastBuild.FinishedTime().Add(policy.RetryDelay(attempt))
+	if delay := retryAt.Sub(c.Clock.Now()); delay > 0 {
+		c.Enqueuer.EnqueueAfter(image, delay)
+		return 0
+	}
+	return attempt + 1
+}
+
+// retriedBuildResult keeps the reasons and changes of the failed last build on a build that only retries it. A build
+// for any other change starts again at the first attempt, so the returned attempt is 0.
+func retriedBuildResult(result buildRequiredResult, lastBuild *buildapi.Build, retryAttempt int64) (buildRequiredResult, int64, error) {
+	if retryAttempt == 0 || result.ReasonsStr != buildapi.BuildReasonRetry {
+		return result, 0, nil
+	}
+
+	var changes []json.RawMessage
+	if err := json.Unmarshal([]byte(result.ChangesStr), &changes); err != nil {
+		return result, 0, err
+	}
+
+	reasons := []string{buildapi.BuildReasonRetry}
+	for _, reason := range strings.Split(lastBuild.BuildReason(), ",") {
+		if reason != "" && reason != buildapi.BuildReasonRetry {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	if lastBuild.BuildChanges() != "" {
+		var lastChanges []json.RawMessage
+		if err := json.Unmarshal([]byte(lastBuild.BuildChanges()), &lastChanges); err != nil {
+			return result, 0, err
+		}
+		for _, change := range lastChanges {
+			var c struct {
+				Reason string `json:"reason"`
+			}
+			if err := json.Unmarshal(change, &c); err != nil {
+				return result, 0, err
+			}
+			if c.Reason != buildapi.BuildReasonRetry {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	changesStr, err := json.Marshal(changes)
+	if err != nil {
+		return result, 0, err
+	}
+
+	result.ReasonsStr = strings.Join(reasons, ",")
+	result.ChangesStr = string(changesStr)
+	return result, retryAttempt, nil
+}
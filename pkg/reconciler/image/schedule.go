@@ -0,0 +1,55 @@
+package image
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// dueScheduledBuild returns the time the image schedule last fired if no build has been created for it since
+func (c *Reconciler) dueScheduledBuild(image *buildapi.Image) (*time.Time, error) {
+	next, err := nextScheduledBuild(image)
+	if err != nil || next == nil {
+		return nil, err
+	}
+
+	if next.After(c.Clock.Now()) {
+		return nil, nil
+	}
+	return next, nil
+}
+
+// enqueueScheduledBuild requeues the image when its schedule next fires
+func (c *Reconciler) enqueueScheduledBuild(image *buildapi.Image) error {
+	next, err := nextScheduledBuild(image)
+	if err != nil || next == nil {
+		return err
+	}
+
+	// due builds are retried when the image is reconciled after the running build or the builder changes
+	if delay := next.Sub(c.Clock.Now()); delay > 0 {
+		c.Enqueuer.EnqueueAfter(image, delay)
+	}
+	return nil
+}
+
+func nextScheduledBuild(image *buildapi.Image) (*time.Time, error) {
+	if image.Spec.Schedule == "" {
+		return nil, nil
+	}
+
+	schedule, err := image.Spec.ParseSchedule()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule '%s'", image.Spec.Schedule)
+	}
+
+	lastScheduled := image.CreationTimestamp.Time
+	if image.Status.LastScheduledBuildTime != nil {
+		lastScheduled = image.Status.LastScheduledBuildTime.Time
+	}
+
+	next := schedule.Next(lastScheduled.UTC())
+	return &next, nil
+}
@@ -0,0 +1,79 @@
+package image
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// upstreamImages returns the latest image of each upstream Image by name. Upstream Images that do not exist or
+// have not been built yet have an empty latest image.
+func (c *Reconciler) upstreamImages(image *buildapi.Image) (map[string]string, error) {
+	if len(image.Spec.UpstreamImages) == 0 {
+		return nil, nil
+	}
+
+	upstreamImages := make(map[string]string, len(image.Spec.UpstreamImages))
+	for _, ref := range image.Spec.UpstreamImages {
+		upstream, err := c.ImageLister.Images(image.Namespace).Get(ref.Name)
+		if k8serrors.IsNotFound(err) {
+			upstreamImages[ref.Name] = ""
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		upstreamImages[ref.Name] = upstream.Status.LatestImage
+	}
+	return upstreamImages, nil
+}
+
+// downstreamImages returns the Images in the namespace of the upstream Image that are built from it
+func (c *Reconciler) downstreamImages(upstream *buildapi.Image) ([]*buildapi.Image, error) {
+	images, err := c.ImageLister.Images(upstream.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var downstreamImages []*buildapi.Image
+	for _, image := range images {
+		for _, ref := range image.Spec.UpstreamImages {
+			if ref.Name == upstream.Name {
+				downstreamImages = append(downstreamImages, image)
+				break
+			}
+		}
+	}
+	return downstreamImages, nil
+}
+
+// setUpstreamImages records the upstream latest images a build is created from on the build
+func setUpstreamImages(build *buildapi.Build, upstreamImages map[string]string) error {
+	if len(upstreamImages) == 0 {
+		return nil
+	}
+
+	upstreamImagesJson, err := json.Marshal(upstreamImages)
+	if err != nil {
+		return err
+	}
+	build.Annotations[buildapi.UpstreamImagesAnnotation] = string(upstreamImagesJson)
+	return nil
+}
+
+// buildUpstreamImages returns the upstream latest images recorded on the build, or nil if none were recorded
+func buildUpstreamImages(build *buildapi.Build) (map[string]string, error) {
+	upstreamImagesJson, ok := build.Annotations[buildapi.UpstreamImagesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var upstreamImages map[string]string
+	if err := json.Unmarshal([]byte(upstreamImagesJson), &upstreamImages); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation on build '%s'", buildapi.UpstreamImagesAnnotation, build.Name)
+	}
+	return upstreamImages, nil
+}
@@ -12,6 +12,6 @@ type workQueueEnqueuer struct {
 }
 
 func (e *workQueueEnqueuer) Enqueue(sr *buildapi.SourceResolver) error {
-	e.enqueueAfter(sr, 1*time.Minute)
+	e.enqueueAfter(sr, e.delay)
 	return nil
 }
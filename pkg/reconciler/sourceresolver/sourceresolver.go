@@ -38,12 +38,14 @@ func NewController(
 	gitResolver Resolver,
 	blobResolver Resolver,
 	registryResolver Resolver,
+	volumeResolver Resolver,
 	featureflags config.FeatureFlags,
 ) *controller.Impl {
 	c := &Reconciler{
 		GitResolver:          gitResolver,
 		BlobResolver:         blobResolver,
 		RegistryResolver:     registryResolver,
+		VolumeResolver:       volumeResolver,
 		Client:               opt.Client,
 		SourceResolverLister: sourceResolverInformer.Lister(),
 		FeatureFlags:         featureflags,
@@ -77,6 +79,7 @@ type Reconciler struct {
 	GitResolver          Resolver
 	BlobResolver         Resolver
 	RegistryResolver     Resolver
+	VolumeResolver       Resolver
 	Enqueuer             Enqueuer
 	Client               versioned.Interface
 	SourceResolverLister buildlisters.SourceResolverLister
@@ -128,6 +131,8 @@ func (c *Reconciler) sourceReconciler(sourceResolver *buildapi.SourceResolver) (
 		return c.BlobResolver, nil
 	} else if c.RegistryResolver.CanResolve(sourceResolver) {
 		return c.RegistryResolver, nil
+	} else if c.VolumeResolver.CanResolve(sourceResolver) {
+		return c.VolumeResolver, nil
 	}
 	return nil, errors.New("invalid source type")
 }
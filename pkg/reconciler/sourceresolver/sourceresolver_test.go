@@ -39,6 +39,7 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 	fakeGitResolver := &sourceresolverfakes.FakeResolver{}
 	fakeBlobResolver := &sourceresolverfakes.FakeResolver{}
 	fakeRegistryResolver := &sourceresolverfakes.FakeResolver{}
+	fakeVolumeResolver := &sourceresolverfakes.FakeResolver{}
 	fakeEnqueuer := &sourceresolverfakes.FakeEnqueuer{}
 
 	rt := testhelpers.ReconcilerTester(t,
@@ -56,6 +57,7 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 				GitResolver:          fakeGitResolver,
 				BlobResolver:         fakeBlobResolver,
 				RegistryResolver:     fakeRegistryResolver,
+				VolumeResolver:       fakeVolumeResolver,
 				Enqueuer:             fakeEnqueuer,
 				Client:               fakeClient,
 				SourceResolverLister: listers.GetSourceResolverLister(),
@@ -316,6 +318,102 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("a revision selector does not match a tag", func() {
+				selectorSourceResolver := sourceResolver.DeepCopy()
+				selectorSourceResolver.Spec.Source.Git.Revision = "v2.*"
+
+				noMatchingTag := corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:  "https://example.com/something",
+						Type: corev1alpha1.Tag,
+					},
+				}
+
+				fakeGitResolver.CanResolveReturns(true)
+
+				it("keeps polling without becoming ready until a tag matches on a later poll", func() {
+					fakeGitResolver.ResolveReturns(noMatchingTag, nil)
+
+					pollingSourceResolver := selectorSourceResolver.DeepCopy()
+					pollingSourceResolver.Status = buildapi.SourceResolverStatus{
+						Status: corev1alpha1.Status{
+							ObservedGeneration: originalGeneration,
+							Conditions: corev1alpha1.Conditions{
+								{
+									Type:    corev1alpha1.ConditionReady,
+									Status:  corev1.ConditionUnknown,
+									Reason:  buildapi.NoMatchingRevisionReason,
+									Message: `no tag matches revision "v2.*"`,
+								},
+								{
+									Type:   buildapi.ActivePolling,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+						Source: noMatchingTag,
+					}
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							selectorSourceResolver,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: pollingSourceResolver,
+							},
+						},
+					})
+					require.Equal(t, 1, fakeEnqueuer.EnqueueCallCount())
+					require.False(t, pollingSourceResolver.Ready())
+
+					matchingTag := corev1alpha1.ResolvedSourceConfig{
+						Git: &corev1alpha1.ResolvedGitSource{
+							URL:      "https://example.com/something",
+							Revision: "abcdef",
+							Tag:      "v2.0.0",
+							Type:     corev1alpha1.Tag,
+						},
+					}
+					fakeGitResolver.ResolveReturns(matchingTag, nil)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							pollingSourceResolver,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.SourceResolver{
+									ObjectMeta: selectorSourceResolver.ObjectMeta,
+									Spec:       selectorSourceResolver.Spec,
+									Status: buildapi.SourceResolverStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:   corev1alpha1.ConditionReady,
+													Status: corev1.ConditionTrue,
+												},
+												{
+													Type:   buildapi.ActivePolling,
+													Status: corev1.ConditionTrue,
+												},
+											},
+										},
+										Source: matchingTag,
+									},
+								},
+							},
+						},
+					})
+					require.Equal(t, 2, fakeEnqueuer.EnqueueCallCount())
+				})
+			})
+
 			when("git resolves to unknown", func() {
 				resolvedSource := corev1alpha1.ResolvedSourceConfig{
 					Git: &corev1alpha1.ResolvedGitSource{
@@ -521,6 +619,69 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("a volume based source config", func() {
+			sourceResolver := &buildapi.SourceResolver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       sourceResolverName,
+					Namespace:  namespace,
+					Generation: originalGeneration,
+				},
+				Spec: buildapi.SourceResolverSpec{
+					ServiceAccountName: serviceAccount,
+					Source: corev1alpha1.SourceConfig{
+						Volume: &corev1alpha1.Volume{
+							ConfigMap: &corev1alpha1.ConfigMapSource{Name: "some-config-map", Key: "source.tar.gz"},
+						},
+					},
+				},
+			}
+
+			resolvedSource := corev1alpha1.ResolvedSourceConfig{
+				Volume: &corev1alpha1.ResolvedVolumeSource{
+					ConfigMap: &corev1alpha1.ConfigMapSource{Name: "some-config-map", Key: "source.tar.gz"},
+					Digest:    "sha256:some-digest",
+				},
+			}
+
+			fakeVolumeResolver.ResolveReturns(resolvedSource, nil)
+			fakeVolumeResolver.CanResolveReturns(true)
+
+			it("reconciles to ready and active polling", func() {
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						sourceResolver,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.SourceResolver{
+								ObjectMeta: sourceResolver.ObjectMeta,
+								Spec:       sourceResolver.Spec,
+								Status: buildapi.SourceResolverStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:   buildapi.ActivePolling,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									Source: resolvedSource,
+								},
+							},
+						},
+					},
+				})
+				require.Equal(t, 1, fakeEnqueuer.EnqueueCallCount())
+			})
+		})
 	})
 }
 
@@ -0,0 +1,30 @@
+package reconciler
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+type StackReader interface {
+	Read(keychain authn.Keychain, clusterStackSpec buildapi.ClusterStackSpec) (buildapi.ResolvedClusterStack, error)
+}
+
+// StackFromImages resolves the build and run images referenced by a builder into a ClusterStack that is only used to
+// create the builder, it is never stored
+func StackFromImages(reader StackReader, keychain authn.Keychain, spec buildapi.BuilderSpec) (*buildapi.ClusterStack, error) {
+	stackSpec := buildapi.ClusterStackSpec{
+		BuildImage: spec.BuildImage,
+		RunImage:   spec.RunImage,
+	}
+
+	resolved, err := reader.Read(keychain, stackSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &buildapi.ClusterStack{
+		Spec:   stackSpec,
+		Status: buildapi.ClusterStackStatus{ResolvedClusterStack: resolved},
+	}, nil
+}
@@ -75,6 +75,14 @@ func (l *Listers) GetClusterBuildpackLister() buildlisters.ClusterBuildpackListe
 	return buildlisters.NewClusterBuildpackLister(l.indexerFor(&buildapi.ClusterBuildpack{}))
 }
 
+func (l *Listers) GetExtensionLister() buildlisters.ExtensionLister {
+	return buildlisters.NewExtensionLister(l.indexerFor(&buildapi.Extension{}))
+}
+
+func (l *Listers) GetClusterExtensionLister() buildlisters.ClusterExtensionLister {
+	return buildlisters.NewClusterExtensionLister(l.indexerFor(&buildapi.ClusterExtension{}))
+}
+
 func (l *Listers) GetClusterStoreLister() buildlisters.ClusterStoreLister {
 	return buildlisters.NewClusterStoreLister(l.indexerFor(&buildapi.ClusterStore{}))
 }
@@ -103,6 +111,14 @@ func (l *Listers) GetConfigMapLister() corev1listers.ConfigMapLister {
 	return corev1listers.NewConfigMapLister(l.indexerFor(&corev1.ConfigMap{}))
 }
 
+func (l *Listers) GetSecretLister() corev1listers.SecretLister {
+	return corev1listers.NewSecretLister(l.indexerFor(&corev1.Secret{}))
+}
+
+func (l *Listers) GetNamespaceLister() corev1listers.NamespaceLister {
+	return corev1listers.NewNamespaceLister(l.indexerFor(&corev1.Namespace{}))
+}
+
 func (l *Listers) GetDuckBuilderLister() *duckbuilder.DuckBuilderLister {
 	return &duckbuilder.DuckBuilderLister{
 		BuilderLister:        l.GetBuilderLister(),
@@ -36,6 +36,22 @@ func (t *Client) Fetch(keychain authn.Keychain, repoName string) (v1.Image, stri
 	return image, identifier, nil
 }
 
+// Digest returns the digest of the manifest or index the reference points to with a HEAD request, without
+// downloading the manifest
+func (t *Client) Digest(keychain authn.Keychain, repoName string) (string, error) {
+	reference, err := name.ParseReference(repoName)
+	if err != nil {
+		return "", err
+	}
+
+	descriptor, err := remote.Head(reference, remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return "", handleError(err)
+	}
+
+	return descriptor.Digest.String(), nil
+}
+
 func (t *Client) Save(keychain authn.Keychain, tag string, image v1.Image) (string, error) {
 	ref, err := name.ParseReference(tag)
 	if err != nil {
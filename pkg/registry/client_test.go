@@ -144,6 +144,42 @@ func testClient(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("Digest", func() {
+		const digest = "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+		it("resolves the digest with a HEAD request", func() {
+			handler.HandleFunc("/v2/some/image/manifests/tag", func(writer http.ResponseWriter, request *http.Request) {
+				if request.Method != http.MethodHead {
+					t.Errorf("unexpected %s to %s", request.Method, request.URL)
+					writer.WriteHeader(500)
+					return
+				}
+				writer.Header().Set("Content-Type", "application/vnd.docker.distribution.manifest.v2+json")
+				writer.Header().Set("Docker-Content-Digest", digest)
+				writer.Header().Set("Content-Length", "0")
+				writer.WriteHeader(200)
+			})
+			handler.HandleFunc("/v2/", func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(200)
+			})
+
+			resolved, err := subject.Digest(keychain, tagName)
+			require.NoError(t, err)
+			require.Equal(t, digest, resolved)
+		})
+
+		it("wraps errors to NetworkError", func() {
+			handler.HandleFunc("/v2/", func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(http.StatusNotFound)
+			})
+
+			assertNetworkErrorOn(t, true, func() error {
+				_, err := subject.Digest(keychain, tagName)
+				return err
+			})
+		})
+	})
+
 	when("Save", func() {
 		when("no error", func() {
 			it("should save", func() {
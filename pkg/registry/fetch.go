@@ -9,6 +9,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
 
@@ -31,13 +32,30 @@ type ImageClient interface {
 	Fetch(keychain authn.Keychain, repoName string) (v1.Image, string, error)
 }
 
+// SignatureVerifier verifies an image is signed by a trusted key and returns the identity of the signer
+type SignatureVerifier interface {
+	Verify(keychain authn.Keychain, image string) (string, error)
+}
+
 type Fetcher struct {
 	Logger   *log.Logger
 	Client   ImageClient
 	Keychain authn.Keychain
+	// Verifier requires the image to be signed by a trusted key when provided
+	Verifier SignatureVerifier
 }
 
-func (f *Fetcher) Fetch(dir, registryImage string, metadataDir string) error {
+// Fetch pulls the source image into dir. When a digest is provided the image is pinned to that digest
+// so the build uses the same source that was resolved by the controller.
+func (f *Fetcher) Fetch(dir, registryImage, digest, metadataDir string) error {
+	if digest != "" {
+		pinnedImage, err := pinToDigest(registryImage, digest)
+		if err != nil {
+			return err
+		}
+		registryImage = pinnedImage
+	}
+
 	f.Logger.Printf("Pulling %s...", registryImage)
 
 	img, identifer, err := f.Client.Fetch(f.Keychain, registryImage)
@@ -46,7 +64,16 @@ func (f *Fetcher) Fetch(dir, registryImage string, metadataDir string) error {
 	}
 
 	parts := strings.SplitN(identifer, "@", 2)
-	ref, digest := parts[0], parts[1]
+	ref, imageDigest := parts[0], parts[1]
+
+	var signer string
+	if f.Verifier != nil {
+		signer, err = f.Verifier.Verify(f.Keychain, identifer)
+		if err != nil {
+			return err
+		}
+		f.Logger.Printf("Verified %s is signed by %s", identifer, signer)
+	}
 
 	cType, err := getContentType(img)
 	if err != nil {
@@ -79,10 +106,11 @@ func (f *Fetcher) Fetch(dir, registryImage string, metadataDir string) error {
 		Source: Source{
 			Type: "image",
 			Metadata: Metadata{
-				Image: ref,
+				Image:  ref,
+				Signer: signer,
 			},
 			Version: Version{
-				Digest: digest,
+				Digest: imageDigest,
 			},
 		},
 	}
@@ -95,6 +123,16 @@ func (f *Fetcher) Fetch(dir, registryImage string, metadataDir string) error {
 	return nil
 }
 
+func pinToDigest(registryImage, digest string) (string, error) {
+	ref, err := name.ParseReference(registryImage, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	pinned := ref.Context().Digest(digest)
+	return pinned.String(), nil
+}
+
 func getContentType(img v1.Image) (contentType, error) {
 	cfg, err := img.ConfigFile()
 	if err != nil {
@@ -238,7 +276,8 @@ type Source struct {
 }
 
 type Metadata struct {
-	Image string `toml:"image"`
+	Image  string `toml:"image"`
+	Signer string `toml:"signer,omitempty"`
 }
 
 type Version struct {
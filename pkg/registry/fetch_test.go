@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/random"
@@ -68,7 +69,7 @@ func testRegistrySourceFetcher(t *testing.T, when spec.G, it spec.S) {
 			repoName := fmt.Sprintf("registry.example/some-image-%d", time.Now().Second())
 			client.AddImage(repoName, img, keychain)
 
-			err = fetcher.Fetch(dir, repoName, metadataDir)
+			err = fetcher.Fetch(dir, repoName, "", metadataDir)
 			require.NoError(t, err)
 
 			files, err := os.ReadDir(dir)
@@ -104,7 +105,7 @@ func testRegistrySourceFetcher(t *testing.T, when spec.G, it spec.S) {
 		repoName := fmt.Sprintf("registry.example/some-image-%d", time.Now().Second())
 		client.AddImage(repoName, img, keychain)
 
-		err = fetcher.Fetch(dir, repoName, metadataDir)
+		err = fetcher.Fetch(dir, repoName, "", metadataDir)
 		require.NoError(t, err)
 
 		files, err := os.ReadDir(dir)
@@ -131,7 +132,7 @@ func testRegistrySourceFetcher(t *testing.T, when spec.G, it spec.S) {
 		repoName := "registry.example/test-exe"
 		client.AddImage(repoName, img, keychain)
 
-		err = fetcher.Fetch(dir, repoName, metadataDir)
+		err = fetcher.Fetch(dir, repoName, "", metadataDir)
 		require.NoError(t, err)
 
 		// the vendor/cache directory doesnt have proper headers
@@ -150,7 +151,7 @@ func testRegistrySourceFetcher(t *testing.T, when spec.G, it spec.S) {
 		repoName := "registry.example/test-exe"
 		client.AddImage(repoName, img, keychain)
 
-		err = fetcher.Fetch(dir, repoName, metadataDir)
+		err = fetcher.Fetch(dir, repoName, "", metadataDir)
 		require.NoError(t, err)
 
 		files, err := os.ReadDir(dir)
@@ -183,7 +184,7 @@ func testRegistrySourceFetcher(t *testing.T, when spec.G, it spec.S) {
 		repoName := "registry.example/some-image"
 		client.AddImage(repoName, img, keychain)
 
-		err = fetcher.Fetch(dir, repoName, metadataDir)
+		err = fetcher.Fetch(dir, repoName, "", metadataDir)
 		require.NoError(t, err)
 
 		p := path.Join(metadataDir, "project-metadata.toml")
@@ -200,11 +201,66 @@ func testRegistrySourceFetcher(t *testing.T, when spec.G, it spec.S) {
 		require.Equal(t, expectedFile, string(contents))
 	})
 
+	it("pulls the pinned digest when provided", func() {
+		buf, err := os.ReadFile(filepath.Join("testdata", "reg.tar"))
+		require.NoError(t, err)
+
+		img := createSourceImage(t, buf, "")
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		client.AddImage("registry.example/some-image@"+digest.String(), img, keychain)
+
+		err = fetcher.Fetch(dir, "registry.example/some-image:latest", digest.String(), metadataDir)
+		require.NoError(t, err)
+
+		require.Contains(t, output.String(), "Pulling registry.example/some-image@"+digest.String())
+
+		_, err = os.ReadFile(filepath.Join(dir, "test.txt"))
+		require.NoError(t, err)
+	})
+
+	when("a verifier is provided", func() {
+		var verifier *fakeVerifier
+
+		it.Before(func() {
+			verifier = &fakeVerifier{signer: "some-signer"}
+			fetcher.Verifier = verifier
+
+			buf, err := os.ReadFile(filepath.Join("testdata", "reg.tar"))
+			require.NoError(t, err)
+			client.AddImage("registry.example/signed-image", createSourceImage(t, buf, ""), keychain)
+		})
+
+		it("records the signer in project-metadata.toml", func() {
+			err := fetcher.Fetch(dir, "registry.example/signed-image", "", metadataDir)
+			require.NoError(t, err)
+
+			require.Len(t, verifier.images, 1)
+			require.Regexp(t, "^registry.example/signed-image@sha256:", verifier.images[0])
+
+			contents, err := os.ReadFile(path.Join(metadataDir, "project-metadata.toml"))
+			require.NoError(t, err)
+			require.Contains(t, string(contents), `signer = "some-signer"`)
+		})
+
+		it("does not extract unverified images", func() {
+			verifier.err = errors.New("some verification error")
+
+			err := fetcher.Fetch(dir, "registry.example/signed-image", "", metadataDir)
+			require.EqualError(t, err, "some verification error")
+
+			files, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			require.Empty(t, files)
+		})
+	})
+
 	it("errors when the registry is inaccessible", func() {
 		registryError := errors.New("some registry error")
 		client.SetFetchError(registryError)
 
-		err := fetcher.Fetch(dir, "registry.example/error", metadataDir)
+		err := fetcher.Fetch(dir, "registry.example/error", "", metadataDir)
 		require.Equal(t, err, registryError)
 	})
 }
@@ -224,3 +280,14 @@ func createSourceImage(t *testing.T, buf []byte, contentType string) v1.Image {
 
 	return img
 }
+
+type fakeVerifier struct {
+	signer string
+	err    error
+	images []string
+}
+
+func (f *fakeVerifier) Verify(_ authn.Keychain, image string) (string, error) {
+	f.images = append(f.images, image)
+	return f.signer, f.err
+}
@@ -0,0 +1,173 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+type IndexWriter struct {
+}
+
+// Write pushes an OCI image index of the platform images to the tags and returns the identifier of the index in the
+// first tag. The platform of each image in the index is read from the image config and must match the platform the
+// image was built for.
+func (w *IndexWriter) Write(keychain authn.Keychain, tags []string, images []buildapi.PlatformImage) (string, error) {
+	if len(tags) == 0 {
+		return "", errors.New("no tags to write the image index to")
+	}
+
+	index := mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+	for _, image := range images {
+		platform, err := v1.ParsePlatform(image.Platform)
+		if err != nil {
+			return "", err
+		}
+
+		ref, err := name.ParseReference(image.Image)
+		if err != nil {
+			return "", err
+		}
+
+		img, err := remote.Image(ref, remote.WithAuthFromKeychain(keychain))
+		if err != nil {
+			return "", handleError(err)
+		}
+
+		config, err := img.ConfigFile()
+		if err != nil {
+			return "", handleError(err)
+		}
+
+		if imagePlatform := config.Platform(); imagePlatform == nil || !imagePlatform.Satisfies(*platform) {
+			return "", errors.Errorf("image %s is for platform %s, expected %s", image.Image, imagePlatform, image.Platform)
+		}
+
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: config.Platform()},
+		})
+	}
+
+	var identifier string
+	for _, tag := range tags {
+		ref, err := name.ParseReference(tag)
+		if err != nil {
+			return "", err
+		}
+
+		if err := remote.WriteIndex(ref, index, remote.WithAuthFromKeychain(keychain)); err != nil {
+			return "", handleError(err)
+		}
+
+		if identifier == "" {
+			digest, err := index.Digest()
+			if err != nil {
+				return "", err
+			}
+			identifier = ref.Context().Name() + "@" + digest.String()
+		}
+	}
+	return identifier, nil
+}
+
+// FetchPlatform fetches the image of the platform, such as linux/arm64, from an image index. Images that are not an
+// index are returned for any platform.
+func (t *Client) FetchPlatform(keychain authn.Keychain, repoName string, platform string) (v1.Image, string, error) {
+	reference, err := name.ParseReference(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, err := v1.ParsePlatform(platform)
+	if err != nil {
+		return nil, "", err
+	}
+
+	image, err := remote.Image(reference, remote.WithAuthFromKeychain(keychain), remote.WithPlatform(*p))
+	if err != nil {
+		return nil, "", handleError(err)
+	}
+
+	identifier, err := getIdentifier(image, reference)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return image, identifier, nil
+}
+
+// FetchIndexed fetches the image like Fetch and also returns the identifier of the image index the reference
+// resolved to, so the images of other platforms can be fetched from the same index. The index identifier is empty if
+// the reference is not an image index.
+func (t *Client) FetchIndexed(keychain authn.Keychain, repoName string) (v1.Image, string, string, error) {
+	reference, err := name.ParseReference(repoName)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	descriptor, err := remote.Get(reference, remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return nil, "", "", handleError(err)
+	}
+
+	image, err := descriptor.Image()
+	if err != nil {
+		return nil, "", "", handleError(err)
+	}
+
+	identifier, err := getIdentifier(image, reference)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var indexIdentifier string
+	if descriptor.MediaType.IsIndex() {
+		indexIdentifier = reference.Context().Name() + "@" + descriptor.Digest.String()
+	}
+
+	return image, identifier, indexIdentifier, nil
+}
+
+// SaveIndex pushes the image index and its images to the tag and returns the identifier of the index
+func (t *Client) SaveIndex(keychain authn.Keychain, tag string, index v1.ImageIndex) (string, error) {
+	ref, err := name.ParseReference(tag)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := index.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	identifier := fmt.Sprintf("%s@%s", tag, digest.String())
+
+	if digest.String() == previousIndexDigest(keychain, ref) {
+		return identifier, nil
+	}
+	err = remote.WriteIndex(ref, index, remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return "", handleError(err)
+	}
+
+	return identifier, remote.Tag(ref.Context().Tag(timestampTag()), index, remote.WithAuthFromKeychain(keychain))
+}
+
+func previousIndexDigest(keychain authn.Keychain, ref name.Reference) string {
+	desc, err := remote.Head(ref, remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return ""
+	}
+
+	return desc.Digest.String()
+}
@@ -0,0 +1,244 @@
+package registry_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+func TestIndexWriter(t *testing.T) {
+	spec.Run(t, "TestIndexWriter", testIndexWriter)
+}
+
+func testIndexWriter(t *testing.T, when spec.G, it spec.S) {
+	var (
+		keychain = authn.NewMultiKeychain()
+		server   = httptest.NewServer(ggcrregistry.New())
+		host     = strings.TrimPrefix(server.URL, "http://")
+		subject  = &registry.IndexWriter{}
+	)
+
+	it.After(func() {
+		server.Close()
+	})
+
+	pushImage := func(tag, arch string) (string, v1.Hash) {
+		image, err := random.Image(10, 1)
+		require.NoError(t, err)
+
+		config, err := image.ConfigFile()
+		require.NoError(t, err)
+		config.OS = "linux"
+		config.Architecture = arch
+		image, err = mutate.ConfigFile(image, config)
+		require.NoError(t, err)
+
+		ref, err := name.ParseReference(tag)
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(ref, image))
+
+		digest, err := image.Digest()
+		require.NoError(t, err)
+		return ref.Context().Name() + "@" + digest.String(), digest
+	}
+
+	when("Write", func() {
+		it("pushes an index of the platform images to the tags", func() {
+			amd64Image, amd64Digest := pushImage(host+"/some/image:linux-amd64", "amd64")
+			arm64Image, arm64Digest := pushImage(host+"/some/image:linux-arm64", "arm64")
+
+			identifier, err := subject.Write(keychain, []string{host + "/some/image", host + "/some/image:other"}, []buildapi.PlatformImage{
+				{Platform: "linux/amd64", Image: amd64Image},
+				{Platform: "linux/arm64", Image: arm64Image},
+			})
+			require.NoError(t, err)
+
+			for _, tag := range []string{host + "/some/image", host + "/some/image:other"} {
+				ref, err := name.ParseReference(tag)
+				require.NoError(t, err)
+
+				index, err := remote.Index(ref)
+				require.NoError(t, err)
+
+				digest, err := index.Digest()
+				require.NoError(t, err)
+				assert.Equal(t, host+"/some/image@"+digest.String(), identifier)
+
+				mediaType, err := index.MediaType()
+				require.NoError(t, err)
+				assert.Equal(t, types.OCIImageIndex, mediaType)
+
+				manifest, err := index.IndexManifest()
+				require.NoError(t, err)
+				require.Len(t, manifest.Manifests, 2)
+				assert.Equal(t, amd64Digest, manifest.Manifests[0].Digest)
+				assert.Equal(t, &v1.Platform{OS: "linux", Architecture: "amd64"}, manifest.Manifests[0].Platform)
+				assert.Equal(t, arm64Digest, manifest.Manifests[1].Digest)
+				assert.Equal(t, &v1.Platform{OS: "linux", Architecture: "arm64"}, manifest.Manifests[1].Platform)
+			}
+		})
+
+		it("errors when a platform image does not exist", func() {
+			_, err := subject.Write(keychain, []string{host + "/some/image"}, []buildapi.PlatformImage{
+				{Platform: "linux/amd64", Image: host + "/some/image@sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+			})
+			require.Error(t, err)
+		})
+
+		it("errors when a platform image was built for a different platform", func() {
+			amd64Image, _ := pushImage(host+"/some/image:linux-arm64", "amd64")
+
+			_, err := subject.Write(keychain, []string{host + "/some/image"}, []buildapi.PlatformImage{
+				{Platform: "linux/arm64", Image: amd64Image},
+			})
+			require.EqualError(t, err, "image "+amd64Image+" is for platform linux/amd64, expected linux/arm64")
+		})
+	})
+}
+
+func TestClientIndex(t *testing.T) {
+	spec.Run(t, "TestClientIndex", testClientIndex)
+}
+
+func testClientIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		keychain = authn.NewMultiKeychain()
+		server   = httptest.NewServer(ggcrregistry.New())
+		host     = strings.TrimPrefix(server.URL, "http://")
+		subject  = &registry.Client{}
+	)
+
+	it.After(func() {
+		server.Close()
+	})
+
+	platformImage := func(arch string) v1.Image {
+		image, err := random.Image(10, 1)
+		require.NoError(t, err)
+
+		config, err := image.ConfigFile()
+		require.NoError(t, err)
+		config.OS = "linux"
+		config.Architecture = arch
+		image, err = mutate.ConfigFile(image, config)
+		require.NoError(t, err)
+		return image
+	}
+
+	platformIndex := func(images ...v1.Image) v1.ImageIndex {
+		index := mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+		for _, image := range images {
+			config, err := image.ConfigFile()
+			require.NoError(t, err)
+			index = mutate.AppendManifests(index, mutate.IndexAddendum{
+				Add:        image,
+				Descriptor: v1.Descriptor{Platform: config.Platform()},
+			})
+		}
+		return index
+	}
+
+	when("SaveIndex", func() {
+		it("pushes the index to the tag", func() {
+			index := platformIndex(platformImage("amd64"), platformImage("arm64"))
+
+			identifier, err := subject.SaveIndex(keychain, host+"/some/builder", index)
+			require.NoError(t, err)
+
+			digest, err := index.Digest()
+			require.NoError(t, err)
+			assert.Equal(t, host+"/some/builder@"+digest.String(), identifier)
+
+			ref, err := name.ParseReference(host + "/some/builder")
+			require.NoError(t, err)
+			pushed, err := remote.Index(ref)
+			require.NoError(t, err)
+			pushedDigest, err := pushed.Digest()
+			require.NoError(t, err)
+			assert.Equal(t, digest, pushedDigest)
+		})
+	})
+
+	when("FetchIndexed", func() {
+		it("returns the identifier of the index the tag resolved to", func() {
+			index := platformIndex(platformImage("amd64"), platformImage("arm64"))
+			_, err := subject.SaveIndex(keychain, host+"/some/stack", index)
+			require.NoError(t, err)
+
+			_, _, indexIdentifier, err := subject.FetchIndexed(keychain, host+"/some/stack")
+			require.NoError(t, err)
+
+			digest, err := index.Digest()
+			require.NoError(t, err)
+			assert.Equal(t, host+"/some/stack@"+digest.String(), indexIdentifier)
+		})
+
+		it("returns no index identifier for an image that is not an index", func() {
+			amd64Image := platformImage("amd64")
+			ref, err := name.ParseReference(host + "/some/stack")
+			require.NoError(t, err)
+			require.NoError(t, remote.Write(ref, amd64Image))
+
+			image, identifier, indexIdentifier, err := subject.FetchIndexed(keychain, host+"/some/stack")
+			require.NoError(t, err)
+
+			digest, err := amd64Image.Digest()
+			require.NoError(t, err)
+			fetchedDigest, err := image.Digest()
+			require.NoError(t, err)
+			assert.Equal(t, digest, fetchedDigest)
+			assert.Equal(t, host+"/some/stack@"+digest.String(), identifier)
+			assert.Empty(t, indexIdentifier)
+		})
+	})
+
+	when("FetchPlatform", func() {
+		it("fetches the image of the platform from an index", func() {
+			arm64Image := platformImage("arm64")
+			_, err := subject.SaveIndex(keychain, host+"/some/stack", platformIndex(platformImage("amd64"), arm64Image))
+			require.NoError(t, err)
+
+			image, identifier, err := subject.FetchPlatform(keychain, host+"/some/stack", "linux/arm64")
+			require.NoError(t, err)
+
+			digest, err := arm64Image.Digest()
+			require.NoError(t, err)
+			fetchedDigest, err := image.Digest()
+			require.NoError(t, err)
+			assert.Equal(t, digest, fetchedDigest)
+			assert.Equal(t, host+"/some/stack@"+digest.String(), identifier)
+		})
+
+		it("fetches an image that is not an index for any platform", func() {
+			amd64Image := platformImage("amd64")
+			ref, err := name.ParseReference(host + "/some/stack")
+			require.NoError(t, err)
+			require.NoError(t, remote.Write(ref, amd64Image))
+
+			image, _, err := subject.FetchPlatform(keychain, host+"/some/stack", "linux/arm64")
+			require.NoError(t, err)
+
+			digest, err := amd64Image.Digest()
+			require.NoError(t, err)
+			fetchedDigest, err := image.Digest()
+			require.NoError(t, err)
+			assert.Equal(t, digest, fetchedDigest)
+		})
+	})
+}
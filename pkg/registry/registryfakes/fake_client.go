@@ -2,6 +2,7 @@ package registryfakes
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -12,17 +13,23 @@ import (
 func NewFakeClient() *FakeClient {
 	return &FakeClient{
 		images:         map[string]v1.Image{},
+		platformImages: map[string]map[string]v1.Image{},
+		indexes:        map[string]string{},
 		readKeychains:  map[string]authn.Keychain{},
 		savedImages:    map[string]v1.Image{},
+		savedIndexes:   map[string]v1.ImageIndex{},
 		writeKeychains: map[string]authn.Keychain{},
 	}
 }
 
 type FakeClient struct {
-	images        map[string]v1.Image
-	readKeychains map[string]authn.Keychain
+	images         map[string]v1.Image
+	platformImages map[string]map[string]v1.Image
+	indexes        map[string]string
+	readKeychains  map[string]authn.Keychain
 
 	savedImages    map[string]v1.Image
+	savedIndexes   map[string]v1.ImageIndex
 	writeKeychains map[string]authn.Keychain
 	fetchError     error
 }
@@ -54,6 +61,15 @@ func (f *FakeClient) Fetch(keychain authn.Keychain, repoName string) (v1.Image,
 	return image, fmt.Sprintf("%s@%s", ref.Context().Name(), digest), nil
 }
 
+func (f *FakeClient) Digest(keychain authn.Keychain, repoName string) (string, error) {
+	_, identifier, err := f.Fetch(keychain, repoName)
+	if err != nil {
+		return "", err
+	}
+
+	return identifier[strings.LastIndex(identifier, "@")+1:], nil
+}
+
 func (f *FakeClient) Save(keychain authn.Keychain, tag string, image v1.Image) (string, error) {
 	if expectedKeychain, ok := f.writeKeychains[tryParsingTag(tag)]; !ok || keychain != expectedKeychain {
 		return "", errors.New("unexpected keychain")
@@ -65,11 +81,71 @@ func (f *FakeClient) Save(keychain authn.Keychain, tag string, image v1.Image) (
 	return fmt.Sprintf("%s@%s", tag, hash), err
 }
 
+func (f *FakeClient) FetchPlatform(keychain authn.Keychain, repoName string, platform string) (v1.Image, string, error) {
+	image, ok := f.platformImages[repoName][platform]
+	if !ok {
+		return f.Fetch(keychain, repoName)
+	}
+
+	if f.fetchError != nil {
+		return nil, "", f.fetchError
+	}
+
+	if expectedKeychain, ok := f.readKeychains[tryParsingTag(repoName)]; !ok || keychain != expectedKeychain {
+		return nil, "", errors.New(fmt.Sprintf("unexpected keychain for %s", repoName))
+	}
+
+	ref, err := name.ParseReference(repoName, name.WeakValidation)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "unable to parse %s", repoName)
+	}
+
+	digest, err := image.Digest()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return image, fmt.Sprintf("%s@%s", ref.Context().Name(), digest), nil
+}
+
+func (f *FakeClient) FetchIndexed(keychain authn.Keychain, repoName string) (v1.Image, string, string, error) {
+	image, identifier, err := f.Fetch(keychain, repoName)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return image, identifier, f.indexes[repoName], nil
+}
+
+func (f *FakeClient) SaveIndex(keychain authn.Keychain, tag string, index v1.ImageIndex) (string, error) {
+	if expectedKeychain, ok := f.writeKeychains[tryParsingTag(tag)]; !ok || keychain != expectedKeychain {
+		return "", errors.New("unexpected keychain")
+	}
+
+	f.savedIndexes[tag] = index
+
+	hash, err := index.Digest()
+	return fmt.Sprintf("%s@%s", tag, hash), err
+}
+
 func (f *FakeClient) AddImage(repoName string, image v1.Image, keychain authn.Keychain) {
 	f.images[repoName] = image
 	f.readKeychains[tryParsingTag(repoName)] = keychain
 }
 
+func (f *FakeClient) AddPlatformImage(repoName string, platform string, image v1.Image, keychain authn.Keychain) {
+	if f.platformImages[repoName] == nil {
+		f.platformImages[repoName] = map[string]v1.Image{}
+	}
+	f.platformImages[repoName][platform] = image
+	f.readKeychains[tryParsingTag(repoName)] = keychain
+}
+
+// AddIndex makes the image added for the repo name resolve from the image index with the identifier
+func (f *FakeClient) AddIndex(repoName string, indexIdentifier string) {
+	f.indexes[repoName] = indexIdentifier
+}
+
 func (f *FakeClient) AddSaveKeychain(tag string, keychain authn.Keychain) {
 	f.writeKeychains[tryParsingTag(tag)] = keychain
 }
@@ -78,6 +154,10 @@ func (f *FakeClient) SavedImages() map[string]v1.Image {
 	return f.savedImages
 }
 
+func (f *FakeClient) SavedIndexes() map[string]v1.ImageIndex {
+	return f.savedIndexes
+}
+
 func (f *FakeClient) SetFetchError(err error) {
 	f.fetchError = err
 }
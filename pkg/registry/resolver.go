@@ -3,21 +3,64 @@ package registry
 import (
 	"context"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
+type DigestClient interface {
+	Digest(keychain authn.Keychain, repoName string) (string, error)
+}
+
 type Resolver struct {
+	KeychainFactory KeychainFactory
+	Client          DigestClient
 }
 
-func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
-	return corev1alpha1.ResolvedSourceConfig{
-		Registry: &corev1alpha1.ResolvedRegistrySource{
-			Image:            sourceResolver.Spec.Source.Registry.Image,
-			ImagePullSecrets: sourceResolver.Spec.Source.Registry.ImagePullSecrets,
-			SubPath:          sourceResolver.Spec.Source.SubPath,
-		},
-	}, nil
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	registrySource := sourceResolver.Spec.Source.Registry
+	resolved := &corev1alpha1.ResolvedRegistrySource{
+		Image:            registrySource.Image,
+		ImagePullSecrets: registrySource.ImagePullSecrets,
+		SubPath:          sourceResolver.Spec.Source.SubPath,
+		Verification:     registrySource.Verification,
+	}
+
+	ref, err := name.ParseReference(registrySource.Image, name.WeakValidation)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	if digest, ok := ref.(name.Digest); ok {
+		resolved.Digest = digest.DigestStr()
+		resolved.Type = corev1alpha1.RegistryDigest
+		return corev1alpha1.ResolvedSourceConfig{Registry: resolved}, nil
+	}
+
+	keychain, err := r.KeychainFactory.KeychainForSecretRef(ctx, SecretRef{
+		ServiceAccount:   sourceResolver.Spec.ServiceAccountName,
+		Namespace:        sourceResolver.Namespace,
+		ImagePullSecrets: registrySource.ImagePullSecrets,
+	})
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	digest, err := r.Client.Digest(keychain, registrySource.Image)
+	if err != nil {
+		// the first resolution is retried, later polls keep the previously resolved digest
+		if sourceResolver.Status.Source.Registry == nil || sourceResolver.Status.ObservedGeneration != sourceResolver.Generation {
+			return corev1alpha1.ResolvedSourceConfig{}, err
+		}
+		resolved.Type = corev1alpha1.RegistryUnknown
+		return corev1alpha1.ResolvedSourceConfig{Registry: resolved}, nil
+	}
+
+	resolved.Digest = digest
+	resolved.Type = corev1alpha1.RegistryTag
+	return corev1alpha1.ResolvedSourceConfig{Registry: resolved}, nil
 }
 
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
@@ -0,0 +1,132 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestRegistryResolver(t *testing.T) {
+	spec.Run(t, "Registry Resolver", testRegistryResolver)
+}
+
+func testRegistryResolver(t *testing.T, when spec.G, it spec.S) {
+	var (
+		client          = registryfakes.NewFakeClient()
+		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		keychain        = authn.NewMultiKeychain(authn.DefaultKeychain)
+		resolver        = &registry.Resolver{
+			KeychainFactory: keychainFactory,
+			Client:          client,
+		}
+		pullSecrets = []corev1.LocalObjectReference{{Name: "some-pull-secret"}}
+	)
+
+	it.Before(func() {
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount:   "some-service-account",
+			Namespace:        "some-namespace",
+			ImagePullSecrets: pullSecrets,
+		}, keychain)
+	})
+
+	sourceResolver := func(image string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-source-resolver",
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.SourceResolverSpec{
+				ServiceAccountName: "some-service-account",
+				Source: corev1alpha1.SourceConfig{
+					Registry: &corev1alpha1.Registry{
+						Image:            image,
+						ImagePullSecrets: pullSecrets,
+					},
+					SubPath: "some-sub-path",
+				},
+			},
+		}
+	}
+
+	it("resolves tags to a digest and polls", func() {
+		img, err := random.Image(0, 0)
+		require.NoError(t, err)
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		client.AddImage("some-registry.io/source:latest", img, keychain)
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver("some-registry.io/source:latest"))
+		require.NoError(t, err)
+
+		require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+			Registry: &corev1alpha1.ResolvedRegistrySource{
+				Image:            "some-registry.io/source:latest",
+				Digest:           digest.String(),
+				Type:             corev1alpha1.RegistryTag,
+				SubPath:          "some-sub-path",
+				ImagePullSecrets: pullSecrets,
+			},
+		}, resolved)
+		require.True(t, resolved.Registry.IsPollable())
+		require.Equal(t, digest.String(), resolved.Registry.SourceConfig().Registry.Digest)
+	})
+
+	it("does not poll images referenced by digest", func() {
+		image := "some-registry.io/source@sha256:0a2b3075a370ed209cc262ca189b56f0e09fee17dc69ab99a479f07168818374"
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(image))
+		require.NoError(t, err)
+
+		require.Equal(t, "sha256:0a2b3075a370ed209cc262ca189b56f0e09fee17dc69ab99a479f07168818374", resolved.Registry.Digest)
+		require.Equal(t, corev1alpha1.RegistryDigest, resolved.Registry.Type)
+		require.False(t, resolved.Registry.IsPollable())
+	})
+
+	it("returns an error when the digest cannot be resolved before the source is resolved", func() {
+		client.SetFetchError(errors.New("some-error"))
+
+		_, err := resolver.Resolve(context.TODO(), sourceResolver("some-registry.io/source:latest"))
+		require.EqualError(t, err, "some-error")
+	})
+
+	it("is unknown when the digest cannot be resolved after the source is resolved", func() {
+		client.SetFetchError(errors.New("some-error"))
+
+		sr := sourceResolver("some-registry.io/source:latest")
+		sr.Generation = 2
+		sr.Status.ObservedGeneration = 2
+		sr.Status.Source.Registry = &corev1alpha1.ResolvedRegistrySource{
+			Image:  "some-registry.io/source:latest",
+			Digest: "sha256:0a2b3075a370ed209cc262ca189b56f0e09fee17dc69ab99a479f07168818374",
+			Type:   corev1alpha1.RegistryTag,
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sr)
+		require.NoError(t, err)
+
+		require.True(t, resolved.Registry.IsUnknown())
+		require.Empty(t, resolved.Registry.Digest)
+	})
+
+	it("returns an error when the keychain cannot be created", func() {
+		sr := sourceResolver("some-registry.io/source:latest")
+		sr.Spec.ServiceAccountName = "other-service-account"
+
+		_, err := resolver.Resolve(context.TODO(), sr)
+		require.Error(t, err)
+	})
+}
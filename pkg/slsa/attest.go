@@ -49,7 +49,7 @@ func (a *Attester) AttestBuild(build *buildv1alpha2.Build, buildMetadata *cnb.Bu
 		return intoto.Statement{}, fmt.Errorf("failed to read app image: %v", err)
 	}
 
-	source, sourceDigest, err := extractSourceFromLabel(appLabels)
+	source, sourceDigest, sourceSigner, err := extractSourceFromLabel(appLabels)
 	if err != nil {
 		return intoto.Statement{}, fmt.Errorf("failed to extract source from label: %v", err)
 	}
@@ -73,9 +73,10 @@ func (a *Attester) AttestBuild(build *buildv1alpha2.Build, buildMetadata *cnb.Bu
 			InternalParameters: a.internalParamsFor(build),
 			ResolvedDependencies: []slsav1.ResourceDescriptor{
 				{
-					Name:   "source",
-					URI:    source,
-					Digest: sourceDigest,
+					Name:        "source",
+					URI:         source,
+					Digest:      sourceDigest,
+					Annotations: sourceAnnotations(sourceSigner),
 				},
 				{
 					Name: "builder-image",
@@ -235,3 +236,10 @@ func WithVersionedObjects(kind string, objs []K8sObject) BuilderDependencyFn {
 		}, nil
 	}
 }
+
+func sourceAnnotations(signer string) map[string]interface{} {
+	if signer == "" {
+		return nil
+	}
+	return map[string]interface{}{"verifiedSigner": signer}
+}
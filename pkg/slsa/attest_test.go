@@ -189,6 +189,13 @@ func testAttester(t *testing.T, when spec.G, it spec.S) {
         "maximumPlatformApiVersion": "",
         "sshTrustUnknownHosts": true,
         "scalingFactor": 0,
+        "sourcePollingFrequency": 0,
+        "sourceWebhookPort": 0,
+        "sourceUploadPort": 0,
+        "sourceUploadRepository": "",
+        "maxConcurrentBuilds": 0,
+        "maxConcurrentBuildsPerNamespace": 0,
+        "maxConcurrentBuildsPerBuilder": 0,
         "buildInitImage": "build-init-image",
         "buildWaiterImage": "build-waiter-image",
         "completionImage": "completion-image",
@@ -241,6 +248,28 @@ func testAttester(t *testing.T, when spec.G, it spec.S) {
 		require.Equal(t, expected, string(actual))
 	})
 
+	it("records the verified source signer", func() {
+		appImage.ConfigFileReturns(&ggcrv1.ConfigFile{
+			Config: ggcrv1.Config{
+				Labels: map[string]string{
+					"io.buildpacks.project.metadata": `{"source":{"type":"git","version":{"commit":"some-commitsh"},"metadata":{"repository":"https://some-git.repo","revision":"some-branch","signer":"some-signer (ssh SHA256:some-fingerprint)"}}}`,
+				},
+			},
+		}, nil)
+
+		stmt, err := attester.AttestBuild(build, buildMetadata, pod, authn.DefaultKeychain, UnsignedBuildID)
+		require.NoError(t, err)
+
+		pred, ok := stmt.Predicate.(slsav1.ProvenancePredicate)
+		require.True(t, ok)
+		require.Equal(t, slsav1.ResourceDescriptor{
+			Name:        "source",
+			URI:         "https://some-git.repo",
+			Digest:      map[string]string{"sha1": "some-commitsh"},
+			Annotations: map[string]interface{}{"verifiedSigner": "some-signer (ssh SHA256:some-fingerprint)"},
+		}, pred.BuildDefinition.ResolvedDependencies[0])
+	})
+
 	when("using the builder dependency fn", func() {
 		it("records single object", func() {
 			fn := WithVersionedObject("Namespace", &corev1.Namespace{
@@ -53,33 +53,36 @@ func (r *reader) Read(keychain authn.Keychain, repoName string) (string, string,
 	return ref.Context().Name(), sha, configFile.Config.Labels, nil
 }
 
-func extractSourceFromLabel(labels map[string]string) (string, slsacommon.DigestSet, error) {
+// extractSourceFromLabel returns the source uri, digest and the identity that signed the source if it was verified
+func extractSourceFromLabel(labels map[string]string) (string, slsacommon.DigestSet, string, error) {
 	metadata, found := labels[ProjectMetadataLabel]
 	if !found {
-		return "", nil, fmt.Errorf("label not found: '%v'", ProjectMetadataLabel)
+		return "", nil, "", fmt.Errorf("label not found: '%v'", ProjectMetadataLabel)
 	}
 
 	var p project
 	err := json.Unmarshal([]byte(metadata), &p)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to unmarshal json: %v", err)
+		return "", nil, "", fmt.Errorf("failed to unmarshal json: %v", err)
 	}
 
 	switch p.Source.Type {
 	case "git":
 		// while sha256 support is available, go-git still defaults to sha1 for now
 		// https://github.com/go-git/go-git/issues/706
-		return p.Source.Metadata.Repository, map[string]string{"sha1": p.Source.Version.Commit}, nil
+		return p.Source.Metadata.Repository, map[string]string{"sha1": p.Source.Version.Commit}, p.Source.Metadata.Signer, nil
 	case "blob":
-		return p.Source.Metadata.Url, map[string]string{"sha256": p.Source.Version.SHA256}, nil
+		return p.Source.Metadata.Url, map[string]string{"sha256": p.Source.Version.SHA256}, "", nil
+	case "volume":
+		return p.Source.Metadata.Volume, map[string]string{"sha256": p.Source.Version.SHA256}, "", nil
 	case "image":
 		sha, found := strings.CutPrefix(p.Source.Version.Digest, "sha256:")
 		if !found {
-			return "", nil, fmt.Errorf("unknown digest format '%v'", p.Source.Version.Digest)
+			return "", nil, "", fmt.Errorf("unknown digest format '%v'", p.Source.Version.Digest)
 		}
-		return p.Source.Metadata.Image, map[string]string{"sha256": sha}, nil
+		return p.Source.Metadata.Image, map[string]string{"sha256": sha}, p.Source.Metadata.Signer, nil
 	default:
-		return "", nil, fmt.Errorf("unknown project type: '%v'", p.Source.Type)
+		return "", nil, "", fmt.Errorf("unknown project type: '%v'", p.Source.Type)
 	}
 }
 
@@ -98,6 +101,8 @@ type metadata struct {
 	Revision   string `json:"revision"`
 	Image      string `json:"image"`
 	Url        string `json:"url"`
+	Volume     string `json:"volume"`
+	Signer     string `json:"signer"`
 }
 
 type version struct {
@@ -16,6 +16,7 @@ import (
 	"github.com/pivotal/kpack/pkg/blob"
 	"github.com/pivotal/kpack/pkg/git"
 	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/volume"
 )
 
 func TestImageReader(t *testing.T) {
@@ -48,7 +49,7 @@ func testImageReader(t *testing.T, when spec.G, it spec.S) {
 		metadata, err := tomlToJsonString(git.Project{
 			Source: git.Source{
 				Type:     "git",
-				Metadata: git.Metadata{Repository: "https://some-git.repo", Revision: "some-branch"},
+				Metadata: git.Metadata{Repository: "https://some-git.repo", Revision: "some-branch", Signer: "some-signer (ssh SHA256:some-fingerprint)"},
 				Version:  git.Version{Commit: "some-commitsh"},
 			},
 		})
@@ -59,11 +60,12 @@ func testImageReader(t *testing.T, when spec.G, it spec.S) {
 			"io.buildpacks.project.metadata": metadata,
 		}
 
-		repo, sha, err := extractSourceFromLabel(labels)
+		repo, sha, signer, err := extractSourceFromLabel(labels)
 		require.NoError(t, err)
 
 		require.Equal(t, "https://some-git.repo", repo)
 		require.Equal(t, slsacommon.DigestSet{"sha1": "some-commitsh"}, sha)
+		require.Equal(t, "some-signer (ssh SHA256:some-fingerprint)", signer)
 	})
 
 	it("can parse project metadata of type blob", func() {
@@ -81,18 +83,42 @@ func testImageReader(t *testing.T, when spec.G, it spec.S) {
 			"io.buildpacks.project.metadata": metadata,
 		}
 
-		repo, sha, err := extractSourceFromLabel(labels)
+		repo, sha, signer, err := extractSourceFromLabel(labels)
 		require.NoError(t, err)
 
 		require.Equal(t, "https://some-blob.store", repo)
 		require.Equal(t, slsacommon.DigestSet{"sha256": "some-sha256sum"}, sha)
+		require.Empty(t, signer)
+	})
+
+	it("can parse project metadata of type volume", func() {
+		metadata, err := tomlToJsonString(volume.Project{
+			Source: volume.Source{
+				Type:     "volume",
+				Metadata: volume.Metadata{Volume: "configmap/some-config-map/source.tar.gz"},
+				Version:  volume.Version{SHA256: "some-sha256sum"},
+			},
+		})
+		require.NoError(t, err)
+
+		labels := map[string]string{
+			"some-label":                     "some-value",
+			"io.buildpacks.project.metadata": metadata,
+		}
+
+		repo, sha, signer, err := extractSourceFromLabel(labels)
+		require.NoError(t, err)
+
+		require.Equal(t, "configmap/some-config-map/source.tar.gz", repo)
+		require.Equal(t, slsacommon.DigestSet{"sha256": "some-sha256sum"}, sha)
+		require.Empty(t, signer)
 	})
 
 	it("can parse project metadata of type image", func() {
 		metadata, err := tomlToJsonString(registry.Project{
 			Source: registry.Source{
 				Type:     "image",
-				Metadata: registry.Metadata{Image: "some-registry.io/repo"},
+				Metadata: registry.Metadata{Image: "some-registry.io/repo", Signer: "cosign public key sha256:some-key-digest"},
 				Version:  registry.Version{Digest: "sha256:some-image-digest"},
 			},
 		})
@@ -103,11 +129,12 @@ func testImageReader(t *testing.T, when spec.G, it spec.S) {
 			"io.buildpacks.project.metadata": metadata,
 		}
 
-		repo, sha, err := extractSourceFromLabel(labels)
+		repo, sha, signer, err := extractSourceFromLabel(labels)
 		require.NoError(t, err)
 
 		require.Equal(t, "some-registry.io/repo", repo)
 		require.Equal(t, slsacommon.DigestSet{"sha256": "some-image-digest"}, sha)
+		require.Equal(t, "cosign public key sha256:some-key-digest", signer)
 	})
 }
 
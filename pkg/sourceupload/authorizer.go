@@ -0,0 +1,73 @@
+package sourceupload
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/pivotal/kpack/pkg/apis/build"
+)
+
+var (
+	ErrUnauthenticated = errors.New("token is not valid")
+	ErrForbidden       = errors.New("not allowed to update image")
+)
+
+type Authorizer interface {
+	Authorize(ctx context.Context, token, namespace, name string) error
+}
+
+// TokenReviewAuthorizer allows uploads from kubernetes users that can update the Image
+type TokenReviewAuthorizer struct {
+	Client kubernetes.Interface
+}
+
+func (a *TokenReviewAuthorizer) Authorize(ctx context.Context, token, namespace, name string) error {
+	tokenReview, err := a.Client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "reviewing token")
+	}
+
+	if !tokenReview.Status.Authenticated {
+		return ErrUnauthenticated
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	accessReview, err := a.Client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "update",
+				Group:     build.GroupName,
+				Resource:  "images",
+				Name:      name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			UID:    user.UID,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "reviewing access")
+	}
+
+	if !accessReview.Status.Allowed {
+		return errors.Wrapf(ErrForbidden, "user '%s'", user.Username)
+	}
+
+	return nil
+}
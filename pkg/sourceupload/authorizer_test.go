@@ -0,0 +1,95 @@
+package sourceupload_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/pivotal/kpack/pkg/sourceupload"
+)
+
+func TestTokenReviewAuthorizer(t *testing.T) {
+	spec.Run(t, "Token Review Authorizer", testTokenReviewAuthorizer)
+}
+
+func testTokenReviewAuthorizer(t *testing.T, when spec.G, it spec.S) {
+	var (
+		k8sClient     *fake.Clientset
+		authorizer    *sourceupload.TokenReviewAuthorizer
+		accessReviews []authorizationv1.SubjectAccessReviewSpec
+		allowed       bool
+	)
+
+	it.Before(func() {
+		accessReviews = nil
+		allowed = true
+		k8sClient = fake.NewSimpleClientset()
+
+		k8sClient.PrependReactor("create", "tokenreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+			review := action.(clientgotesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+			if review.Spec.Token == "some-token" {
+				review.Status = authenticationv1.TokenReviewStatus{
+					Authenticated: true,
+					User: authenticationv1.UserInfo{
+						Username: "some-user",
+						UID:      "some-uid",
+						Groups:   []string{"some-group"},
+						Extra:    map[string]authenticationv1.ExtraValue{"some-key": {"some-value"}},
+					},
+				}
+			}
+			return true, review, nil
+		})
+
+		k8sClient.PrependReactor("create", "subjectaccessreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+			review := action.(clientgotesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			accessReviews = append(accessReviews, review.Spec)
+			review.Status.Allowed = allowed
+			return true, review, nil
+		})
+
+		authorizer = &sourceupload.TokenReviewAuthorizer{Client: k8sClient}
+	})
+
+	it("allows users that can update the image", func() {
+		require.NoError(t, authorizer.Authorize(context.Background(), "some-token", "some-namespace", "some-image"))
+
+		require.Equal(t, []authorizationv1.SubjectAccessReviewSpec{
+			{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: "some-namespace",
+					Verb:      "update",
+					Group:     "kpack.io",
+					Resource:  "images",
+					Name:      "some-image",
+				},
+				User:   "some-user",
+				Groups: []string{"some-group"},
+				Extra:  map[string]authorizationv1.ExtraValue{"some-key": {"some-value"}},
+				UID:    "some-uid",
+			},
+		}, accessReviews)
+	})
+
+	it("rejects unauthenticated tokens", func() {
+		err := authorizer.Authorize(context.Background(), "other-token", "some-namespace", "some-image")
+		require.True(t, errors.Is(err, sourceupload.ErrUnauthenticated))
+		require.Empty(t, accessReviews)
+	})
+
+	it("rejects users that cannot update the image", func() {
+		allowed = false
+
+		err := authorizer.Authorize(context.Background(), "some-token", "some-namespace", "some-image")
+		require.True(t, errors.Is(err, sourceupload.ErrForbidden))
+		require.EqualError(t, err, "user 'some-user': not allowed to update image")
+	})
+}
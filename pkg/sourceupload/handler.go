@@ -0,0 +1,241 @@
+package sourceupload
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+// uploads are buffered to a temporary file to validate them before they are pushed
+const maxSourceSize = 100 * 1024 * 1024
+
+// Handler accepts source code tarballs for an Image at PUT /namespaces/<namespace>/images/<name>/source.
+// The tarball is pushed to <Repository>/<namespace>:<name> as a source image with the registry credentials of the
+// Image, so each namespace can only pull its own sources, and the Image source is updated to the pushed digest.
+// Requests carry kubernetes bearer tokens, so they are only accepted over TLS.
+type Handler struct {
+	Repository      string
+	KeychainFactory registry.KeychainFactory
+	Authorizer      Authorizer
+	Client          versioned.Interface
+	Logger          *zap.SugaredLogger
+}
+
+type uploadResponse struct {
+	Image string `json:"image"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.TLS == nil {
+		http.Error(w, "source uploads require https", http.StatusForbidden)
+		return
+	}
+
+	namespace, imageName, ok := parsePath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		http.Error(w, "bearer token is required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.Authorizer.Authorize(r.Context(), token, namespace, imageName); err != nil {
+		switch {
+		case errors.Is(err, ErrUnauthenticated):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case errors.Is(err, ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			h.Logger.Errorw("failed to authorize source upload", zap.Error(err))
+			http.Error(w, "failed to authorize request", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	image, err := h.Client.KpackV1alpha2().Images(namespace).Get(r.Context(), imageName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		http.Error(w, fmt.Sprintf("image '%s' not found in namespace '%s'", imageName, namespace), http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.Logger.Errorw("failed to get image for source upload", zap.Error(err))
+		http.Error(w, "failed to get image", http.StatusInternalServerError)
+		return
+	}
+
+	if hasVerification(image.Spec.Source) {
+		http.Error(w, "image source requires verification, uploaded sources cannot be verified", http.StatusConflict)
+		return
+	}
+
+	sourceFile, err := os.CreateTemp("", "source-upload")
+	if err != nil {
+		h.Logger.Errorw("failed to create source upload file", zap.Error(err))
+		http.Error(w, "failed to store source", http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(sourceFile.Name())
+	defer sourceFile.Close()
+
+	size, err := io.Copy(sourceFile, io.LimitReader(r.Body, maxSourceSize+1))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if size > maxSourceSize {
+		http.Error(w, fmt.Sprintf("source exceeds the maximum size of %d bytes", maxSourceSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	layer, err := tarball.LayerFromFile(sourceFile.Name())
+	if err != nil {
+		http.Error(w, "source must be a tar or tar.gz archive", http.StatusBadRequest)
+		return
+	}
+	if err := validateTar(layer); err != nil {
+		http.Error(w, "source must be a tar or tar.gz archive", http.StatusBadRequest)
+		return
+	}
+
+	sourceImage, err := h.push(r.Context(), image, layer)
+	if err != nil {
+		h.Logger.Errorw("failed to push uploaded source", zap.Error(err))
+		http.Error(w, "failed to store source", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.updateSource(r.Context(), namespace, imageName, sourceImage); err != nil {
+		h.Logger.Errorw("failed to update image source", zap.Error(err))
+		http.Error(w, "failed to update image source", http.StatusInternalServerError)
+		return
+	}
+
+	h.Logger.Infow("updated image source from upload", "namespace", namespace, "name", imageName, "source", sourceImage)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(uploadResponse{Image: sourceImage})
+}
+
+func (h *Handler) push(ctx context.Context, image *buildapi.Image, layer v1.Layer) (string, error) {
+	tag, err := name.NewTag(fmt.Sprintf("%s/%s:%s", h.Repository, image.Namespace, image.Name), name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return "", err
+	}
+
+	keychain, err := h.KeychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{
+		ServiceAccount:   image.Spec.ServiceAccountName,
+		Namespace:        image.Namespace,
+		ImagePullSecrets: imagePullSecrets(image.Spec.Source),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := remote.Write(tag, img, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx)); err != nil {
+		return "", err
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return tag.Context().Digest(digest.String()).String(), nil
+}
+
+func (h *Handler) updateSource(ctx context.Context, namespace, imageName, sourceImage string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		image, err := h.Client.KpackV1alpha2().Images(namespace).Get(ctx, imageName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if hasVerification(image.Spec.Source) {
+			return errors.New("image source requires verification")
+		}
+
+		image.Spec.Source = corev1alpha1.SourceConfig{
+			Registry: &corev1alpha1.Registry{
+				Image:            sourceImage,
+				ImagePullSecrets: imagePullSecrets(image.Spec.Source),
+			},
+			SubPath: image.Spec.Source.SubPath,
+		}
+
+		_, err = h.Client.KpackV1alpha2().Images(namespace).Update(ctx, image, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func parsePath(path string) (string, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 5 || parts[0] != "namespaces" || parts[2] != "images" || parts[4] != "source" {
+		return "", "", false
+	}
+
+	if parts[1] == "" || parts[3] == "" {
+		return "", "", false
+	}
+
+	return parts[1], parts[3], true
+}
+
+// imagePullSecrets are kept from a registry source, so the uploaded source is pushed and pulled with them
+func imagePullSecrets(source corev1alpha1.SourceConfig) []corev1.LocalObjectReference {
+	if source.Registry == nil {
+		return nil
+	}
+	return source.Registry.ImagePullSecrets
+}
+
+func hasVerification(source corev1alpha1.SourceConfig) bool {
+	return (source.Git != nil && source.Git.Verification != nil) ||
+		(source.Registry != nil && source.Registry.Verification != nil)
+}
+
+func validateTar(layer v1.Layer) error {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = tar.NewReader(rc).Next()
+	return err
+}
@@ -0,0 +1,281 @@
+package sourceupload_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+	"github.com/pivotal/kpack/pkg/sourceupload"
+)
+
+func TestHandler(t *testing.T) {
+	spec.Run(t, "Source Upload Handler", testHandler)
+}
+
+func testHandler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		namespace = "some-namespace"
+		imageName = "some-image"
+		uploadURL = "/namespaces/some-namespace/images/some-image/source"
+	)
+
+	var (
+		registryServer  *httptest.Server
+		repository      string
+		client          *fake.Clientset
+		authorizer      *fakeAuthorizer
+		handler         *sourceupload.Handler
+		keychainFactory *registryfakes.FakeKeychainFactory
+	)
+
+	it.Before(func() {
+		registryServer = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+		u, err := url.Parse(registryServer.URL)
+		require.NoError(t, err)
+		repository = u.Host + "/some/sources"
+
+		client = fake.NewSimpleClientset(&buildapi.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      imageName,
+				Namespace: namespace,
+			},
+			Spec: buildapi.ImageSpec{
+				Tag:                "some-registry.io/some/app",
+				ServiceAccountName: "some-service-account",
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "https://github.com/some-org/some-repo",
+						Revision: "main",
+					},
+					SubPath: "some-sub-path",
+				},
+			},
+		})
+
+		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{ServiceAccount: "some-service-account", Namespace: namespace}, &registryfakes.FakeKeychain{})
+
+		authorizer = &fakeAuthorizer{token: "some-token"}
+		handler = &sourceupload.Handler{
+			Repository:      repository,
+			KeychainFactory: keychainFactory,
+			Authorizer:      authorizer,
+			Client:          client,
+			Logger:          zap.NewNop().Sugar(),
+		}
+	})
+
+	it.After(func() {
+		registryServer.Close()
+	})
+
+	upload := func(method, target, token string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		req.TLS = &tls.ConnectionState{}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	getImage := func() *buildapi.Image {
+		image, err := client.KpackV1alpha2().Images(namespace).Get(context.Background(), imageName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return image
+	}
+
+	it("pushes the source to the repository of the namespace and updates the image source to the pushed digest", func() {
+		rec := upload(http.MethodPut, uploadURL, "some-token", sourceTarGz(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response struct {
+			Image string `json:"image"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		require.Regexp(t, "^"+repository+"/some-namespace@sha256:[0-9a-f]{64}$", response.Image)
+
+		require.Equal(t, corev1alpha1.SourceConfig{
+			Registry: &corev1alpha1.Registry{Image: response.Image},
+			SubPath:  "some-sub-path",
+		}, getImage().Spec.Source)
+
+		require.Equal(t, []authorizeCall{{namespace: namespace, name: imageName}}, authorizer.calls)
+
+		ref, err := name.ParseReference(response.Image)
+		require.NoError(t, err)
+		img, err := remote.Image(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+		require.NoError(t, err)
+		layers, err := img.Layers()
+		require.NoError(t, err)
+		require.Len(t, layers, 1)
+
+		tagged, err := remote.Image(ref.Context().Tag("some-image"))
+		require.NoError(t, err)
+		taggedDigest, err := tagged.Digest()
+		require.NoError(t, err)
+		require.Equal(t, ref.Identifier(), taggedDigest.String())
+	})
+
+	it("accepts uncompressed tarballs", func() {
+		rec := upload(http.MethodPut, uploadURL, "some-token", sourceTar(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NotNil(t, getImage().Spec.Source.Registry)
+	})
+
+	it("rejects requests without tls", func() {
+		req := httptest.NewRequest(http.MethodPut, uploadURL, bytes.NewReader(sourceTarGz(t, map[string]string{"main.go": "package main"})))
+		req.Header.Set("Authorization", "Bearer some-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+		require.Empty(t, authorizer.calls)
+		require.NotNil(t, getImage().Spec.Source.Git)
+	})
+
+	it("pushes with the image pull secrets of a registry source and keeps them", func() {
+		pullSecrets := []corev1.LocalObjectReference{{Name: "some-pull-secret"}}
+		image := getImage()
+		image.Spec.Source = corev1alpha1.SourceConfig{
+			Registry: &corev1alpha1.Registry{
+				Image:            "some-registry.io/some/source",
+				ImagePullSecrets: pullSecrets,
+			},
+		}
+		_, err := client.KpackV1alpha2().Images(namespace).Update(context.Background(), image, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{ServiceAccount: "some-service-account", Namespace: namespace, ImagePullSecrets: pullSecrets}, &registryfakes.FakeKeychain{})
+
+		rec := upload(http.MethodPut, uploadURL, "some-token", sourceTarGz(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		source := getImage().Spec.Source
+		require.NotNil(t, source.Registry)
+		require.Regexp(t, "^"+repository+"/some-namespace@sha256:[0-9a-f]{64}$", source.Registry.Image)
+		require.Equal(t, pullSecrets, source.Registry.ImagePullSecrets)
+	})
+
+	it("rejects requests without a token", func() {
+		rec := upload(http.MethodPut, uploadURL, "", sourceTarGz(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.NotNil(t, getImage().Spec.Source.Git)
+	})
+
+	it("rejects invalid tokens", func() {
+		rec := upload(http.MethodPut, uploadURL, "other-token", sourceTarGz(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.NotNil(t, getImage().Spec.Source.Git)
+	})
+
+	it("rejects users that cannot update the image", func() {
+		authorizer.forbidden = true
+
+		rec := upload(http.MethodPut, uploadURL, "some-token", sourceTarGz(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusForbidden, rec.Code)
+		require.NotNil(t, getImage().Spec.Source.Git)
+	})
+
+	it("returns not found for unknown images", func() {
+		rec := upload(http.MethodPut, "/namespaces/some-namespace/images/other-image/source", "some-token", sourceTarGz(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	it("returns not found for unknown paths", func() {
+		rec := upload(http.MethodPut, "/namespaces/some-namespace/builds/some-build/source", "some-token", nil)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	it("only allows PUT", func() {
+		rec := upload(http.MethodPost, uploadURL, "some-token", nil)
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	it("rejects sources that are not tarballs", func() {
+		rec := upload(http.MethodPut, uploadURL, "some-token", []byte("not a tarball"))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.NotNil(t, getImage().Spec.Source.Git)
+	})
+
+	it("rejects uploads for images that require source verification", func() {
+		image := getImage()
+		image.Spec.Source.Git.Verification = &corev1alpha1.SourceVerification{SecretRef: corev1.LocalObjectReference{Name: "some-keys"}}
+		_, err := client.KpackV1alpha2().Images(namespace).Update(context.Background(), image, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		rec := upload(http.MethodPut, uploadURL, "some-token", sourceTarGz(t, map[string]string{"main.go": "package main"}))
+		require.Equal(t, http.StatusConflict, rec.Code)
+		require.NotNil(t, getImage().Spec.Source.Git)
+	})
+}
+
+type authorizeCall struct {
+	namespace string
+	name      string
+}
+
+type fakeAuthorizer struct {
+	token     string
+	forbidden bool
+	calls     []authorizeCall
+}
+
+func (f *fakeAuthorizer) Authorize(_ context.Context, token, namespace, name string) error {
+	f.calls = append(f.calls, authorizeCall{namespace: namespace, name: name})
+	if token != f.token {
+		return sourceupload.ErrUnauthenticated
+	}
+	if f.forbidden {
+		return errors.Wrap(sourceupload.ErrForbidden, "some-user")
+	}
+	return nil
+}
+
+func sourceTar(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for path, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: path, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func sourceTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	_, err := gw.Write(sourceTar(t, files))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
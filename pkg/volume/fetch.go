@@ -0,0 +1,219 @@
+package volume
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/pivotal/kpack/pkg/archive"
+)
+
+var errUnexpectedArchiveType = fmt.Errorf("unexpected archive file type, must be one of .zip, .tar.gz, .tar")
+
+// magic bytes identifying the archive types, the tar magic is at offset 257 of the first header
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+	tarMagic  = []byte("ustar")
+)
+
+const tarMagicOffset = 257
+
+type Fetcher struct {
+	Logger *log.Logger
+	// VolumeDir is where the source volume is mounted
+	VolumeDir string
+}
+
+// Fetch copies the directory at sourcePath of the volume into dir. If isArchive is set, sourcePath is a
+// zip, tar or tar.gz file that is extracted into dir instead.
+func (f *Fetcher) Fetch(dir, location, sourcePath string, isArchive bool, metadataDir string) error {
+	f.Logger.Printf("Copying source from volume %s...", location)
+
+	source := filepath.Join(f.VolumeDir, filepath.FromSlash(sourcePath))
+
+	var (
+		checksum string
+		err      error
+	)
+	if isArchive {
+		checksum, err = extractArchive(source, dir)
+	} else {
+		checksum, err = copyDir(source, dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	projectMetadataFile, err := os.Create(path.Join(metadataDir, "project-metadata.toml"))
+	if err != nil {
+		return fmt.Errorf("invalid metadata destination '%s/project-metadata.toml' for volume '%s': %v", metadataDir, location, err)
+	}
+	defer projectMetadataFile.Close()
+
+	projectMd := Project{
+		Source: Source{
+			Type: "volume",
+			Metadata: Metadata{
+				Volume: location,
+			},
+			Version: Version{
+				SHA256: checksum,
+			},
+		},
+	}
+	if err := toml.NewEncoder(projectMetadataFile).Encode(projectMd); err != nil {
+		return fmt.Errorf("invalid metadata destination '%s/project-metadata.toml' for volume '%s': %v", metadataDir, location, err)
+	}
+
+	f.Logger.Printf("Successfully copied %s in path %q", location, dir)
+
+	return nil
+}
+
+func extractArchive(source, dir string) (string, error) {
+	file, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	header := buf[:n]
+
+	hash := sha256.New()
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, zipMagic):
+		info, err := file.Stat()
+		if err != nil {
+			return "", err
+		}
+		err = archive.ExtractZip(file, info.Size(), dir, 0)
+		if err != nil {
+			return "", err
+		}
+	case bytes.HasPrefix(header, gzipMagic):
+		if err := archive.ExtractTarGZ(file, dir, 0); err != nil {
+			return "", err
+		}
+	case len(header) >= tarMagicOffset+len(tarMagic) && bytes.Equal(header[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic):
+		if err := archive.ExtractTar(file, dir, 0); err != nil {
+			return "", err
+		}
+	default:
+		return "", errUnexpectedArchiveType
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// copyDir copies the contents of source into dir and returns a checksum of the copied paths, modes and contents
+func copyDir(source, dir string) (string, error) {
+	hash := sha256.New()
+
+	err := filepath.WalkDir(source, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		// created by mkfs at the root of most volumes and not readable by the build user
+		if rel == "lost+found" && entry.IsDir() {
+			return filepath.SkipDir
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, rel)
+		fmt.Fprintf(hash, "%s\x00%o\x00", filepath.ToSlash(rel), info.Mode())
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(hash, "%s\x00", link)
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(p, target, info.Mode().Perm(), hash)
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+func copyFile(source, target string, mode os.FileMode, hash hash.Hash) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(io.MultiWriter(out, hash), in)
+	return err
+}
+
+type Project struct {
+	Source Source `toml:"source"`
+}
+
+type Source struct {
+	Type     string   `toml:"type"`
+	Metadata Metadata `toml:"metadata"`
+	Version  Version  `toml:"version"`
+}
+
+type Metadata struct {
+	Volume string `toml:"volume"`
+}
+
+type Version struct {
+	SHA256 string `toml:"sha256sum"`
+}
@@ -0,0 +1,132 @@
+package volume_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/volume"
+)
+
+func TestVolumeFetcher(t *testing.T) {
+	spec.Run(t, "testVolumeFetcher", testVolumeFetcher)
+}
+
+func testVolumeFetcher(t *testing.T, when spec.G, it spec.S) {
+	var (
+		output      = &bytes.Buffer{}
+		fetcher     *volume.Fetcher
+		volumeDir   string
+		dir         string
+		metadataDir string
+	)
+
+	it.Before(func() {
+		volumeDir = t.TempDir()
+		dir = t.TempDir()
+		metadataDir = t.TempDir()
+
+		fetcher = &volume.Fetcher{
+			Logger:    log.New(output, "", 0),
+			VolumeDir: volumeDir,
+		}
+	})
+
+	readMetadata := func() volume.Project {
+		var project volume.Project
+		_, err := toml.DecodeFile(filepath.Join(metadataDir, "project-metadata.toml"), &project)
+		require.NoError(t, err)
+		return project
+	}
+
+	when("copying a directory", func() {
+		it.Before(func() {
+			require.NoError(t, os.MkdirAll(filepath.Join(volumeDir, "some/path/testdir"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(volumeDir, "some/path/testdir/testfile"), []byte("test file contents"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(volumeDir, "some/path/run.sh"), []byte("#!/bin/sh"), 0755))
+			require.NoError(t, os.Symlink("testdir/testfile", filepath.Join(volumeDir, "some/path/link")))
+			require.NoError(t, os.WriteFile(filepath.Join(volumeDir, "other-file"), []byte("other"), 0644))
+		})
+
+		it("copies the path from the volume", func() {
+			err := fetcher.Fetch(dir, "persistentvolumeclaim/some-claim/some/path", "some/path", false, metadataDir)
+			require.NoError(t, err)
+
+			contents, err := os.ReadFile(filepath.Join(dir, "testdir", "testfile"))
+			require.NoError(t, err)
+			require.Equal(t, "test file contents", string(contents))
+
+			info, err := os.Stat(filepath.Join(dir, "run.sh"))
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+			link, err := os.Readlink(filepath.Join(dir, "link"))
+			require.NoError(t, err)
+			require.Equal(t, "testdir/testfile", link)
+
+			require.NoFileExists(t, filepath.Join(dir, "other-file"))
+			require.Contains(t, output.String(), "Successfully copied persistentvolumeclaim/some-claim/some/path")
+		})
+
+		it("writes project metadata with a checksum of the contents", func() {
+			require.NoError(t, fetcher.Fetch(dir, "persistentvolumeclaim/some-claim", "", false, metadataDir))
+			project := readMetadata()
+			require.Equal(t, "volume", project.Source.Type)
+			require.Equal(t, "persistentvolumeclaim/some-claim", project.Source.Metadata.Volume)
+			checksum := project.Source.Version.SHA256
+			require.Len(t, checksum, 64)
+
+			require.NoError(t, fetcher.Fetch(t.TempDir(), "persistentvolumeclaim/some-claim", "", false, metadataDir))
+			require.Equal(t, checksum, readMetadata().Source.Version.SHA256)
+
+			require.NoError(t, os.WriteFile(filepath.Join(volumeDir, "other-file"), []byte("changed"), 0644))
+			require.NoError(t, fetcher.Fetch(t.TempDir(), "persistentvolumeclaim/some-claim", "", false, metadataDir))
+			require.NotEqual(t, checksum, readMetadata().Source.Version.SHA256)
+		})
+
+		it("skips lost+found at the root of the volume", func() {
+			require.NoError(t, os.Mkdir(filepath.Join(volumeDir, "lost+found"), 0700))
+
+			require.NoError(t, fetcher.Fetch(dir, "persistentvolumeclaim/some-claim", "", false, metadataDir))
+			require.NoDirExists(t, filepath.Join(dir, "lost+found"))
+		})
+	})
+
+	when("extracting an archive", func() {
+		for _, f := range []string{"test.zip", "test.tar.gz", "test.tar"} {
+			testFile := f
+			it("extracts "+testFile, func() {
+				archive, err := os.ReadFile(filepath.Join("testdata", testFile))
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(filepath.Join(volumeDir, "source"), archive, 0644))
+
+				err = fetcher.Fetch(dir, "configmap/some-config-map/source", "source", true, metadataDir)
+				require.NoError(t, err)
+
+				contents, err := os.ReadFile(filepath.Join(dir, "testdir", "testfile"))
+				require.NoError(t, err)
+				require.Equal(t, "test file contents", string(contents))
+
+				project := readMetadata()
+				require.Equal(t, "volume", project.Source.Type)
+				require.Equal(t, "configmap/some-config-map/source", project.Source.Metadata.Volume)
+				require.Len(t, project.Source.Version.SHA256, 64)
+			})
+		}
+
+		it("errors when the file is not an archive", func() {
+			text, err := os.ReadFile(filepath.Join("testdata", "test.txt"))
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(volumeDir, "source"), text, 0644))
+
+			err = fetcher.Fetch(dir, "configmap/some-config-map/source", "source", true, metadataDir)
+			require.EqualError(t, err, "unexpected archive file type, must be one of .zip, .tar.gz, .tar")
+		})
+	})
+}
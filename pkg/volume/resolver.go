@@ -0,0 +1,84 @@
+package volume
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// DigestAnnotation must be set on a PersistentVolumeClaim by the process that populates it to identify its contents.
+// The controller cannot read the claim, so claims without the annotation are not resolved.
+const DigestAnnotation = "kpack.io/source-digest"
+
+type Resolver struct {
+	K8sClient k8sclient.Interface
+}
+
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	volume := sourceResolver.Spec.Source.Volume
+
+	var (
+		digest string
+		err    error
+	)
+	if volume.PersistentVolumeClaim != nil {
+		digest, err = r.claimDigest(ctx, sourceResolver.Namespace, volume.PersistentVolumeClaim)
+	} else {
+		digest, err = r.configMapDigest(ctx, sourceResolver.Namespace, volume.ConfigMap)
+	}
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	return corev1alpha1.ResolvedSourceConfig{
+		Volume: &corev1alpha1.ResolvedVolumeSource{
+			PersistentVolumeClaim: volume.PersistentVolumeClaim,
+			ConfigMap:             volume.ConfigMap,
+			SubPath:               sourceResolver.Spec.Source.SubPath,
+			Digest:                digest,
+		},
+	}, nil
+}
+
+func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
+	return sourceResolver.IsVolume()
+}
+
+func (r *Resolver) claimDigest(ctx context.Context, namespace string, source *corev1alpha1.PersistentVolumeClaimSource) (string, error) {
+	pvc, err := r.K8sClient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, source.ClaimName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "getting persistent volume claim '%s'", source.ClaimName)
+	}
+
+	digest := pvc.Annotations[DigestAnnotation]
+	if digest == "" {
+		return "", errors.Errorf("persistent volume claim '%s' does not have the '%s' annotation identifying its contents", source.ClaimName, DigestAnnotation)
+	}
+
+	return digest, nil
+}
+
+func (r *Resolver) configMapDigest(ctx context.Context, namespace string, source *corev1alpha1.ConfigMapSource) (string, error) {
+	configMap, err := r.K8sClient.CoreV1().ConfigMaps(namespace).Get(ctx, source.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "getting config map '%s'", source.Name)
+	}
+
+	var contents []byte
+	if data, ok := configMap.BinaryData[source.Key]; ok {
+		contents = data
+	} else if data, ok := configMap.Data[source.Key]; ok {
+		contents = []byte(data)
+	} else {
+		return "", errors.Errorf("config map '%s' does not contain key '%s'", source.Name, source.Key)
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(contents)), nil
+}
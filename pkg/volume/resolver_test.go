@@ -0,0 +1,127 @@
+package volume_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/volume"
+)
+
+func TestVolumeResolver(t *testing.T) {
+	spec.Run(t, "testVolumeResolver", testVolumeResolver)
+}
+
+func testVolumeResolver(t *testing.T, when spec.G, it spec.S) {
+	const namespace = "some-namespace"
+
+	var (
+		k8sClient = fake.NewSimpleClientset(
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-claim", Namespace: namespace, UID: "some-uid"},
+			},
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "annotated-claim",
+					Namespace:   namespace,
+					UID:         "other-uid",
+					Annotations: map[string]string{volume.DigestAnnotation: "sha256:some-digest"},
+				},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-config-map", Namespace: namespace},
+				Data:       map[string]string{"source.tar": "some-contents"},
+				BinaryData: map[string][]byte{"source.tar.gz": []byte("some-contents")},
+			},
+		)
+
+		resolver = &volume.Resolver{K8sClient: k8sClient}
+	)
+
+	sourceResolver := func(v *corev1alpha1.Volume) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Volume:  v,
+					SubPath: "some-sub-path",
+				},
+			},
+		}
+	}
+
+	it("can resolve volume sources", func() {
+		require.True(t, resolver.CanResolve(sourceResolver(&corev1alpha1.Volume{})))
+		require.False(t, resolver.CanResolve(sourceResolver(nil)))
+	})
+
+	when("persistent volume claim", func() {
+		it("uses the digest annotation", func() {
+			claim := &corev1alpha1.PersistentVolumeClaimSource{ClaimName: "annotated-claim", Path: "some/path"}
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver(&corev1alpha1.Volume{PersistentVolumeClaim: claim}))
+			require.NoError(t, err)
+			require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+				Volume: &corev1alpha1.ResolvedVolumeSource{
+					PersistentVolumeClaim: claim,
+					SubPath:               "some-sub-path",
+					Digest:                "sha256:some-digest",
+				},
+			}, resolved)
+		})
+
+		it("errors when the claim does not have the digest annotation", func() {
+			claim := &corev1alpha1.PersistentVolumeClaimSource{ClaimName: "some-claim"}
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(&corev1alpha1.Volume{PersistentVolumeClaim: claim}))
+			require.EqualError(t, err, `persistent volume claim 'some-claim' does not have the 'kpack.io/source-digest' annotation identifying its contents`)
+		})
+
+		it("errors when the claim does not exist", func() {
+			claim := &corev1alpha1.PersistentVolumeClaimSource{ClaimName: "missing-claim"}
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(&corev1alpha1.Volume{PersistentVolumeClaim: claim}))
+			require.EqualError(t, err, `getting persistent volume claim 'missing-claim': persistentvolumeclaims "missing-claim" not found`)
+		})
+	})
+
+	when("config map", func() {
+		const someContentsDigest = "sha256:6e32ea34db1b3755d7dec972eb72c705338f0dd8e0be881d966963438fb2e800"
+
+		it("uses a digest of the key", func() {
+			configMap := &corev1alpha1.ConfigMapSource{Name: "some-config-map", Key: "source.tar"}
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver(&corev1alpha1.Volume{ConfigMap: configMap}))
+			require.NoError(t, err)
+			require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+				Volume: &corev1alpha1.ResolvedVolumeSource{
+					ConfigMap: configMap,
+					SubPath:   "some-sub-path",
+					Digest:    someContentsDigest,
+				},
+			}, resolved)
+		})
+
+		it("uses a digest of binary data", func() {
+			configMap := &corev1alpha1.ConfigMapSource{Name: "some-config-map", Key: "source.tar.gz"}
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver(&corev1alpha1.Volume{ConfigMap: configMap}))
+			require.NoError(t, err)
+			require.Equal(t, someContentsDigest, resolved.Volume.Digest)
+		})
+
+		it("errors when the key does not exist", func() {
+			configMap := &corev1alpha1.ConfigMapSource{Name: "some-config-map", Key: "missing"}
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(&corev1alpha1.Volume{ConfigMap: configMap}))
+			require.EqualError(t, err, "config map 'some-config-map' does not contain key 'missing'")
+		})
+	})
+}